@@ -14,6 +14,7 @@ import (
 	"meta-api/app/di"
 	"meta-api/app/router"
 	articleService "meta-api/app/service/article"
+	commentService "meta-api/app/service/comment"
 	"meta-api/bootstrap"
 )
 
@@ -57,6 +58,10 @@ func NewApp(bs *bootstrap.Bootstrap) *Application {
 	if err = container.Invoke(func(s articleService.Service) { artSvc = s }); err != nil {
 		bs.Logger.Fatal("failed to resolve article service", zap.Error(err))
 	}
+	var cmtSvc commentService.Service
+	if err = container.Invoke(func(s commentService.Service) { cmtSvc = s }); err != nil {
+		bs.Logger.Fatal("failed to resolve comment service", zap.Error(err))
+	}
 
 	r, err := router.SetUpRouter(bs, container)
 	if err != nil {
@@ -69,12 +74,18 @@ func NewApp(bs *bootstrap.Bootstrap) *Application {
 		http:      httpServer,
 		startupTasks: []startupTask{
 			{name: "warm up article cache", run: artSvc.WarmUpCache},
+			{name: "backfill article content stats", run: artSvc.BackfillArticleContent},
+			{name: "build article search index", run: artSvc.RebuildSearchIndex},
+			{name: "rebuild related articles", run: artSvc.RebuildRelatedArticles},
+			{name: "rescore trending articles", run: artSvc.RescoreTrending},
 		},
 		cronTasks: []cronTask{
 			{name: "register article cron jobs", register: artSvc.RegisterCronJobs},
+			{name: "register comment cron jobs", register: cmtSvc.RegisterCronJobs},
 		},
 		shutdownTasks: []shutdownTask{
 			{name: "persist article view count", run: artSvc.PersistViewCount},
+			{name: "flush article view stats", run: artSvc.FlushViewStats},
 		},
 	}
 }
@@ -14,7 +14,6 @@ import (
 	"meta-api/common/guard/keymanager"
 	"meta-api/config"
 	"meta-api/pkg/cdn"
-	"meta-api/pkg/cos"
 	"meta-api/pkg/sitemap"
 )
 
@@ -52,9 +51,7 @@ func registerBaseProviders(container *dig.Container, bs *bootstrap.Bootstrap) er
 		{name: "redis", constructor: func() *redis.Client { return bs.Redis }},
 		{name: "key manager", constructor: func() *keymanager.Manager { return bs.KeyManager }},
 		{name: "cdn client", constructor: func(logger *zap.Logger) *cdn.Client { return cdn.New(logger, bs.Context()) }},
-		{name: "cos client", constructor: func(cfg *config.Config, logger *zap.Logger) *cos.Client {
-			return cos.New(cfg.ArticleImageCOSSnapshot(), logger)
-		}},
+		{name: "image storage", constructor: newImageStorage},
 		{name: "sitemap client", constructor: func(logger *zap.Logger) *sitemap.Client {
 			return sitemap.New(logger, bs.Context())
 		}},
@@ -6,6 +6,7 @@ import (
 	adminHandler "meta-api/app/handler/admin"
 	articleHandler "meta-api/app/handler/article"
 	commentHandler "meta-api/app/handler/comment"
+	dashboardHandler "meta-api/app/handler/dashboard"
 	jsonshareHandler "meta-api/app/handler/jsonshare"
 	linkHandler "meta-api/app/handler/link"
 	siteDynamicHandler "meta-api/app/handler/sitedynamic"
@@ -24,6 +25,7 @@ import (
 	adminService "meta-api/app/service/admin"
 	articleService "meta-api/app/service/article"
 	commentService "meta-api/app/service/comment"
+	dashboardService "meta-api/app/service/dashboard"
 	jsonshareService "meta-api/app/service/jsonshare"
 	linkService "meta-api/app/service/link"
 	siteDynamicService "meta-api/app/service/sitedynamic"
@@ -50,6 +52,7 @@ func registerServiceProviders(container *dig.Container) error {
 		{name: "admin service", constructor: adminService.NewService},
 		{name: "article service", constructor: articleService.NewService},
 		{name: "comment service", constructor: commentService.NewService},
+		{name: "dashboard service", constructor: dashboardService.NewService},
 		{name: "jsonshare service", constructor: jsonshareService.NewService},
 		{name: "link service", constructor: linkService.NewService},
 		{name: "site dynamic service", constructor: siteDynamicService.NewService},
@@ -65,6 +68,7 @@ func registerHandlerProviders(container *dig.Container) error {
 		{name: "admin handler", constructor: adminHandler.NewHandler},
 		{name: "article handler", constructor: articleHandler.NewHandler},
 		{name: "comment handler", constructor: commentHandler.NewHandler},
+		{name: "dashboard handler", constructor: dashboardHandler.NewHandler},
 		{name: "jsonshare handler", constructor: jsonshareHandler.NewHandler},
 		{name: "link handler", constructor: linkHandler.NewHandler},
 		{name: "site dynamic handler", constructor: siteDynamicHandler.NewHandler},
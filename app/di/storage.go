@@ -0,0 +1,28 @@
+package di
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+
+	"meta-api/config"
+	"meta-api/pkg/cos"
+	"meta-api/pkg/storage"
+)
+
+// newImageStorage 按 article_image.backend 选择文章图片存储后端，未配置时使用 COS
+func newImageStorage(cfg *config.Config, logger *zap.Logger) storage.ObjectStore {
+	imageConfig := cfg.ArticleImageStorageSnapshot()
+	backend := strings.ToLower(strings.TrimSpace(imageConfig.Backend))
+	switch backend {
+	case storage.BackendLocal:
+		return storage.NewLocal(imageConfig.Local, logger)
+	case storage.BackendS3:
+		return storage.NewS3(imageConfig.S3, logger)
+	case "", storage.BackendCOS:
+		return cos.New(imageConfig.COS, logger)
+	default:
+		logger.Warn("unknown article image storage backend, falling back to cos", zap.String("backend", backend))
+		return cos.New(imageConfig.COS, logger)
+	}
+}
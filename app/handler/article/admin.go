@@ -69,7 +69,17 @@ func (a *articleHandler) AdminAddArticle(c *gin.Context) {
 
 	response, err := a.service.AdminAddArticle(ctx, request)
 	if err != nil {
-		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "添加文章失败", Data: nil})
+		code := codes.InternalServerError
+		message := "添加文章失败"
+		switch {
+		case articleService.IsArticleSlugInvalidError(err):
+			code = codes.BadRequest
+			message = "文章地址只能包含小写字母、数字与连字符，且至少包含一个字母"
+		case articleService.IsArticleSlugConflictError(err):
+			code = codes.BadRequest
+			message = "文章地址已被其他文章使用"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
 		return
 	}
 
@@ -93,7 +103,26 @@ func (a *articleHandler) AdminUpdateArticle(c *gin.Context) {
 
 	response, err := a.service.AdminUpdateArticle(ctx, request)
 	if err != nil {
-		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "更新文章失败", Data: nil})
+		// 版本冲突：返回服务端当前内容，由编辑器提示合并或覆盖
+		if conflict, ok := articleService.AsArticleVersionConflictError(err); ok {
+			c.JSON(http.StatusOK, types.Response{Code: codes.Conflict, Message: "文章已被其他页面修改",
+				Data: conflict.Current})
+			return
+		}
+		code := codes.InternalServerError
+		message := "更新文章失败"
+		switch {
+		case articleService.IsArticleScheduleInvalidError(err):
+			code = codes.BadRequest
+			message = "定时时间无效"
+		case articleService.IsArticleSlugInvalidError(err):
+			code = codes.BadRequest
+			message = "文章地址只能包含小写字母、数字与连字符，且至少包含一个字母"
+		case articleService.IsArticleSlugConflictError(err):
+			code = codes.BadRequest
+			message = "文章地址已被其他文章使用"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
 		return
 	}
 
@@ -173,8 +202,19 @@ func (a *articleHandler) AdminSaveArticleDraft(c *gin.Context) {
 
 	response, err := a.service.AdminSaveArticleDraft(ctx, request)
 	if err != nil {
+		if conflict, ok := articleService.AsArticleVersionConflictError(err); ok {
+			c.JSON(http.StatusOK, types.Response{Code: codes.Conflict, Message: "草稿已被其他页面修改",
+				Data: conflict.Current})
+			return
+		}
 		a.logger.Error("save article draft failed", zap.Error(err))
-		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "保存草稿失败", Data: nil})
+		code := codes.InternalServerError
+		message := "保存草稿失败"
+		if articleService.IsArticleScheduleInvalidError(err) {
+			code = codes.BadRequest
+			message = "定时时间无效"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
 		return
 	}
 	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
@@ -198,7 +238,13 @@ func (a *articleHandler) AdminPublishArticleDraft(c *gin.Context) {
 	response, err := a.service.AdminPublishArticleDraft(ctx, request)
 	if err != nil {
 		a.logger.Error("publish article draft failed", zap.Error(err))
-		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "发布草稿失败", Data: nil})
+		code := codes.InternalServerError
+		message := "发布草稿失败"
+		if articleService.IsArticleScheduleInvalidError(err) {
+			code = codes.BadRequest
+			message = "定时时间无效"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
 		return
 	}
 	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
@@ -319,7 +365,7 @@ func (a *articleHandler) AdminGetArticleImageDetail(c *gin.Context) {
 	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
 }
 
-// AdminDeleteArticleImage 删除 COS 图片和本地资产记录。
+// AdminDeleteArticleImage 把文章图片移入回收站。
 func (a *articleHandler) AdminDeleteArticleImage(c *gin.Context) {
 	ctx := c.Request.Context()
 	request := new(types.AdminDeleteArticleImageRequest)
@@ -0,0 +1,106 @@
+package article
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	articleService "meta-api/app/service/article"
+	"meta-api/common/codes"
+	"meta-api/common/constants"
+	"meta-api/common/types"
+)
+
+// AdminExportArticle 导出已发布文章为 zip 归档，可按标签筛选。
+func (a *articleHandler) AdminExportArticle(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminExportArticleRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminExportArticles(ctx, request)
+	if err != nil {
+		code := codes.InternalServerError
+		message := "导出文章失败"
+		if articleService.IsArticleExportTagNotFoundError(err) {
+			code = codes.NotFound
+			message = "标签不存在"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+response.FileName+`"`)
+	c.Data(http.StatusOK, "application/zip", response.Body)
+}
+
+// AdminImportArticle 导入 zip 归档中的 Markdown 文章为草稿。
+func (a *articleHandler) AdminImportArticle(c *gin.Context) {
+	ctx := c.Request.Context()
+	if err := c.Request.ParseMultipartForm(constants.MaxArticleZipSize); err != nil {
+		a.logger.Warn("article archive multipart parse error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "归档上传参数无效", Data: nil})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		a.logger.Warn("article archive file missing", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "请选择要导入的zip文件", Data: nil})
+		return
+	}
+	if !strings.EqualFold(filepath.Ext(fileHeader.Filename), ".zip") {
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "仅支持导入zip文件", Data: nil})
+		return
+	}
+	if fileHeader.Size <= 0 || fileHeader.Size > constants.MaxArticleZipSize {
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "归档大小不能超过32MB", Data: nil})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		a.logger.Error("article archive open error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "读取归档失败", Data: nil})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, constants.MaxArticleZipSize+1))
+	if err != nil {
+		a.logger.Error("article archive read error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "读取归档失败", Data: nil})
+		return
+	}
+	if int64(len(content)) > constants.MaxArticleZipSize {
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "归档大小不能超过32MB", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminImportArticles(ctx, content)
+	if err != nil {
+		a.logger.Warn("article archive import failed", zap.Error(err))
+		code := codes.InternalServerError
+		message := "导入文章失败"
+		switch {
+		case articleService.IsArticleArchiveInvalidError(err):
+			code = codes.BadRequest
+			message = "无效的文章归档"
+		case errors.Is(err, ctx.Err()):
+			code = codes.BadRequest
+			message = "导入已取消"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
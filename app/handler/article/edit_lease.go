@@ -0,0 +1,72 @@
+package article
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"meta-api/common/codes"
+	"meta-api/common/types"
+)
+
+// AdminAcquireArticleEditLease 获取文章或草稿的编辑租约，被其他会话持有时返回持有者。
+func (a *articleHandler) AdminAcquireArticleEditLease(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminArticleEditLeaseRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminAcquireArticleEditLease(ctx, c.GetString("userID"), request)
+	if err != nil {
+		if strings.Contains(err.Error(), "record not found") {
+			c.JSON(http.StatusOK, types.Response{Code: codes.NotFound, Message: "文章不存在", Data: nil})
+			return
+		}
+		a.logger.Error("acquire article edit lease failed", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取编辑状态失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminRenewArticleEditLease 续期编辑租约。
+func (a *articleHandler) AdminRenewArticleEditLease(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminArticleEditLeaseRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminRenewArticleEditLease(ctx, request)
+	if err != nil {
+		a.logger.Error("renew article edit lease failed", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "续期编辑状态失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminReleaseArticleEditLease 释放编辑租约。
+func (a *articleHandler) AdminReleaseArticleEditLease(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminArticleEditLeaseRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminReleaseArticleEditLease(ctx, request); err != nil {
+		a.logger.Error("release article edit lease failed", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "释放编辑状态失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
@@ -13,21 +13,56 @@ type Handler interface {
 	AdminAddArticle(c *gin.Context)
 	AdminUpdateArticle(c *gin.Context)
 	AdminDeleteArticle(c *gin.Context)
+	AdminGetArticleTrashList(c *gin.Context)
+	AdminRestoreArticle(c *gin.Context)
+	AdminPurgeArticle(c *gin.Context)
 	AdminGetArticleDraftList(c *gin.Context)
 	AdminGetArticleDraftDetail(c *gin.Context)
 	AdminSaveArticleDraft(c *gin.Context)
 	AdminPublishArticleDraft(c *gin.Context)
 	AdminDeleteArticleDraft(c *gin.Context)
+	AdminAcquireArticleEditLease(c *gin.Context)
+	AdminRenewArticleEditLease(c *gin.Context)
+	AdminReleaseArticleEditLease(c *gin.Context)
+	AdminGetArticleRevisionList(c *gin.Context)
+	AdminGetArticleRevisionDiff(c *gin.Context)
+	AdminRestoreArticleRevision(c *gin.Context)
 	AdminUploadArticleImage(c *gin.Context)
+	AdminExportArticle(c *gin.Context)
+	AdminImportArticle(c *gin.Context)
+	AdminGetArticleViewStats(c *gin.Context)
+	AdminGetSiteViewStats(c *gin.Context)
 	AdminGetArticleImageList(c *gin.Context)
 	AdminGetArticleImageDetail(c *gin.Context)
 	AdminDeleteArticleImage(c *gin.Context)
+	AdminGetArticleImageTrashList(c *gin.Context)
+	AdminRestoreArticleImage(c *gin.Context)
+	AdminPurgeArticleImage(c *gin.Context)
+	AdminGetArticleImageGCReport(c *gin.Context)
+	AdminGetSeriesList(c *gin.Context)
+	AdminGetSeriesDetail(c *gin.Context)
+	AdminAddSeries(c *gin.Context)
+	AdminUpdateSeries(c *gin.Context)
+	AdminUpdateSeriesArticles(c *gin.Context)
+	AdminDeleteSeries(c *gin.Context)
+	AdminGetArticlePromotion(c *gin.Context)
+	AdminUpdateArticlePromotion(c *gin.Context)
+	AdminUpdateArticleVisibility(c *gin.Context)
 
 	UserGetArticleList(c *gin.Context)
 	UserGetArticleDetail(c *gin.Context)
+	UserUnlockArticle(c *gin.Context)
 	UserSearchArticle(c *gin.Context)
 	UserGetHotArticle(c *gin.Context)
+	UserGetTrendingArticle(c *gin.Context)
+	UserGetFeaturedArticle(c *gin.Context)
+	UserGetRelatedArticle(c *gin.Context)
 	UserGetTimeline(c *gin.Context)
+	UserGetArticleArchive(c *gin.Context)
+	UserGetArticleArchiveMonth(c *gin.Context)
+	UserGetArticleFeed(c *gin.Context)
+	UserGetSeriesList(c *gin.Context)
+	UserGetSeriesDetail(c *gin.Context)
 }
 
 type articleHandler struct {
@@ -0,0 +1,30 @@
+package article
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"meta-api/common/codes"
+	"meta-api/common/types"
+)
+
+// AdminGetArticleImageGCReport 预览未引用图片回收结果，不删除任何图片。
+func (a *articleHandler) AdminGetArticleImageGCReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetArticleImageGCReportRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminGetArticleImageGCReport(ctx, request)
+	if err != nil {
+		a.logger.Error("get article image gc report failed", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取图片回收预览失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
@@ -0,0 +1,68 @@
+package article
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	articleService "meta-api/app/service/article"
+	"meta-api/common/codes"
+	"meta-api/common/types"
+)
+
+// AdminGetArticlePromotion 获取置顶或精选文章
+func (a *articleHandler) AdminGetArticlePromotion(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetArticlePromotionRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminGetArticlePromotions(ctx, request)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取推荐文章失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminUpdateArticlePromotion 调整置顶或精选文章及顺序
+func (a *articleHandler) AdminUpdateArticlePromotion(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminUpdateArticlePromotionRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminUpdateArticlePromotions(ctx, request); err != nil {
+		code := codes.InternalServerError
+		message := "更新推荐文章失败"
+		switch {
+		case articleService.IsPromotionLimitExceededError(err):
+			code = codes.BadRequest
+			message = "推荐文章数量超过上限"
+		case articleService.IsPromotionArticleNotPublishedError(err):
+			code = codes.BadRequest
+			message = "文章不存在或未发布"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
+
+// UserGetFeaturedArticle 获取精选文章
+func (a *articleHandler) UserGetFeaturedArticle(c *gin.Context) {
+	ctx := c.Request.Context()
+	response, err := a.service.UserGetFeaturedArticle(ctx)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取精选文章失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
@@ -0,0 +1,87 @@
+package article
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	articleService "meta-api/app/service/article"
+	"meta-api/common/codes"
+	"meta-api/common/types"
+)
+
+// AdminGetArticleRevisionList 获取文章历史版本列表。
+func (a *articleHandler) AdminGetArticleRevisionList(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetArticleRevisionListRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminGetArticleRevisionList(ctx, request)
+	if err != nil {
+		a.logger.Error("get article revision list failed", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取历史版本失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminGetArticleRevisionDiff 对比两个历史版本。
+func (a *articleHandler) AdminGetArticleRevisionDiff(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetArticleRevisionDiffRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminGetArticleRevisionDiff(ctx, request)
+	if err != nil {
+		a.logger.Error("get article revision diff failed", zap.Error(err))
+		code := codes.InternalServerError
+		message := "对比历史版本失败"
+		if articleService.IsArticleRevisionNotFoundError(err) {
+			code = codes.NotFound
+			message = "历史版本不存在"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminRestoreArticleRevision 将历史版本恢复为草稿。
+func (a *articleHandler) AdminRestoreArticleRevision(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminRestoreArticleRevisionRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminRestoreArticleRevision(ctx, request)
+	if err != nil {
+		// 恢复期间草稿被其他页面修改
+		if conflict, ok := articleService.AsArticleVersionConflictError(err); ok {
+			c.JSON(http.StatusOK, types.Response{Code: codes.Conflict, Message: "草稿已被其他页面修改",
+				Data: conflict.Current})
+			return
+		}
+		a.logger.Error("restore article revision failed", zap.Error(err))
+		code := codes.InternalServerError
+		message := "恢复历史版本失败"
+		if articleService.IsArticleRevisionNotFoundError(err) {
+			code = codes.NotFound
+			message = "历史版本不存在"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
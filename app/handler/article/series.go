@@ -0,0 +1,181 @@
+package article
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	articleService "meta-api/app/service/article"
+	"meta-api/common/codes"
+	"meta-api/common/types"
+)
+
+// AdminGetSeriesList 获取系列列表
+func (a *articleHandler) AdminGetSeriesList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	response, err := a.service.AdminGetSeriesList(ctx)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取系列列表失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminGetSeriesDetail 获取系列详情
+func (a *articleHandler) AdminGetSeriesDetail(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetSeriesDetailRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminGetSeriesDetail(ctx, request)
+	if err != nil {
+		code := codes.InternalServerError
+		message := "获取系列详情失败"
+		if articleService.IsSeriesNotFoundError(err) {
+			code = codes.NotFound
+			message = "系列不存在"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminAddSeries 创建系列
+func (a *articleHandler) AdminAddSeries(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminAddSeriesRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminAddSeries(ctx, request)
+	if err != nil {
+		code, message := seriesArticlesErrorResponse(err, "创建系列失败")
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminUpdateSeries 修改系列标题与简介
+func (a *articleHandler) AdminUpdateSeries(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminUpdateSeriesRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminUpdateSeries(ctx, request); err != nil {
+		code := codes.InternalServerError
+		message := "更新系列失败"
+		if articleService.IsSeriesNotFoundError(err) {
+			code = codes.NotFound
+			message = "系列不存在"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
+
+// AdminUpdateSeriesArticles 调整系列成员及顺序
+func (a *articleHandler) AdminUpdateSeriesArticles(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminUpdateSeriesArticlesRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminUpdateSeriesArticles(ctx, request); err != nil {
+		code, message := seriesArticlesErrorResponse(err, "更新系列文章失败")
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
+
+// AdminDeleteSeries 删除系列
+func (a *articleHandler) AdminDeleteSeries(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminDeleteSeriesRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminDeleteSeries(ctx, request); err != nil {
+		code := codes.InternalServerError
+		message := "删除系列失败"
+		if articleService.IsSeriesNotFoundError(err) {
+			code = codes.NotFound
+			message = "系列不存在"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
+
+// UserGetSeriesList 前台获取系列列表
+func (a *articleHandler) UserGetSeriesList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	response, err := a.service.UserGetSeriesList(ctx)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取系列列表失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// UserGetSeriesDetail 前台获取系列详情及文章目录
+func (a *articleHandler) UserGetSeriesDetail(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.UserGetSeriesDetailRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.UserGetSeriesDetail(ctx, request)
+	if err != nil {
+		code := codes.InternalServerError
+		message := "获取系列详情失败"
+		if articleService.IsSeriesNotFoundError(err) {
+			code = codes.NotFound
+			message = "系列不存在"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// seriesArticlesErrorResponse 写入系列成员时的错误映射
+func seriesArticlesErrorResponse(err error, fallback string) (int, string) {
+	switch {
+	case articleService.IsSeriesNotFoundError(err):
+		return codes.NotFound, "系列不存在"
+	case articleService.IsSeriesArticleConflictError(err):
+		return codes.BadRequest, "文章已属于其他系列"
+	case articleService.IsSeriesArticleNotPublishedError(err):
+		return codes.BadRequest, "文章不存在或未发布"
+	default:
+		return codes.InternalServerError, fallback
+	}
+}
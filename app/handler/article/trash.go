@@ -0,0 +1,147 @@
+package article
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	articleService "meta-api/app/service/article"
+	"meta-api/common/codes"
+	"meta-api/common/types"
+)
+
+// AdminGetArticleTrashList 获取回收站文章列表。
+func (a *articleHandler) AdminGetArticleTrashList(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetArticleTrashListRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminGetArticleTrashList(ctx, request)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取回收站文章失败", Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminRestoreArticle 从回收站恢复文章。
+func (a *articleHandler) AdminRestoreArticle(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminRestoreArticleRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminRestoreArticle(ctx, request); err != nil {
+		code := codes.InternalServerError
+		message := "恢复文章失败"
+		if articleService.IsArticleTrashNotFoundError(err) {
+			code = codes.NotFound
+			message = "回收站中不存在该文章"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
+
+// AdminPurgeArticle 彻底删除回收站中的文章。
+func (a *articleHandler) AdminPurgeArticle(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminPurgeArticleRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminPurgeArticle(ctx, request); err != nil {
+		code := codes.InternalServerError
+		message := "彻底删除文章失败"
+		if articleService.IsArticleTrashNotFoundError(err) {
+			code = codes.NotFound
+			message = "回收站中不存在该文章"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
+
+// AdminGetArticleImageTrashList 获取回收站图片列表。
+func (a *articleHandler) AdminGetArticleImageTrashList(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetArticleImageTrashListRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminGetArticleImageTrashList(ctx, request)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取回收站图片失败", Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminRestoreArticleImage 从回收站恢复图片。
+func (a *articleHandler) AdminRestoreArticleImage(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminRestoreArticleImageRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminRestoreArticleImage(ctx, request); err != nil {
+		code := codes.InternalServerError
+		message := "恢复图片失败"
+		if articleService.IsArticleTrashNotFoundError(err) {
+			code = codes.NotFound
+			message = "回收站中不存在该图片"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
+
+// AdminPurgeArticleImage 彻底删除回收站中的图片及其 COS 对象。
+func (a *articleHandler) AdminPurgeArticleImage(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminPurgeArticleImageRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminPurgeArticleImage(ctx, request); err != nil {
+		a.logger.Warn("purge article image failed", zap.Error(err))
+		code := codes.InternalServerError
+		message := "彻底删除图片失败"
+		if articleService.IsArticleTrashNotFoundError(err) {
+			code = codes.NotFound
+			message = "回收站中不存在该图片"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
@@ -7,8 +7,12 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	articleService "meta-api/app/service/article"
+	"meta-api/app/service/article/feed"
 	"meta-api/common/codes"
+	"meta-api/common/middlewares"
 	"meta-api/common/types"
+	"meta-api/common/utils"
 )
 
 // UserGetArticleList 获取文章列表
@@ -39,8 +43,22 @@ func (a *articleHandler) UserGetArticleDetail(c *gin.Context) { // ignore_securi
 		return
 	}
 
+	request.AccessTokens = utils.ArticleAccessTokens(c)
 	response, err := a.service.UserGetArticleDetail(ctx, request) // ignore_security_alert
 	if err != nil {
+		// 旧 slug：返回新地址，由前端做 301 重定向
+		if moved, ok := articleService.AsArticleSlugMovedError(err); ok {
+			c.JSON(http.StatusOK, types.Response{Code: codes.MovedPermanently, Message: "文章地址已变更",
+				Data: &types.UserArticleMovedResponse{ID: moved.ID, Slug: moved.Slug}})
+			return
+		}
+		// 加密文章：只返回标题，由前端展示密码输入框
+		if protected, ok := articleService.AsArticleProtectedError(err); ok {
+			middlewares.SetPrivateResponse(c)
+			c.JSON(http.StatusOK, types.Response{Code: codes.Forbidden, Message: "文章需要密码访问",
+				Data: &types.UserArticleProtectedResponse{ID: protected.ID, Title: protected.Title}})
+			return
+		}
 		if strings.Contains(err.Error(), "record not found") {
 			c.JSON(http.StatusOK, types.Response{Code: codes.NotFound, Message: "文章不存在", Data: nil})
 			return
@@ -48,6 +66,9 @@ func (a *articleHandler) UserGetArticleDetail(c *gin.Context) { // ignore_securi
 		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取文章详情失败", Data: nil})
 		return
 	}
+	if response.Protected {
+		middlewares.SetPrivateResponse(c)
+	}
 	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
 }
 
@@ -81,6 +102,42 @@ func (a *articleHandler) UserGetHotArticle(c *gin.Context) {
 	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
 }
 
+// UserGetTrendingArticle 获取趋势文章
+func (a *articleHandler) UserGetTrendingArticle(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.UserGetTrendingArticleRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.UserGetTrendingArticle(ctx, request)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取趋势文章失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// UserGetRelatedArticle 获取相关文章
+func (a *articleHandler) UserGetRelatedArticle(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.UserGetRelatedArticleRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.UserGetRelatedArticle(ctx, request)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取相关文章失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
 // UserGetTimeline 获取文章归档
 func (a *articleHandler) UserGetTimeline(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -91,3 +148,89 @@ func (a *articleHandler) UserGetTimeline(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
 }
+
+// UserGetArticleArchive 获取按年月分组的归档目录及各月文章数
+func (a *articleHandler) UserGetArticleArchive(c *gin.Context) {
+	ctx := c.Request.Context()
+	response, err := a.service.UserGetArticleArchive(ctx)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取文章归档失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// UserGetArticleArchiveMonth 分页获取某月的归档文章
+func (a *articleHandler) UserGetArticleArchiveMonth(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.UserGetArticleArchiveMonthRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.UserGetArticleArchiveMonth(ctx, request)
+	if err != nil {
+		if articleService.IsArticleArchiveMonthOutOfRangeError(err) {
+			c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "归档月份超出范围", Data: nil})
+			return
+		}
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取归档文章列表失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// UserGetArticleFeed 获取 RSS / Atom / JSON Feed 订阅源，支持 If-None-Match / If-Modified-Since 条件请求
+func (a *articleHandler) UserGetArticleFeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.UserGetArticleFeedRequest)
+	if err := c.ShouldBindUri(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的订阅格式", Data: nil})
+		return
+	}
+	if err := c.ShouldBindQuery(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+	request.Origin = requestOrigin(c)
+
+	response, err := a.service.UserGetArticleFeed(ctx, request)
+	if err != nil {
+		if articleService.IsArticleFeedTagNotFoundError(err) {
+			c.JSON(http.StatusOK, types.Response{Code: codes.NotFound, Message: "标签不存在", Data: nil})
+			return
+		}
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取订阅源失败", Data: nil})
+		return
+	}
+
+	c.Header("ETag", response.ETag)
+	c.Header("Last-Modified", response.LastModified.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", "public, max-age=300")
+	if feed.NotModified(c.GetHeader("If-None-Match"), c.GetHeader("If-Modified-Since"),
+		response.ETag, response.LastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, response.ContentType, response.Body)
+}
+
+// requestOrigin 按请求推导站点来源（scheme://host），兼容反向代理转发头
+func requestOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := strings.TrimSpace(strings.Split(c.GetHeader("X-Forwarded-Proto"), ",")[0]); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	host := c.Request.Host
+	if forwarded := strings.TrimSpace(strings.Split(c.GetHeader("X-Forwarded-Host"), ",")[0]); forwarded != "" {
+		host = forwarded
+	}
+	return scheme + "://" + host
+}
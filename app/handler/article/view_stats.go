@@ -0,0 +1,56 @@
+package article
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	articleService "meta-api/app/service/article"
+	"meta-api/common/codes"
+	"meta-api/common/types"
+)
+
+// AdminGetArticleViewStats 获取单篇文章按天 / 按小时的浏览量序列。
+func (a *articleHandler) AdminGetArticleViewStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetArticleViewStatsRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminGetArticleViewStats(ctx, request)
+	if err != nil {
+		code := codes.InternalServerError
+		message := "获取文章浏览量统计失败"
+		if articleService.IsArticleViewStatsNotFoundError(err) {
+			code = codes.NotFound
+			message = "文章不存在"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+// AdminGetSiteViewStats 获取全站按天 / 按小时的浏览量序列。
+func (a *articleHandler) AdminGetSiteViewStats(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetSiteViewStatsRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := a.service.AdminGetSiteViewStats(ctx, request)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取全站浏览量统计失败", Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
@@ -0,0 +1,77 @@
+package article
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	articleService "meta-api/app/service/article"
+	"meta-api/common/codes"
+	"meta-api/common/ratelimit"
+	"meta-api/common/types"
+	"meta-api/common/utils"
+)
+
+// AdminUpdateArticleVisibility 修改文章可见性（公开 / 不公开 / 密码访问）
+func (a *articleHandler) AdminUpdateArticleVisibility(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminUpdateArticleVisibilityRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := a.service.AdminUpdateArticleVisibility(ctx, request); err != nil {
+		code := codes.InternalServerError
+		message := "修改文章可见性失败"
+		switch {
+		case articleService.IsArticlePasswordRequiredError(err):
+			code = codes.BadRequest
+			message = "请设置访问密码"
+		case strings.Contains(err.Error(), "record not found"):
+			code = codes.NotFound
+			message = "文章不存在或未发布"
+		}
+		c.JSON(http.StatusOK, types.Response{Code: code, Message: message, Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
+
+// UserUnlockArticle 输入密码解锁加密文章，访问凭证写入 Cookie
+func (a *articleHandler) UserUnlockArticle(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.UserUnlockArticleRequest)
+	if err := c.ShouldBind(request); err != nil {
+		a.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+	request.ClientIP = c.ClientIP()
+
+	response, err := a.service.UserUnlockArticle(ctx, request)
+	if err != nil {
+		if limited, ok := ratelimit.AsLimited(err); ok {
+			c.JSON(http.StatusOK, types.Response{
+				Code:    codes.TooManyRequests,
+				Message: limited.Error(),
+				Data:    types.RetryAfterResponse{RetryAfter: limited.RetryAfterSeconds()},
+			})
+			return
+		}
+		switch {
+		case articleService.IsArticlePasswordWrongError(err):
+			c.JSON(http.StatusOK, types.Response{Code: codes.Forbidden, Message: "密码错误", Data: nil})
+		case articleService.IsArticleNotProtectedError(err), strings.Contains(err.Error(), "record not found"):
+			c.JSON(http.StatusOK, types.Response{Code: codes.NotFound, Message: "文章不存在", Data: nil})
+		default:
+			c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "解锁文章失败", Data: nil})
+		}
+		return
+	}
+	utils.SetArticleAccessCookie(c, response.ID, response.Token)
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
@@ -17,6 +17,9 @@ type Handler interface {
 	AdminGetCommentList(c *gin.Context)
 	AdminUpdateCommentStatus(c *gin.Context)
 	AdminDeleteComment(c *gin.Context)
+	AdminGetCommentTrashList(c *gin.Context)
+	AdminRestoreComment(c *gin.Context)
+	AdminPurgeComment(c *gin.Context)
 	AdminPreviewCommentModeration(c *gin.Context)
 	AdminGetCommentReportList(c *gin.Context)
 	AdminHandleCommentReport(c *gin.Context)
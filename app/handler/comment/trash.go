@@ -0,0 +1,78 @@
+package comment
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commentService "meta-api/app/service/comment"
+	"meta-api/common/codes"
+	"meta-api/common/types"
+)
+
+func (h *commentHandler) AdminGetCommentTrashList(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetCommentTrashListRequest)
+	if err := c.ShouldBind(request); err != nil {
+		h.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := h.service.AdminGetCommentTrashList(ctx, request)
+	if err != nil {
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取回收站评论失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
+
+func (h *commentHandler) AdminRestoreComment(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminRestoreCommentRequest)
+	if err := c.ShouldBind(request); err != nil {
+		h.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := h.service.AdminRestoreComment(ctx, request); err != nil {
+		if errors.Is(err, commentService.ErrInvalidComment) {
+			c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+			return
+		}
+		if errors.Is(err, commentService.ErrCommentNotFound) {
+			c.JSON(http.StatusOK, types.Response{Code: codes.NotFound, Message: "回收站中不存在该评论", Data: nil})
+			return
+		}
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "恢复评论失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
+
+func (h *commentHandler) AdminPurgeComment(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminPurgeCommentRequest)
+	if err := c.ShouldBind(request); err != nil {
+		h.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	if err := h.service.AdminPurgeComment(ctx, request); err != nil {
+		if errors.Is(err, commentService.ErrInvalidComment) {
+			c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+			return
+		}
+		if errors.Is(err, commentService.ErrCommentNotFound) {
+			c.JSON(http.StatusOK, types.Response{Code: codes.NotFound, Message: "回收站中不存在该评论", Data: nil})
+			return
+		}
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "彻底删除评论失败", Data: nil})
+		return
+	}
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: nil})
+}
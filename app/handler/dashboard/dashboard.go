@@ -0,0 +1,36 @@
+package dashboard
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	dashboardService "meta-api/app/service/dashboard"
+	"meta-api/common/codes"
+	"meta-api/common/types"
+)
+
+// AdminGetDashboard 获取数据看板的指标合计与按天趋势
+func (d *dashboardHandler) AdminGetDashboard(c *gin.Context) {
+	ctx := c.Request.Context()
+	request := new(types.AdminGetDashboardRequest)
+	if err := c.ShouldBind(request); err != nil {
+		d.logger.Warn("parameter binding error", zap.Error(err))
+		c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的请求参数", Data: nil})
+		return
+	}
+
+	response, err := d.service.AdminGetDashboard(ctx, request)
+	if err != nil {
+		if errors.Is(err, dashboardService.ErrInvalidDashboardRange) {
+			c.JSON(http.StatusOK, types.Response{Code: codes.BadRequest, Message: "无效的统计时间范围", Data: nil})
+			return
+		}
+		c.JSON(http.StatusOK, types.Response{Code: codes.InternalServerError, Message: "获取数据看板失败", Data: nil})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.Response{Code: codes.Success, Message: "", Data: response})
+}
@@ -0,0 +1,24 @@
+package dashboard
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"meta-api/app/service/dashboard"
+)
+
+type Handler interface {
+	AdminGetDashboard(c *gin.Context)
+}
+
+type dashboardHandler struct {
+	logger  *zap.Logger
+	service dashboard.Service
+}
+
+func NewHandler(logger *zap.Logger, service dashboard.Service) Handler {
+	return &dashboardHandler{
+		logger:  logger,
+		service: service,
+	}
+}
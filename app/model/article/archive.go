@@ -0,0 +1,37 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExportArticleRecord 导出归档所需的文章字段
+type ExportArticleRecord struct {
+	ID         uint64    `gorm:"column:id"`
+	Title      string    `gorm:"column:title"`
+	Describe   string    `gorm:"column:describe"`
+	Content    string    `gorm:"column:content"`
+	Slug       string    `gorm:"column:slug"`
+	CreateTime time.Time `gorm:"column:create_time"`
+	UpdateTime time.Time `gorm:"column:update_time"`
+}
+
+// ListExportArticles 已发布文章，tagName 非空时只取该标签下的文章，按发布时间正序
+func (a *articleModel) ListExportArticles(ctx context.Context, tagName string) ([]ExportArticleRecord, error) {
+	query := a.mysql.WithContext(ctx).Model(&Article{}).
+		Select("article.id, article.title, article.`describe`, article.content, IFNULL(article.slug, '') AS slug, "+
+			"article.create_time, article.update_time").
+		Where("article.status = ?", ArticleStatusPublished)
+	if tagName != "" {
+		query = query.
+			Joins("JOIN article_tag ON article_tag.article_id = article.id").
+			Joins("JOIN tag ON tag.id = article_tag.tag_id").
+			Where("tag.name = ?", tagName)
+	}
+	rows := make([]ExportArticleRecord, 0)
+	if err := query.Order("article.create_time ASC").Order("article.id ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list export articles: %w", err)
+	}
+	return rows, nil
+}
@@ -8,7 +8,6 @@ import (
 
 	"gorm.io/gorm"
 
-	"meta-api/app/model/tag"
 	"meta-api/common/constants"
 	"meta-api/common/utils"
 )
@@ -21,26 +20,78 @@ type Article struct {
 	ViewNum       uint64     `gorm:"NOT NULL;default:0"`
 	Status        string     `gorm:"column:status;type:varchar(20);NOT NULL;default:published;index"`
 	PublishedID   *uint64    `gorm:"column:published_id;uniqueIndex"`
-	PublishedTime *time.Time `gorm:"column:published_time"`
+	PublishedTime *time.Time `gorm:"column:published_time;index"`
+	PublishAt     *time.Time `gorm:"column:publish_at;index"`   // 草稿定时发布时间
+	UnpublishAt   *time.Time `gorm:"column:unpublish_at;index"` // 定时下线时间
 	CreateTime    time.Time  `gorm:"NOT NULL"`
 	UpdateTime    time.Time  `gorm:"NOT NULL"`
-	TagID         *uint64    `gorm:"column:tag_id;index"`
-	Tag           tag.Tag    `gorm:"foreignKey:TagID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	// Slug 自定义地址，NULL 表示未设置。编辑草稿不设置 slug，不占用唯一索引；
+	// 已发布文章下线为草稿时保留，重新发布后恢复原地址
+	Slug *string `gorm:"column:slug;type:varchar(100);uniqueIndex"`
+	// 以下为发布/更新时由内容处理流程从 Content 派生的字段
+	TOC         string `gorm:"column:toc;type:text;NOT NULL"`              // 标题目录树（JSON）
+	PlainText   string `gorm:"column:plain_text;type:mediumtext;NOT NULL"` // 去除标记后的纯文本，用于搜索与摘要
+	WordCount   int    `gorm:"column:word_count;NOT NULL;default:0"`       // 字数：中日韩文字按字计，其他按词计
+	CharCount   int    `gorm:"column:char_count;NOT NULL;default:0"`       // 不含空白的字符数
+	ReadingTime int    `gorm:"column:reading_time;NOT NULL;default:0"`     // 预计阅读时长（分钟）
+	// Visibility 已发布文章的可见性，只影响前台列表与访问，与草稿/发布状态相互独立
+	Visibility string `gorm:"column:visibility;type:varchar(20);NOT NULL;default:public"`
+	// AccessPassword 密码保护文章的访问密码（bcrypt），其他可见性下为空
+	AccessPassword string `gorm:"column:access_password;type:varchar(100);NOT NULL;default:''"`
+	// Version 乐观锁版本号，更新文章、保存草稿与草稿覆盖发布时加一，保存时据此发现并发修改
+	Version uint64 `gorm:"column:version;NOT NULL;default:1"`
+	// DeletedAt 移入回收站的时间，非空时常规查询自动过滤；超过保留期后由定时任务彻底删除
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+	// TagIDs 文章标签（按填写顺序），存储在 article_tag 关联表中，由各写入方法在同一事务内同步
+	TagIDs []uint64 `gorm:"-"`
 }
 
 type Detail struct {
-	ID            uint64     `gorm:"column:id" json:"id"`
-	Title         string     `gorm:"column:title" json:"title"`
-	Describe      string     `gorm:"column:describe" json:"describe"`
-	Content       string     `gorm:"column:content" json:"content"`
-	ViewNum       uint64     `gorm:"column:view_num" json:"viewNum"`
-	Status        string     `gorm:"column:status" json:"status"`
-	PublishedID   *uint64    `gorm:"column:published_id" json:"publishedID"`
-	PublishedTime *time.Time `gorm:"column:published_time" json:"publishedTime"`
-	CreateTime    time.Time  `gorm:"column:create_time" json:"createTime"`
-	UpdateTime    time.Time  `gorm:"column:update_time" json:"updateTime"`
-	TagID         *uint64    `gorm:"column:tag_id" json:"tagID"`
-	TagName       string     `gorm:"column:tag_name" json:"tagName"`
+	ID             uint64     `gorm:"column:id" json:"id"`
+	Title          string     `gorm:"column:title" json:"title"`
+	Describe       string     `gorm:"column:describe" json:"describe"`
+	Content        string     `gorm:"column:content" json:"content"`
+	ViewNum        uint64     `gorm:"column:view_num" json:"viewNum"`
+	Status         string     `gorm:"column:status" json:"status"`
+	PublishedID    *uint64    `gorm:"column:published_id" json:"publishedID"`
+	PublishedTime  *time.Time `gorm:"column:published_time" json:"publishedTime"`
+	PublishAt      *time.Time `gorm:"column:publish_at" json:"publishAt"`
+	UnpublishAt    *time.Time `gorm:"column:unpublish_at" json:"unpublishAt"`
+	Slug           string     `gorm:"column:slug" json:"slug"`
+	CreateTime     time.Time  `gorm:"column:create_time" json:"createTime"`
+	UpdateTime     time.Time  `gorm:"column:update_time" json:"updateTime"`
+	TOC            string     `gorm:"column:toc" json:"toc"`
+	PlainText      string     `gorm:"column:plain_text" json:"plainText"`
+	WordCount      int        `gorm:"column:word_count" json:"wordCount"`
+	CharCount      int        `gorm:"column:char_count" json:"charCount"`
+	ReadingTime    int        `gorm:"column:reading_time" json:"readingTime"`
+	Visibility     string     `gorm:"column:visibility" json:"visibility"`
+	AccessPassword string     `gorm:"column:access_password" json:"-"`
+	Version        uint64     `gorm:"column:version" json:"version"`
+	TagNames       []string   `gorm:"-" json:"tagNames"`
+}
+
+// HashData 文章 Hash 缓存（article:{id}:Hash）的全部字段，各处回源写缓存时统一使用
+func (d *Detail) HashData() map[string]any {
+	return map[string]any{
+		"id":          d.ID,
+		"title":       d.Title,
+		"describe":    d.Describe,
+		"content":     d.Content,
+		"viewNum":     d.ViewNum,
+		"createTime":  d.CreateTime.Format(constants.TimeLayoutToSecond),
+		"updateTime":  d.UpdateTime.Format(constants.TimeLayoutToSecond),
+		"tagName":     JoinTagNames(d.TagNames),
+		"slug":        d.Slug,
+		"toc":         d.TOC,
+		"plainText":   d.PlainText,
+		"wordCount":   d.WordCount,
+		"charCount":   d.CharCount,
+		"readingTime": d.ReadingTime,
+		"visibility":  d.Visibility,
+		// 只缓存密码哈希的摘要，用于校验访问令牌是否签发于当前密码
+		"accessVersion": AccessVersion(d.AccessPassword),
+	}
 }
 
 type SearchArticle struct {
@@ -51,10 +102,25 @@ type SearchArticle struct {
 	CreateTime time.Time `gorm:"column:create_time" json:"createTime"`
 }
 
-type DelArticle struct {
-	ID      uint64 `gorm:"column:id" json:"id"`
-	TagID   uint64 `gorm:"column:tag_id" json:"tagID"`
-	TagName string `gorm:"column:tag_name" json:"tagName"`
+// SearchDocument 构建全文索引所需的文章字段
+type SearchDocument struct {
+	ID         uint64    `gorm:"column:id"`
+	Visibility string    `gorm:"column:visibility"`
+	Title      string    `gorm:"column:title"`
+	Describe   string    `gorm:"column:describe"`
+	Content    string    `gorm:"column:content"`
+	ViewNum    uint64    `gorm:"column:view_num"`
+	CreateTime time.Time `gorm:"column:create_time"`
+	PlainText  string    `gorm:"column:plain_text"`
+}
+
+// ContentStats 内容处理流程的输出，与 Article 上的派生字段一一对应
+type ContentStats struct {
+	TOC         string
+	PlainText   string
+	WordCount   int
+	CharCount   int
+	ReadingTime int
 }
 
 type ListByTagName struct {
@@ -64,6 +130,7 @@ type ListByTagName struct {
 
 type TimeAndViewZSet struct {
 	ID         uint64    `gorm:"column:id" json:"ID"`
+	Visibility string    `gorm:"column:visibility" json:"visibility"`
 	ViewNum    uint64    `gorm:"column:view_num" json:"viewNum"`
 	CreateTime time.Time `gorm:"column:create_time" json:"createTime"`
 }
@@ -71,6 +138,10 @@ type TimeAndViewZSet struct {
 const (
 	ArticleStatusDraft     = constants.ArticleStatusDraft
 	ArticleStatusPublished = constants.ArticleStatusPublished
+
+	ArticleVisibilityPublic    = constants.ArticleVisibilityPublic
+	ArticleVisibilityUnlisted  = constants.ArticleVisibilityUnlisted
+	ArticleVisibilityProtected = constants.ArticleVisibilityProtected
 )
 
 // ViewNumUpdate 文章浏览量批量更新项
@@ -84,21 +155,40 @@ func (a *articleModel) CreateArticle(ctx context.Context, newArticle *Article) e
 	if newArticle.Status == "" {
 		newArticle.Status = ArticleStatusPublished
 	}
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).Create(newArticle).Error; err != nil {
-		return fmt.Errorf("failed to create article: %w", err)
-	}
-
-	return nil
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Article{}).Create(newArticle).Error; err != nil {
+			return fmt.Errorf("failed to create article: %w", err)
+		}
+		return replaceArticleTags(tx, newArticle.ID, newArticle.TagIDs)
+	})
 }
 
-// UpdateArticle 更新文章
-func (a *articleModel) UpdateArticle(ctx context.Context, articleInfo *Article) error {
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).
-		Where("id = ? AND status = ?", articleInfo.ID, ArticleStatusPublished).Updates(articleInfo).Error; err != nil {
-		return fmt.Errorf("failed to update article: %w", err)
+// UpdateArticle 更新已发布文章。articleInfo.Version 必须与库中版本一致，
+// 否则返回 ErrArticleVersionConflict；成功后 articleInfo.Version 为新版本号。
+// slug 非 nil 时在版本校验通过后于同一事务内修改 slug（规则见 setArticleSlug），返回修改前的 slug
+func (a *articleModel) UpdateArticle(ctx context.Context, articleInfo *Article, slug *string) (string, error) {
+	var oldSlug string
+	err := a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		version, err := bumpArticleVersion(tx, articleInfo.ID, ArticleStatusPublished, articleInfo.Version)
+		if err != nil {
+			return err
+		}
+		articleInfo.Version = version
+		if slug != nil {
+			if oldSlug, err = setArticleSlug(tx, articleInfo.ID, *slug, articleInfo.UpdateTime); err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&Article{}).
+			Where("id = ? AND status = ?", articleInfo.ID, ArticleStatusPublished).Updates(articleInfo).Error; err != nil {
+			return fmt.Errorf("failed to update article: %w", err)
+		}
+		return replaceArticleTags(tx, articleInfo.ID, articleInfo.TagIDs)
+	})
+	if err != nil {
+		return "", err
 	}
-
-	return nil
+	return oldSlug, nil
 }
 
 // UpdateArticleViewNum 更新文章浏览量
@@ -114,14 +204,17 @@ func (a *articleModel) UpdateArticleViewNum(ctx context.Context, id string, view
 // GetArticleDetailByID 通过文章ID获取文章详情
 func (a *articleModel) GetArticleDetailByID(ctx context.Context, id uint64) (*Detail, error) {
 	detail := &Detail{}
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).
-		Table("article as a").
-		Select("a.id, a.title, a.describe, a.content, a.view_num, a.status, a.published_id, a.published_time, a.create_time, a.update_time, a.tag_id, COALESCE(b.name, '') as tag_name").
-		Joins("LEFT JOIN tag as b ON a.tag_id=b.id").
-		Where("a.id = ? AND a.status = ?", id, ArticleStatusPublished).
+	db := a.mysql.WithContext(ctx)
+	if err := db.Model(&Article{}).
+		Select("id, title, `describe`, content, view_num, status, published_id, published_time, publish_at, unpublish_at, create_time, update_time, "+
+			"toc, plain_text, word_count, char_count, reading_time, IFNULL(slug, '') AS slug, visibility, access_password, version").
+		Where("id = ? AND status = ?", id, ArticleStatusPublished).
 		First(detail).Error; err != nil {
 		return nil, err
 	}
+	if err := fillDetailTagNames(db, detail); err != nil {
+		return nil, err
+	}
 
 	return detail, nil
 }
@@ -131,7 +224,8 @@ func (a *articleModel) GetArticleListByTagName(ctx context.Context, tagName stri
 	list := make([]ListByTagName, 0)
 	if err := a.mysql.WithContext(ctx).Model(&Article{}).
 		Select("article.id, article.create_time").
-		Joins("JOIN tag ON tag.id = article.tag_id").
+		Joins("JOIN article_tag ON article_tag.article_id = article.id").
+		Joins("JOIN tag ON tag.id = article_tag.tag_id").
 		Where("tag.name = ? AND article.status = ?", tagName, ArticleStatusPublished).
 		Find(&list).Error; err != nil {
 		return nil, err
@@ -140,27 +234,28 @@ func (a *articleModel) GetArticleListByTagName(ctx context.Context, tagName stri
 	return list, nil
 }
 
-// GetArticleDeleteInfo 获取删除文章需要的元信息。
-func (a *articleModel) GetArticleDeleteInfo(ctx context.Context, id uint64) (string, error) {
-	articleInfo := &DelArticle{}
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).Table("article as a").
-		Select("a.id, a.tag_id, t.name as tag_name").
-		Joins("LEFT JOIN tag as t ON a.tag_id = t.id").
-		Where("a.id = ? AND a.status = ?", id, ArticleStatusPublished).
-		First(articleInfo).Error; err != nil {
-		return "", err
-	}
-
-	if articleInfo.ID != 0 && articleInfo.TagName != "" {
-		return articleInfo.TagName, nil
-	}
-
-	return "", fmt.Errorf("article not exist")
-}
-
-// DeleteArticleByID 硬删除文章。
-func (a *articleModel) DeleteArticleByID(ctx context.Context, id uint64) error {
+// PurgeArticleByID 彻底删除文章（包括回收站中的文章）及其草稿、标签、系列、推荐位、slug 历史与按天浏览量，
+// 图片引用与评论由外键级联删除。
+func (a *articleModel) PurgeArticleByID(ctx context.Context, id uint64) error {
 	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx = tx.Unscoped()
+		drafts := tx.Model(&Article{}).Select("id").Where("published_id = ? AND status = ?", id, ArticleStatusDraft)
+		if err := tx.Where("article_id = ? OR article_id IN (?)", id, drafts).
+			Delete(&ArticleTag{}).Error; err != nil {
+			return fmt.Errorf("failed to delete article tags: %w", err)
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&SeriesArticle{}).Error; err != nil {
+			return fmt.Errorf("failed to delete article series membership: %w", err)
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&ArticlePromotion{}).Error; err != nil {
+			return fmt.Errorf("failed to delete article promotions: %w", err)
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&ArticleSlugHistory{}).Error; err != nil {
+			return fmt.Errorf("failed to delete article slug history: %w", err)
+		}
+		if err := tx.Where("article_id = ?", id).Delete(&ArticleViewDaily{}).Error; err != nil {
+			return fmt.Errorf("failed to delete article view daily: %w", err)
+		}
 		if err := tx.Where("published_id = ? AND status = ?", id, ArticleStatusDraft).
 			Delete(&Article{}).Error; err != nil {
 			return fmt.Errorf("failed to delete article drafts: %w", err)
@@ -173,10 +268,9 @@ func (a *articleModel) DeleteArticleByID(ctx context.Context, id uint64) error {
 	})
 }
 
-// SearchArticle 搜索文章
+// SearchArticle 搜索文章，只搜索公开文章
 //
-// 标题与正文均按子串匹配（LIKE）：标题是短的高信号字段、正文是长字段，
-// 文章量级很小（线上 ≤500 篇），全表扫描即为毫秒级，无需全文索引。
+// 标题与正文均按子串匹配（LIKE），仅在进程内全文索引尚未构建完成时作为兜底使用。
 // 排序上让标题命中的优先，其次按浏览量近似相关度。
 func (a *articleModel) SearchArticle(ctx context.Context, word string, limit, offset int) ([]SearchArticle, int64, error) {
 	list := make([]SearchArticle, 0)
@@ -185,8 +279,8 @@ func (a *articleModel) SearchArticle(ctx context.Context, word string, limit, of
 
 	// 第一次查询：统计命中总数（不分页），用于前端分页
 	if err := a.mysql.WithContext(ctx).Model(&Article{}).
-		Where("status = ? AND (title LIKE ? COLLATE utf8mb4_general_ci OR content LIKE ?)",
-			ArticleStatusPublished, like, like).
+		Where("status = ? AND visibility = ? AND (title LIKE ? COLLATE utf8mb4_general_ci OR content LIKE ?)",
+			ArticleStatusPublished, ArticleVisibilityPublic, like, like).
 		Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to count articles: %w", err)
 	}
@@ -197,8 +291,8 @@ func (a *articleModel) SearchArticle(ctx context.Context, word string, limit, of
 	// 第二次查询：取当前分页的数据，标题命中优先、再按浏览量排序
 	if err := a.mysql.WithContext(ctx).Model(&Article{}).
 		Select("`id`, `title`, `describe`, `view_num`, `create_time`, (`title` LIKE ? COLLATE utf8mb4_general_ci) AS title_hit", like).
-		Where("status = ? AND (title LIKE ? COLLATE utf8mb4_general_ci OR content LIKE ?)",
-			ArticleStatusPublished, like, like).
+		Where("status = ? AND visibility = ? AND (title LIKE ? COLLATE utf8mb4_general_ci OR content LIKE ?)",
+			ArticleStatusPublished, ArticleVisibilityPublic, like, like).
 		Order("title_hit DESC, view_num DESC").
 		Limit(limit).Offset(offset).
 		Find(&list).Error; err != nil {
@@ -216,24 +310,12 @@ func (a *articleModel) GetArticleListByIDList(ctx context.Context, ids []uint64)
 	return articles, err
 }
 
-// UpdateArticleTagID 更新文章的tagID
-func (a *articleModel) UpdateArticleTagID(ctx context.Context, articleIDList []string, tagID uint64) error {
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).
-		Where("id IN ? AND status = ?", articleIDList, ArticleStatusPublished).
-		Update("tag_id", tagID).Error; err != nil {
-		return err
-	}
-	return nil
-}
-
 // GetArticleList 获取文章列表（带分页）
 func (a *articleModel) GetArticleList(ctx context.Context, offset, limit int) ([]*Article, error) {
 	var articles []*Article
 
-	// 使用 Preload 加载关联的标签数据
 	// 按创建时间倒序排列（根据需求可调整排序字段）
 	err := a.mysql.WithContext(ctx).
-		Preload("Tag").
 		Where("status = ?", ArticleStatusPublished).
 		Order("create_time DESC").
 		Offset(offset).
@@ -261,7 +343,7 @@ func (a *articleModel) ListTimeAndView(ctx context.Context) ([]TimeAndViewZSet,
 	list := make([]TimeAndViewZSet, 0)
 	if err := a.mysql.WithContext(ctx).
 		Model(&Article{}).
-		Select("id", "view_num", "create_time").
+		Select("id", "view_num", "create_time", "visibility").
 		Where("status = ?", ArticleStatusPublished).
 		Find(&list).Error; err != nil {
 		return nil, fmt.Errorf("failed to list time and view: %w", err)
@@ -269,6 +351,48 @@ func (a *articleModel) ListTimeAndView(ctx context.Context) ([]TimeAndViewZSet,
 	return list, nil
 }
 
+// ListArticlesWithoutContentStats 取一批尚未生成派生字段的已发布文章（功能上线前发布的存量文章）
+func (a *articleModel) ListArticlesWithoutContentStats(ctx context.Context, limit int) ([]Article, error) {
+	list := make([]Article, 0)
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Select("id", "content").
+		Where("status = ? AND toc = ''", ArticleStatusPublished).
+		Order("id").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to list articles without content stats: %w", err)
+	}
+	return list, nil
+}
+
+// UpdateArticleContentStats 只回写派生字段，不改动 update_time
+func (a *articleModel) UpdateArticleContentStats(ctx context.Context, id uint64, stats ContentStats) error {
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"toc":          stats.TOC,
+			"plain_text":   stats.PlainText,
+			"word_count":   stats.WordCount,
+			"char_count":   stats.CharCount,
+			"reading_time": stats.ReadingTime,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update article content stats: %w", err)
+	}
+	return nil
+}
+
+// ListSearchDocuments 拉取所有已发布文章的正文，用于构建全文索引
+func (a *articleModel) ListSearchDocuments(ctx context.Context) ([]SearchDocument, error) {
+	list := make([]SearchDocument, 0)
+	if err := a.mysql.WithContext(ctx).
+		Model(&Article{}).
+		Select("id", "title", "describe", "content", "view_num", "create_time", "plain_text", "visibility").
+		Where("status = ?", ArticleStatusPublished).
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to list search documents: %w", err)
+	}
+	return list, nil
+}
+
 // BatchUpdateViewNum 批量回写浏览量到数据库
 // 使用 CASE WHEN 单条 SQL 完成 N 行更新，显著降低 RTT 与持久化耗时
 func (a *articleModel) BatchUpdateViewNum(ctx context.Context, items []ViewNumUpdate) error {
@@ -0,0 +1,152 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagNameSeparator 文章 Hash 缓存与历史版本中多个标签名的分隔符，标签名本身不允许包含该字符
+const TagNameSeparator = ","
+
+// ArticleTag 文章与标签的多对多关联，草稿与已发布文章共用。
+// Sort 记录编辑填写标签的顺序，展示时第一个标签视为主标签。
+type ArticleTag struct {
+	ArticleID uint64 `gorm:"column:article_id;primaryKey;autoIncrement:false"`
+	TagID     uint64 `gorm:"column:tag_id;primaryKey;autoIncrement:false;index"`
+	Sort      int    `gorm:"column:sort;NOT NULL;default:0"`
+}
+
+func (ArticleTag) TableName() string {
+	return "article_tag"
+}
+
+// JoinTagNames 把标签名列表拼成缓存/快照使用的单个字符串
+func JoinTagNames(names []string) string {
+	return strings.Join(names, TagNameSeparator)
+}
+
+// SplitTagNames JoinTagNames 的逆操作，空串返回空列表
+func SplitTagNames(value string) []string {
+	names := make([]string, 0)
+	for _, name := range strings.Split(value, TagNameSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// replaceArticleTags 用 tagIDs 整体替换文章的标签关联，需在事务内调用
+func replaceArticleTags(tx *gorm.DB, articleID uint64, tagIDs []uint64) error {
+	if err := tx.Where("article_id = ?", articleID).Delete(&ArticleTag{}).Error; err != nil {
+		return fmt.Errorf("failed to clear article tags: %w", err)
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	rows := make([]ArticleTag, 0, len(tagIDs))
+	for i, tagID := range tagIDs {
+		rows = append(rows, ArticleTag{ArticleID: articleID, TagID: tagID, Sort: i})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to create article tags: %w", err)
+	}
+	return nil
+}
+
+// listArticleTagNames 批量查询文章的标签名，按编辑填写顺序返回
+func listArticleTagNames(db *gorm.DB, articleIDs []uint64) (map[uint64][]string, error) {
+	result := make(map[uint64][]string, len(articleIDs))
+	if len(articleIDs) == 0 {
+		return result, nil
+	}
+	rows := make([]struct {
+		ArticleID uint64 `gorm:"column:article_id"`
+		Name      string `gorm:"column:name"`
+	}, 0)
+	if err := db.Table("article_tag as at").
+		Select("at.article_id, t.name").
+		Joins("JOIN tag as t ON t.id = at.tag_id").
+		Where("at.article_id IN ?", articleIDs).
+		Order("at.article_id, at.sort, t.name").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list article tag names: %w", err)
+	}
+	for _, row := range rows {
+		result[row.ArticleID] = append(result[row.ArticleID], row.Name)
+	}
+	return result, nil
+}
+
+// fillDetailTagNames 给文章详情补齐标签名
+func fillDetailTagNames(db *gorm.DB, detail *Detail) error {
+	names, err := listArticleTagNames(db, []uint64{detail.ID})
+	if err != nil {
+		return err
+	}
+	detail.TagNames = names[detail.ID]
+	if detail.TagNames == nil {
+		detail.TagNames = []string{}
+	}
+	return nil
+}
+
+// ListArticleTagNames 批量查询文章的标签名
+func (a *articleModel) ListArticleTagNames(ctx context.Context, articleIDs []uint64) (map[uint64][]string, error) {
+	return listArticleTagNames(a.mysql.WithContext(ctx), articleIDs)
+}
+
+// ReplaceArticleTag 把一批已发布文章上的 oldTagID 换成 newTagID（标签重命名/合并）。
+// 文章本来就同时带有新标签时只删除旧关联，不产生重复行。
+func (a *articleModel) ReplaceArticleTag(ctx context.Context, articleIDList []string, oldTagID uint64,
+	newTagID uint64) error {
+	if len(articleIDList) == 0 || oldTagID == newTagID {
+		return nil
+	}
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		published := tx.Model(&Article{}).Select("id").
+			Where("id IN ? AND status = ?", articleIDList, ArticleStatusPublished)
+		rows := make([]ArticleTag, 0)
+		if err := tx.Where("tag_id = ? AND article_id IN (?)", oldTagID, published).
+			Find(&rows).Error; err != nil {
+			return fmt.Errorf("failed to list article tags: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		for i := range rows {
+			rows[i].TagID = newTagID
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to add new article tag: %w", err)
+		}
+		articleIDs := make([]uint64, 0, len(rows))
+		for _, row := range rows {
+			articleIDs = append(articleIDs, row.ArticleID)
+		}
+		if err := tx.Where("tag_id = ? AND article_id IN ?", oldTagID, articleIDs).
+			Delete(&ArticleTag{}).Error; err != nil {
+			return fmt.Errorf("failed to remove old article tag: %w", err)
+		}
+		return nil
+	})
+}
+
+// MigrateLegacyArticleTags 把旧版单标签列 article.tag_id 迁移到 article_tag，只应执行一次
+// （由调用方记录迁移标记），否则新版本中已移除的标签会被旧列重新写回。
+// 旧列保留原值不再读写，回滚到旧版本时标签不丢失，由后续版本统一清理。
+func MigrateLegacyArticleTags(tx *gorm.DB) error {
+	if !tx.Migrator().HasColumn(&Article{}, "tag_id") {
+		return nil
+	}
+	if err := tx.Exec("INSERT IGNORE INTO article_tag (article_id, tag_id, sort) " +
+		"SELECT a.id, a.tag_id, 0 FROM article AS a JOIN tag AS t ON t.id = a.tag_id " +
+		"WHERE a.tag_id IS NOT NULL").Error; err != nil {
+		return fmt.Errorf("failed to migrate legacy article tags: %w", err)
+	}
+	return nil
+}
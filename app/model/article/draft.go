@@ -9,30 +9,42 @@ import (
 )
 
 type DraftListRecord struct {
-	ID          uint64    `gorm:"column:id"`
-	PublishedID *uint64   `gorm:"column:published_id"`
-	Title       string    `gorm:"column:title"`
-	TagName     string    `gorm:"column:tag_name"`
-	CreateTime  time.Time `gorm:"column:create_time"`
-	UpdateTime  time.Time `gorm:"column:update_time"`
+	ID          uint64     `gorm:"column:id"`
+	PublishedID *uint64    `gorm:"column:published_id"`
+	Title       string     `gorm:"column:title"`
+	TagNames    []string   `gorm:"-"`
+	PublishAt   *time.Time `gorm:"column:publish_at"`
+	UnpublishAt *time.Time `gorm:"column:unpublish_at"`
+	CreateTime  time.Time  `gorm:"column:create_time"`
+	UpdateTime  time.Time  `gorm:"column:update_time"`
 }
 
 func (a *articleModel) CreateArticleDraft(ctx context.Context, draft *Article) error {
 	draft.Status = ArticleStatusDraft
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).Create(draft).Error; err != nil {
-		return fmt.Errorf("failed to create article draft: %w", err)
-	}
-	return nil
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Article{}).Create(draft).Error; err != nil {
+			return fmt.Errorf("failed to create article draft: %w", err)
+		}
+		return replaceArticleTags(tx, draft.ID, draft.TagIDs)
+	})
 }
 
+// UpdateArticleDraft 更新草稿，版本号校验规则与 UpdateArticle 相同
 func (a *articleModel) UpdateArticleDraft(ctx context.Context, draft *Article) error {
 	values := articleDraftUpdateValues(draft)
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).
-		Where("id = ? AND status = ?", draft.ID, ArticleStatusDraft).
-		Updates(values).Error; err != nil {
-		return fmt.Errorf("failed to update article draft: %w", err)
-	}
-	return nil
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		version, err := bumpArticleVersion(tx, draft.ID, ArticleStatusDraft, draft.Version)
+		if err != nil {
+			return err
+		}
+		draft.Version = version
+		if err := tx.Model(&Article{}).
+			Where("id = ? AND status = ?", draft.ID, ArticleStatusDraft).
+			Updates(values).Error; err != nil {
+			return fmt.Errorf("failed to update article draft: %w", err)
+		}
+		return replaceArticleTags(tx, draft.ID, draft.TagIDs)
+	})
 }
 
 func (a *articleModel) GetArticleDraftByID(ctx context.Context, id uint64) (*Article, error) {
@@ -47,14 +59,16 @@ func (a *articleModel) GetArticleDraftByID(ctx context.Context, id uint64) (*Art
 
 func (a *articleModel) GetArticleDraftDetailByID(ctx context.Context, id uint64) (*Detail, error) {
 	detail := &Detail{}
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).
-		Table("article as a").
-		Select("a.id, a.title, a.describe, a.content, a.view_num, a.status, a.published_id, a.published_time, a.create_time, a.update_time, a.tag_id, COALESCE(t.name, '') as tag_name").
-		Joins("LEFT JOIN tag as t ON a.tag_id = t.id").
-		Where("a.id = ? AND a.status = ?", id, ArticleStatusDraft).
+	db := a.mysql.WithContext(ctx)
+	if err := db.Model(&Article{}).
+		Select("id, title, `describe`, content, view_num, status, published_id, published_time, publish_at, unpublish_at, create_time, update_time, version").
+		Where("id = ? AND status = ?", id, ArticleStatusDraft).
 		First(detail).Error; err != nil {
 		return nil, err
 	}
+	if err := fillDetailTagNames(db, detail); err != nil {
+		return nil, err
+	}
 	return detail, nil
 }
 
@@ -90,17 +104,28 @@ func (a *articleModel) ListArticleDrafts(ctx context.Context, offset int, limit
 	}
 
 	rows := make([]DraftListRecord, 0)
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).
-		Table("article as a").
-		Select("a.id, a.published_id, a.title, COALESCE(t.name, '') as tag_name, a.create_time, a.update_time").
-		Joins("LEFT JOIN tag as t ON a.tag_id = t.id").
-		Where("a.status = ?", ArticleStatusDraft).
-		Order("a.update_time DESC, a.id DESC").
+	db := a.mysql.WithContext(ctx)
+	if err := db.Model(&Article{}).
+		Select("id, published_id, title, publish_at, unpublish_at, create_time, update_time").
+		Where("status = ?", ArticleStatusDraft).
+		Order("update_time DESC, id DESC").
 		Offset(offset).
 		Limit(limit).
 		Find(&rows).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to list article drafts: %w", err)
 	}
+
+	ids := make([]uint64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	tagNames, err := listArticleTagNames(db, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range rows {
+		rows[i].TagNames = tagNames[rows[i].ID]
+	}
 	return rows, total, nil
 }
 
@@ -113,16 +138,24 @@ func (a *articleModel) PublishNewArticleDraft(ctx context.Context, draft *Articl
 		"status":         ArticleStatusPublished,
 		"published_id":   nil,
 		"published_time": draft.PublishedTime,
-		"tag_id":         draft.TagID,
+		"publish_at":     nil,
+		"unpublish_at":   draft.UnpublishAt,
 		"create_time":    draft.CreateTime,
 		"update_time":    draft.UpdateTime,
+		"toc":            draft.TOC,
+		"plain_text":     draft.PlainText,
+		"word_count":     draft.WordCount,
+		"char_count":     draft.CharCount,
+		"reading_time":   draft.ReadingTime,
 	}
-	if err := a.mysql.WithContext(ctx).Model(&Article{}).
-		Where("id = ? AND status = ?", draft.ID, ArticleStatusDraft).
-		Updates(values).Error; err != nil {
-		return fmt.Errorf("failed to publish new article draft: %w", err)
-	}
-	return nil
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Article{}).
+			Where("id = ? AND status = ?", draft.ID, ArticleStatusDraft).
+			Updates(values).Error; err != nil {
+			return fmt.Errorf("failed to publish new article draft: %w", err)
+		}
+		return replaceArticleTags(tx, draft.ID, draft.TagIDs)
+	})
 }
 
 func (a *articleModel) PublishArticleDraftToPublished(ctx context.Context, draftID uint64,
@@ -132,9 +165,15 @@ func (a *articleModel) PublishArticleDraftToPublished(ctx context.Context, draft
 		"describe":       published.Describe,
 		"content":        published.Content,
 		"view_num":       published.ViewNum,
-		"tag_id":         published.TagID,
 		"published_time": published.PublishedTime,
+		"unpublish_at":   published.UnpublishAt,
 		"update_time":    published.UpdateTime,
+		"toc":            published.TOC,
+		"plain_text":     published.PlainText,
+		"word_count":     published.WordCount,
+		"char_count":     published.CharCount,
+		"reading_time":   published.ReadingTime,
+		"version":        gorm.Expr("version + 1"), // 正在直接编辑该文章的页面保存时会得到版本冲突
 	}
 	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Model(&Article{}).
@@ -142,7 +181,13 @@ func (a *articleModel) PublishArticleDraftToPublished(ctx context.Context, draft
 			Updates(values).Error; err != nil {
 			return fmt.Errorf("failed to update published article from draft: %w", err)
 		}
-		if err := tx.Where("id = ? AND status = ?", draftID, ArticleStatusDraft).
+		if err := replaceArticleTags(tx, published.ID, published.TagIDs); err != nil {
+			return err
+		}
+		if err := replaceArticleTags(tx, draftID, nil); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("id = ? AND status = ?", draftID, ArticleStatusDraft).
 			Delete(&Article{}).Error; err != nil {
 			return fmt.Errorf("failed to delete published article draft: %w", err)
 		}
@@ -151,12 +196,17 @@ func (a *articleModel) PublishArticleDraftToPublished(ctx context.Context, draft
 }
 
 func (a *articleModel) DeleteArticleDraftByID(ctx context.Context, id uint64) error {
-	if err := a.mysql.WithContext(ctx).
-		Where("id = ? AND status = ?", id, ArticleStatusDraft).
-		Delete(&Article{}).Error; err != nil {
-		return fmt.Errorf("failed to delete article draft: %w", err)
-	}
-	return nil
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 草稿直接删除，不进回收站：编辑草稿占用 published_id 唯一索引，软删除会阻塞再次编辑
+		result := tx.Unscoped().Where("id = ? AND status = ?", id, ArticleStatusDraft).Delete(&Article{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete article draft: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return replaceArticleTags(tx, id, nil)
+	})
 }
 
 func articleDraftUpdateValues(draft *Article) map[string]any {
@@ -164,8 +214,9 @@ func articleDraftUpdateValues(draft *Article) map[string]any {
 		"title":        draft.Title,
 		"describe":     draft.Describe,
 		"content":      draft.Content,
-		"tag_id":       draft.TagID,
 		"published_id": draft.PublishedID,
+		"publish_at":   draft.PublishAt,
+		"unpublish_at": draft.UnpublishAt,
 		"update_time":  draft.UpdateTime,
 	}
 }
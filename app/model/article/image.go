@@ -2,7 +2,9 @@ package article
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,19 +17,48 @@ const (
 
 	ImageRefTypeMarkdown = "markdown"
 	ImageRefTypeHTML     = "html"
+
+	ImageVariantKindResized   = "resized"
+	ImageVariantKindOptimized = "optimized"
 )
 
+// ErrArticleImageContentExists 相同内容的图片已被并发上传写入
+var ErrArticleImageContentExists = errors.New("article image with the same content already exists")
+
+// articleImageContentHashIndex content_hash 的唯一索引，保证相同内容只存一份
+const articleImageContentHashIndex = "idx_article_image_content_hash_unique"
+
 type ArticleImage struct {
-	ID         uint64    `gorm:"primary_key;NOT NULL"`
-	ObjectKey  string    `gorm:"column:object_key;type:varchar(500);NOT NULL;uniqueIndex"`
-	URL        string    `gorm:"column:url;type:varchar(1000);NOT NULL"`
-	ImageName  string    `gorm:"column:image_name;type:varchar(255);NOT NULL"`
-	Mime       string    `gorm:"column:mime;type:varchar(100);NOT NULL;default:''"`
-	Size       int64     `gorm:"column:size;NOT NULL;default:0"`
-	ETag       string    `gorm:"column:etag;type:varchar(128);NOT NULL;default:''"`
-	Status     string    `gorm:"column:status;type:varchar(20);NOT NULL;index"`
-	CreateTime time.Time `gorm:"column:create_time;NOT NULL"`
-	UpdateTime time.Time `gorm:"column:update_time;NOT NULL"`
+	ID          uint64    `gorm:"primary_key;NOT NULL"`
+	ObjectKey   string    `gorm:"column:object_key;type:varchar(500);NOT NULL;uniqueIndex"`
+	URL         string    `gorm:"column:url;type:varchar(1000);NOT NULL"`
+	ImageName   string    `gorm:"column:image_name;type:varchar(255);NOT NULL"`
+	Mime        string    `gorm:"column:mime;type:varchar(100);NOT NULL;default:''"`
+	Size        int64     `gorm:"column:size;NOT NULL;default:0"`
+	Width       int       `gorm:"column:width;NOT NULL;default:0"`
+	Height      int       `gorm:"column:height;NOT NULL;default:0"`
+	ETag        string    `gorm:"column:etag;type:varchar(128);NOT NULL;default:''"`
+	ContentHash *string   `gorm:"column:content_hash;type:varchar(64);uniqueIndex:idx_article_image_content_hash_unique"` // 未计算哈希的旧图片为 NULL
+	Status      string    `gorm:"column:status;type:varchar(20);NOT NULL;index"`
+	CreateTime  time.Time `gorm:"column:create_time;NOT NULL"`
+	UpdateTime  time.Time `gorm:"column:update_time;NOT NULL"`
+	// DeletedAt 移入回收站的时间，COS 对象保留到彻底删除时再清理
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
+}
+
+// ArticleImageVariant 上传时生成的缩略图或原尺寸压缩版本，随原图彻底删除
+type ArticleImageVariant struct {
+	ID         uint64       `gorm:"primary_key;NOT NULL"`
+	ImageID    uint64       `gorm:"column:image_id;NOT NULL;index"`
+	Kind       string       `gorm:"column:kind;type:varchar(20);NOT NULL"`
+	ObjectKey  string       `gorm:"column:object_key;type:varchar(500);NOT NULL;uniqueIndex"`
+	URL        string       `gorm:"column:url;type:varchar(1000);NOT NULL"`
+	Mime       string       `gorm:"column:mime;type:varchar(100);NOT NULL;default:''"`
+	Width      int          `gorm:"column:width;NOT NULL;default:0"`
+	Height     int          `gorm:"column:height;NOT NULL;default:0"`
+	Size       int64        `gorm:"column:size;NOT NULL;default:0"`
+	CreateTime time.Time    `gorm:"column:create_time;NOT NULL"`
+	Image      ArticleImage `gorm:"foreignKey:ImageID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
 type ArticleImageReference struct {
@@ -42,6 +73,12 @@ type ArticleImageReference struct {
 	Article    Article      `gorm:"foreignKey:ArticleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
+// ArticleContentRecord 图片回收时复核引用用到的文章或历史版本内容
+type ArticleContentRecord struct {
+	ID      uint64 `gorm:"column:id"`
+	Content string `gorm:"column:content"`
+}
+
 type ArticleImageQuery struct {
 	Status  string
 	Keyword string
@@ -80,8 +117,9 @@ func (a *articleModel) FindArticleImagesByObjectKeys(ctx context.Context, object
 		return result, nil
 	}
 
+	// 回收站中的图片同样返回，重新被文章引用时由 SyncArticleImageReferences 恢复
 	images := make([]ArticleImage, 0)
-	if err := a.mysql.WithContext(ctx).Model(&ArticleImage{}).
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&ArticleImage{}).
 		Where("object_key IN ?", objectKeys).
 		Find(&images).Error; err != nil {
 		return nil, fmt.Errorf("find article images by object keys: %w", err)
@@ -116,6 +154,15 @@ func (a *articleModel) SyncArticleImageReferences(ctx context.Context, articleID
 			if err := tx.CreateInBatches(references, 100).Error; err != nil {
 				return fmt.Errorf("create article image references: %w", err)
 			}
+			referencedImageIDs := make([]uint64, 0, len(references))
+			for _, ref := range references {
+				referencedImageIDs = append(referencedImageIDs, ref.ImageID)
+			}
+			if err := tx.Unscoped().Model(&ArticleImage{}).
+				Where("id IN ? AND deleted_at IS NOT NULL", referencedImageIDs).
+				Update("deleted_at", nil).Error; err != nil {
+				return fmt.Errorf("restore referenced article images: %w", err)
+			}
 		}
 
 		affectedImageIDs := make([]uint64, 0, len(oldReferences)+len(references)+len(images))
@@ -133,12 +180,17 @@ func (a *articleModel) SyncArticleImageReferences(ctx context.Context, articleID
 			affectedImageIDs = append(affectedImageIDs, imageID)
 		}
 		if len(affectedImageIDs) > 0 {
-			if err := tx.Model(&ArticleImage{}).
-				Where("id IN ?", affectedImageIDs).
-				Update("status", gorm.Expr(
-					"CASE WHEN EXISTS (SELECT 1 FROM `article_image_reference` WHERE `article_image_reference`.`image_id` = `article_image`.`id`) THEN ? ELSE ? END",
-					ImageStatusUsed, ImageStatusUnused,
-				)).Error; err != nil {
+			// 只更新状态发生变化的图片，update_time 记录变为未使用的时间，供未引用图片回收判断保留期
+			hasReference := "EXISTS (SELECT 1 FROM `article_image_reference` WHERE `article_image_reference`.`image_id` = `article_image`.`id`)"
+			now := time.Now()
+			if err := tx.Unscoped().Model(&ArticleImage{}).
+				Where("id IN ? AND status <> ? AND "+hasReference, affectedImageIDs, ImageStatusUsed).
+				Updates(map[string]any{"status": ImageStatusUsed, "update_time": now}).Error; err != nil {
+				return fmt.Errorf("refresh article image status: %w", err)
+			}
+			if err := tx.Unscoped().Model(&ArticleImage{}).
+				Where("id IN ? AND status <> ? AND NOT "+hasReference, affectedImageIDs, ImageStatusUnused).
+				Updates(map[string]any{"status": ImageStatusUnused, "update_time": now}).Error; err != nil {
 				return fmt.Errorf("refresh article image status: %w", err)
 			}
 		}
@@ -146,24 +198,61 @@ func (a *articleModel) SyncArticleImageReferences(ctx context.Context, articleID
 	})
 }
 
+// CreateArticleImage 写入新上传的图片，相同内容已存在时返回 ErrArticleImageContentExists
 func (a *articleModel) CreateArticleImage(ctx context.Context, image *ArticleImage) error {
-	if err := a.mysql.WithContext(ctx).Clauses(clause.OnConflict{
+	if err := a.mysql.WithContext(ctx).Create(image).Error; err != nil {
+		if isDuplicateKeyError(err, articleImageContentHashIndex) {
+			return ErrArticleImageContentExists
+		}
+		return fmt.Errorf("create article image: %w", err)
+	}
+	return nil
+}
+
+// ReuseArticleImage 复用已上传的图片：移出回收站并刷新 update_time
+func (a *articleModel) ReuseArticleImage(ctx context.Context, id uint64, now time.Time) error {
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&ArticleImage{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"deleted_at": nil, "update_time": now}).Error; err != nil {
+		return fmt.Errorf("reuse article image: %w", err)
+	}
+	return nil
+}
+
+// CreateArticleImageVariants 写入图片变体；同一对象重复上传时覆盖旧记录
+func (a *articleModel) CreateArticleImageVariants(ctx context.Context, variants []ArticleImageVariant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+	if err := a.mysql.WithContext(ctx).Omit("Image").Clauses(clause.OnConflict{
 		Columns: []clause.Column{{Name: "object_key"}},
 		DoUpdates: clause.AssignmentColumns([]string{
+			"image_id",
+			"kind",
 			"url",
-			"image_name",
 			"mime",
+			"width",
+			"height",
 			"size",
-			"etag",
-			"status",
-			"update_time",
 		}),
-	}).Create(image).Error; err != nil {
-		return fmt.Errorf("create article image: %w", err)
+	}).Create(&variants).Error; err != nil {
+		return fmt.Errorf("create article image variants: %w", err)
 	}
 	return nil
 }
 
+// ListArticleImageVariants 图片的全部变体，按宽度升序
+func (a *articleModel) ListArticleImageVariants(ctx context.Context, imageID uint64) ([]ArticleImageVariant, error) {
+	variants := make([]ArticleImageVariant, 0)
+	if err := a.mysql.WithContext(ctx).Model(&ArticleImageVariant{}).
+		Where("image_id = ?", imageID).
+		Order("width ASC, id ASC").
+		Find(&variants).Error; err != nil {
+		return nil, fmt.Errorf("list article image variants: %w", err)
+	}
+	return variants, nil
+}
+
 func (a *articleModel) ListArticleImages(ctx context.Context,
 	query ArticleImageQuery) ([]ArticleImageListRecord, int64, error) {
 	applyFilter := func(db *gorm.DB) *gorm.DB {
@@ -209,6 +298,19 @@ func (a *articleModel) GetArticleImageByID(ctx context.Context, id uint64) (*Art
 	return image, nil
 }
 
+// FindArticleImageByContentHash 按去除元数据后内容的 SHA-256 查找上传过的图片，
+// 回收站中的图片同样返回，由调用方决定是否恢复
+func (a *articleModel) FindArticleImageByContentHash(ctx context.Context, contentHash string) (*ArticleImage, error) {
+	image := &ArticleImage{}
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&ArticleImage{}).
+		Where("content_hash = ?", contentHash).
+		Order("deleted_at IS NOT NULL, id ASC").
+		First(image).Error; err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
 func (a *articleModel) ListArticleImageReferences(ctx context.Context,
 	imageID uint64) ([]ArticleImageReferenceRecord, error) {
 	records := make([]ArticleImageReferenceRecord, 0)
@@ -234,14 +336,100 @@ func (a *articleModel) CountArticleImageReferences(ctx context.Context, imageID
 	return count, nil
 }
 
-func (a *articleModel) DeleteArticleImage(ctx context.Context, id uint64) error {
-	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("image_id = ?", id).Delete(&ArticleImageReference{}).Error; err != nil {
-			return fmt.Errorf("delete article image references: %w", err)
-		}
-		if err := tx.Delete(&ArticleImage{}, id).Error; err != nil {
-			return fmt.Errorf("delete article image: %w", err)
-		}
+// TrashArticleImage 把图片移入回收站，引用关系保留，恢复后无需重新同步
+func (a *articleModel) TrashArticleImage(ctx context.Context, id uint64, now time.Time) error {
+	result := a.mysql.WithContext(ctx).Model(&ArticleImage{}).Where("id = ?", id).Update("deleted_at", now)
+	if result.Error != nil {
+		return fmt.Errorf("trash article image: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// MarkArticleImageUsed 回收复核发现图片仍被内容引用时调用：刷新 update_time，
+// 使其排到候选队列末尾，保留期过后才会再次复核
+func (a *articleModel) MarkArticleImageUsed(ctx context.Context, id uint64, now time.Time) error {
+	if err := a.mysql.WithContext(ctx).Model(&ArticleImage{}).Where("id = ?", id).
+		Updates(map[string]any{"status": ImageStatusUsed, "update_time": now}).Error; err != nil {
+		return fmt.Errorf("mark article image used: %w", err)
+	}
+	return nil
+}
+
+// ListOrphanedArticleImages 没有任何引用且 before 之后未再变动的图片，最早变动的在前。
+// 不按 status 过滤：文章彻底删除时引用随外键级联删除，图片状态仍停留在 used
+func (a *articleModel) ListOrphanedArticleImages(ctx context.Context, before time.Time,
+	limit int) ([]ArticleImage, error) {
+	images := make([]ArticleImage, 0)
+	if err := a.mysql.WithContext(ctx).Model(&ArticleImage{}).
+		Where("update_time < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM `article_image_reference` WHERE `article_image_reference`.`image_id` = `article_image`.`id`)").
+		Order("update_time ASC, id ASC").
+		Limit(limit).
+		Find(&images).Error; err != nil {
+		return nil, fmt.Errorf("list orphaned article images: %w", err)
+	}
+	return images, nil
+}
+
+// ListArticleContentsAfter 按 ID 分批读取全部文章内容，包括草稿与回收站中的文章
+func (a *articleModel) ListArticleContentsAfter(ctx context.Context, afterID uint64,
+	limit int) ([]ArticleContentRecord, error) {
+	records := make([]ArticleContentRecord, 0)
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&Article{}).
+		Select("id, content").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("list article contents: %w", err)
+	}
+	return records, nil
+}
+
+// ListArticleRevisionContentsAfter 按 ID 分批读取全部历史版本内容，恢复版本后其中引用的图片仍需可用
+func (a *articleModel) ListArticleRevisionContentsAfter(ctx context.Context, afterID uint64,
+	limit int) ([]ArticleContentRecord, error) {
+	records := make([]ArticleContentRecord, 0)
+	if err := a.mysql.WithContext(ctx).Model(&ArticleRevision{}).
+		Select("id, content").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("list article revision contents: %w", err)
+	}
+	return records, nil
+}
+
+// MigrateArticleImageContentHash 把 content_hash 的普通索引换成唯一索引，需在 AutoMigrate 之前执行：
+// 空串改为 NULL，并发上传遗留的重复哈希只保留最早一条，其余置为 NULL 后再由 AutoMigrate 建唯一索引
+func MigrateArticleImageContentHash(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&ArticleImage{}) || !migrator.HasIndex(&ArticleImage{}, "idx_article_image_content_hash") {
 		return nil
-	})
+	}
+	if err := migrator.AlterColumn(&ArticleImage{}, "ContentHash"); err != nil {
+		return fmt.Errorf("failed to alter article image content hash: %w", err)
+	}
+	if err := db.Exec("UPDATE article_image SET content_hash = NULL WHERE content_hash = ''").Error; err != nil {
+		return fmt.Errorf("failed to clear empty article image content hash: %w", err)
+	}
+	if err := db.Exec("UPDATE article_image AS a JOIN article_image AS b " +
+		"ON a.content_hash = b.content_hash AND a.id > b.id SET a.content_hash = NULL").Error; err != nil {
+		return fmt.Errorf("failed to clear duplicated article image content hash: %w", err)
+	}
+	if err := migrator.DropIndex(&ArticleImage{}, "idx_article_image_content_hash"); err != nil &&
+		migrator.HasIndex(&ArticleImage{}, "idx_article_image_content_hash") {
+		return fmt.Errorf("failed to drop article image content hash index: %w", err)
+	}
+	return nil
+}
+
+// isDuplicateKeyError 判断是否为指定唯一索引上的冲突（MySQL 1062）
+func isDuplicateKeyError(err error, index string) bool {
+	message := err.Error()
+	return strings.Contains(message, "Duplicate entry") && strings.Contains(message, index)
 }
@@ -2,25 +2,40 @@ package article
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type Model interface {
 	CreateArticle(ctx context.Context, newArticle *Article) error
-	UpdateArticle(ctx context.Context, articleInfo *Article) error
-	UpdateArticleTagID(ctx context.Context, articleIDList []string, tagID uint64) error
+	UpdateArticle(ctx context.Context, articleInfo *Article, slug *string) (string, error)
+	GetArticleVersion(ctx context.Context, id uint64) (uint64, error)
 	UpdateArticleViewNum(ctx context.Context, id string, viewNum float64) error
 	GetArticleDetailByID(ctx context.Context, id uint64) (*Detail, error)
 	GetArticleListByTagName(ctx context.Context, tagName string) ([]ListByTagName, error)
-	GetArticleDeleteInfo(ctx context.Context, id uint64) (string, error)
-	DeleteArticleByID(ctx context.Context, id uint64) error
+	TrashArticleByID(ctx context.Context, id uint64, now time.Time) error
+	RestoreArticleByID(ctx context.Context, id uint64) error
+	PurgeArticleByID(ctx context.Context, id uint64) error
+	GetTrashedArticleByID(ctx context.Context, id uint64) (*TrashArticleRecord, error)
+	ListTrashedArticles(ctx context.Context, offset int, limit int) ([]TrashArticleRecord, int64, error)
+	ListExpiredTrashedArticleIDs(ctx context.Context, before time.Time, limit int) ([]uint64, error)
 	SearchArticle(ctx context.Context, word string, limit, offset int) ([]SearchArticle, int64, error)
 	GetArticleListByIDList(ctx context.Context, idList []uint64) ([]*Article, error)
 
 	GetArticleList(ctx context.Context, offset, limit int) ([]*Article, error)
 	GetArticleCount(ctx context.Context) (int, error)
 
+	ListArticleTagNames(ctx context.Context, articleIDs []uint64) (map[uint64][]string, error)
+	ReplaceArticleTag(ctx context.Context, articleIDList []string, oldTagID uint64, newTagID uint64) error
+
+	CheckArticleSlugAvailable(ctx context.Context, articleID uint64, slug string) error
+	FindArticleBySlug(ctx context.Context, slug string) (*ArticleSlugLookup, error)
+
+	GetArticleAccess(ctx context.Context, id uint64) (*ArticleAccess, error)
+	UpdateArticleVisibility(ctx context.Context, id uint64, visibility string, passwordHash *string) error
+	ListArticleURLKeys(ctx context.Context, articleIDs []uint64) ([]string, error)
+
 	CreateArticleDraft(ctx context.Context, draft *Article) error
 	UpdateArticleDraft(ctx context.Context, draft *Article) error
 	GetArticleDraftByID(ctx context.Context, id uint64) (*Article, error)
@@ -32,18 +47,68 @@ type Model interface {
 	PublishArticleDraftToPublished(ctx context.Context, draftID uint64, published *Article) error
 	DeleteArticleDraftByID(ctx context.Context, id uint64) error
 
+	ListDueScheduledDrafts(ctx context.Context, now time.Time, limit int) ([]Article, error)
+	ListDueUnpublishArticles(ctx context.Context, now time.Time, limit int) ([]Article, error)
+	ClearArticleDraftSchedule(ctx context.Context, id uint64) error
+	UpdateArticleUnpublishAt(ctx context.Context, id uint64, unpublishAt *time.Time) error
+	UnpublishArticle(ctx context.Context, id uint64, updateTime time.Time) error
+
+	ListSeries(ctx context.Context, publicOnly bool) ([]SeriesListRecord, error)
+	GetSeriesByID(ctx context.Context, id uint64) (*Series, error)
+	ListSeriesArticles(ctx context.Context, seriesID uint64, publicOnly bool) ([]SeriesArticleRecord, error)
+	FindSeriesIDByArticleID(ctx context.Context, articleID uint64) (uint64, error)
+	CreateSeries(ctx context.Context, series *Series, articleIDs []uint64) error
+	UpdateSeries(ctx context.Context, series *Series) error
+	ReplaceSeriesArticles(ctx context.Context, seriesID uint64, articleIDs []uint64, updateTime time.Time) ([]uint64, error)
+	DeleteSeries(ctx context.Context, id uint64) ([]uint64, error)
+
+	ListArticlePromotions(ctx context.Context, kind string, publishedOnly bool) ([]PromotionRecord, error)
+	ReplaceArticlePromotions(ctx context.Context, kind string, articleIDs []uint64, now time.Time) error
+
+	CreateArticleRevision(ctx context.Context, revision *ArticleRevision) error
+	CountArticleRevisions(ctx context.Context, articleID uint64) (int64, error)
+	ListArticleRevisions(ctx context.Context, articleID uint64, offset int, limit int) ([]RevisionListRecord, int64, error)
+	GetArticleRevisionByID(ctx context.Context, id uint64) (*ArticleRevision, error)
+
 	FindArticleImagesByObjectKeys(ctx context.Context, objectKeys []string) (map[string]ArticleImage, error)
 	SyncArticleImageReferences(ctx context.Context, articleID uint64, images []ArticleImage,
 		references []ArticleImageReference) error
 	CreateArticleImage(ctx context.Context, image *ArticleImage) error
+	ReuseArticleImage(ctx context.Context, id uint64, now time.Time) error
+	CreateArticleImageVariants(ctx context.Context, variants []ArticleImageVariant) error
+	ListArticleImageVariants(ctx context.Context, imageID uint64) ([]ArticleImageVariant, error)
+	ListOrphanedArticleImages(ctx context.Context, before time.Time, limit int) ([]ArticleImage, error)
+	MarkArticleImageUsed(ctx context.Context, id uint64, now time.Time) error
+	ListArticleContentsAfter(ctx context.Context, afterID uint64, limit int) ([]ArticleContentRecord, error)
+	ListArticleRevisionContentsAfter(ctx context.Context, afterID uint64, limit int) ([]ArticleContentRecord, error)
 	ListArticleImages(ctx context.Context, query ArticleImageQuery) ([]ArticleImageListRecord, int64, error)
 	GetArticleImageByID(ctx context.Context, id uint64) (*ArticleImage, error)
+	FindArticleImageByContentHash(ctx context.Context, contentHash string) (*ArticleImage, error)
 	ListArticleImageReferences(ctx context.Context, imageID uint64) ([]ArticleImageReferenceRecord, error)
 	CountArticleImageReferences(ctx context.Context, imageID uint64) (int64, error)
-	DeleteArticleImage(ctx context.Context, id uint64) error
+	TrashArticleImage(ctx context.Context, id uint64, now time.Time) error
+	GetTrashedArticleImageByID(ctx context.Context, id uint64) (*ArticleImage, error)
+	ListTrashedArticleImages(ctx context.Context, offset int, limit int) ([]ArticleImageTrashRecord, int64, error)
+	RestoreArticleImage(ctx context.Context, id uint64) error
+	PurgeArticleImage(ctx context.Context, id uint64) error
+	ListExpiredTrashedArticleImages(ctx context.Context, before time.Time, limit int) ([]ArticleImage, error)
+
+	ListExportArticles(ctx context.Context, tagName string) ([]ExportArticleRecord, error)
 
 	ListTimeAndView(ctx context.Context) ([]TimeAndViewZSet, error)
+	ListSearchDocuments(ctx context.Context) ([]SearchDocument, error)
+	ListRelatedArticles(ctx context.Context, ids []uint64) ([]RelatedArticleRecord, error)
+	ListSameTagArticleIDs(ctx context.Context, articleID uint64, limit int) ([]uint64, error)
+	ListArticlesWithoutContentStats(ctx context.Context, limit int) ([]Article, error)
+	UpdateArticleContentStats(ctx context.Context, id uint64, stats ContentStats) error
 	BatchUpdateViewNum(ctx context.Context, items []ViewNumUpdate) error
+	UpsertArticleViewDaily(ctx context.Context, rows []ArticleViewDaily, site *SiteViewDaily) error
+	ListArticleViewDaily(ctx context.Context, articleID uint64) ([]DailyViewCount, error)
+	ListSiteViewDaily(ctx context.Context, from string, to string) ([]DailyViewCount, error)
+	CountPublishedArticlesByDay(ctx context.Context, start time.Time, end time.Time) ([]DailyCount, error)
+
+	CountArchiveMonths(ctx context.Context) ([]ArchiveMonthCount, error)
+	ListArchiveArticles(ctx context.Context, start time.Time, end time.Time) ([]ArchiveArticle, error)
 }
 
 type articleModel struct {
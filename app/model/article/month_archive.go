@@ -0,0 +1,50 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ArchiveMonthCount 某年某月创建的公开文章数
+type ArchiveMonthCount struct {
+	Year  int   `gorm:"column:year"`
+	Month int   `gorm:"column:month"`
+	Count int64 `gorm:"column:count"`
+}
+
+// ArchiveArticle 按月归档列表所需的文章字段
+type ArchiveArticle struct {
+	ID         uint64    `gorm:"column:id"`
+	Title      string    `gorm:"column:title"`
+	Slug       string    `gorm:"column:slug"`
+	CreateTime time.Time `gorm:"column:create_time"`
+}
+
+// CountArchiveMonths 已发布的公开文章按创建年月分组计数，年月均倒序
+func (a *articleModel) CountArchiveMonths(ctx context.Context) ([]ArchiveMonthCount, error) {
+	rows := make([]ArchiveMonthCount, 0)
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Select("YEAR(create_time) AS year, MONTH(create_time) AS month, COUNT(*) AS count").
+		Where("status = ? AND visibility = ?", ArticleStatusPublished, ArticleVisibilityPublic).
+		Group("year, month").
+		Order("year DESC, month DESC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count archive months: %w", err)
+	}
+	return rows, nil
+}
+
+// ListArchiveArticles [start, end) 内创建的已发布公开文章，按创建时间倒序
+func (a *articleModel) ListArchiveArticles(ctx context.Context, start time.Time, end time.Time) ([]ArchiveArticle, error) {
+	rows := make([]ArchiveArticle, 0)
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Select("id, title, IFNULL(slug, '') AS slug, create_time").
+		Where("status = ? AND visibility = ? AND create_time >= ? AND create_time < ?",
+			ArticleStatusPublished, ArticleVisibilityPublic, start, end).
+		Order("create_time DESC").Order("id DESC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list archive articles: %w", err)
+	}
+	return rows, nil
+}
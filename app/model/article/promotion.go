@@ -0,0 +1,108 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 推荐位类型：置顶文章显示在文章列表最前面，精选文章用于首页轮播
+const (
+	PromotionPinned   = "pinned"
+	PromotionFeatured = "featured"
+)
+
+// ErrPromotionArticleNotPublished 加入推荐位的文章不存在或未发布
+var ErrPromotionArticleNotPublished = errors.New("promotion article not found or not published")
+
+// ArticlePromotion 文章推荐位，同一篇文章可以同时置顶和精选，各推荐位内按 SortOrder 排序
+type ArticlePromotion struct {
+	ArticleID  uint64    `gorm:"column:article_id;primaryKey;autoIncrement:false"`
+	Kind       string    `gorm:"column:kind;type:varchar(20);primaryKey;index:idx_article_promotion_sort,priority:1"`
+	SortOrder  int       `gorm:"column:sort_order;NOT NULL;default:0;index:idx_article_promotion_sort,priority:2"`
+	CreateTime time.Time `gorm:"column:create_time;NOT NULL"`
+}
+
+func (ArticlePromotion) TableName() string {
+	return "article_promotion"
+}
+
+type PromotionRecord struct {
+	ArticleID  uint64    `gorm:"column:article_id"`
+	Slug       string    `gorm:"column:slug"`
+	Title      string    `gorm:"column:title"`
+	Describe   string    `gorm:"column:describe"`
+	Status     string    `gorm:"column:status"`
+	SortOrder  int       `gorm:"column:sort_order"`
+	CreateTime time.Time `gorm:"column:create_time"`
+}
+
+// ListArticlePromotions 某一推荐位的文章，按推荐位顺序返回；publishedOnly 时跳过已下线的文章
+func (a *articleModel) ListArticlePromotions(ctx context.Context, kind string,
+	publishedOnly bool) ([]PromotionRecord, error) {
+	query := a.mysql.WithContext(ctx).Table("article_promotion AS ap").
+		Select("ap.article_id, COALESCE(a.slug, '') AS slug, a.title, a.`describe`, a.status, ap.sort_order, a.create_time").
+		Joins("JOIN article AS a ON a.id = ap.article_id AND a.deleted_at IS NULL").
+		Where("ap.kind = ?", kind)
+	if publishedOnly {
+		query = query.Where("a.status = ?", ArticleStatusPublished)
+	}
+	rows := make([]PromotionRecord, 0)
+	if err := query.Order("ap.sort_order ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list article promotions: %w", err)
+	}
+	return rows, nil
+}
+
+// ReplaceArticlePromotions 用 articleIDs 的顺序整体替换某一推荐位，增删与排序都走这里
+func (a *articleModel) ReplaceArticlePromotions(ctx context.Context, kind string, articleIDs []uint64,
+	now time.Time) error {
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(articleIDs) > 0 {
+			var published int64
+			if err := tx.Model(&Article{}).
+				Where("id IN ? AND status = ?", articleIDs, ArticleStatusPublished).
+				Count(&published).Error; err != nil {
+				return fmt.Errorf("failed to check promotion articles: %w", err)
+			}
+			if int(published) != len(articleIDs) {
+				return ErrPromotionArticleNotPublished
+			}
+		}
+
+		previous := make([]ArticlePromotion, 0)
+		if err := tx.Where("kind = ?", kind).Find(&previous).Error; err != nil {
+			return fmt.Errorf("failed to list article promotions: %w", err)
+		}
+		createTimes := make(map[uint64]time.Time, len(previous))
+		for _, row := range previous {
+			createTimes[row.ArticleID] = row.CreateTime
+		}
+		if err := tx.Where("kind = ?", kind).Delete(&ArticlePromotion{}).Error; err != nil {
+			return fmt.Errorf("failed to clear article promotions: %w", err)
+		}
+		if len(articleIDs) == 0 {
+			return nil
+		}
+		rows := make([]ArticlePromotion, 0, len(articleIDs))
+		for i, articleID := range articleIDs {
+			createTime, ok := createTimes[articleID]
+			if !ok {
+				createTime = now
+			}
+			rows = append(rows, ArticlePromotion{
+				ArticleID:  articleID,
+				Kind:       kind,
+				SortOrder:  i + 1,
+				CreateTime: createTime,
+			})
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return fmt.Errorf("failed to create article promotions: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,27 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DailyCount 某一天的数量，日期格式 2006-01-02
+type DailyCount struct {
+	Day   string `gorm:"column:day"`
+	Count int64  `gorm:"column:count"`
+}
+
+// CountPublishedArticlesByDay [start, end) 内按发布日期分组的已发布文章数，不含已下线或移入回收站的文章
+func (a *articleModel) CountPublishedArticlesByDay(ctx context.Context, start time.Time, end time.Time) ([]DailyCount, error) {
+	rows := make([]DailyCount, 0)
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Select("DATE_FORMAT(published_time, '%Y-%m-%d') AS day, COUNT(*) AS count").
+		Where("status = ? AND published_time >= ? AND published_time < ?", ArticleStatusPublished, start, end).
+		Group("day").
+		Order("day ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count published articles by day: %w", err)
+	}
+	return rows, nil
+}
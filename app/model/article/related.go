@@ -0,0 +1,49 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RelatedArticleRecord 相关文章列表展示所需的字段
+type RelatedArticleRecord struct {
+	ID         uint64    `gorm:"column:id"`
+	Title      string    `gorm:"column:title"`
+	Describe   string    `gorm:"column:describe"`
+	ViewNum    uint64    `gorm:"column:view_num"`
+	CreateTime time.Time `gorm:"column:create_time"`
+}
+
+// ListRelatedArticles 按 ID 批量查询已发布的公开文章，未发布、不公开或已删除的文章直接跳过
+func (a *articleModel) ListRelatedArticles(ctx context.Context, ids []uint64) ([]RelatedArticleRecord, error) {
+	list := make([]RelatedArticleRecord, 0, len(ids))
+	if len(ids) == 0 {
+		return list, nil
+	}
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Select("id", "title", "describe", "view_num", "create_time").
+		Where("id IN ? AND status = ? AND visibility = ?", ids, ArticleStatusPublished, ArticleVisibilityPublic).
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to list related articles: %w", err)
+	}
+	return list, nil
+}
+
+// ListSameTagArticleIDs 与文章共享标签的其他已发布文章，共享标签越多越靠前，其次按发布时间倒序
+func (a *articleModel) ListSameTagArticleIDs(ctx context.Context, articleID uint64, limit int) ([]uint64, error) {
+	ids := make([]uint64, 0, limit)
+	if err := a.mysql.WithContext(ctx).Table("article_tag AS self").
+		Select("other.article_id").
+		Joins("JOIN article_tag AS other ON other.tag_id = self.tag_id AND other.article_id <> self.article_id").
+		Joins("JOIN article AS a ON a.id = other.article_id AND a.status = ? AND a.deleted_at IS NULL", ArticleStatusPublished).
+		Where("self.article_id = ?", articleID).
+		Group("other.article_id").
+		Order("COUNT(*) DESC").
+		Order("MAX(a.create_time) DESC").
+		Limit(limit).
+		Pluck("other.article_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list same tag articles: %w", err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,86 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	RevisionSourceBaseline = "baseline" // 首次修改前补录的原始版本
+	RevisionSourceCreate   = "create"
+	RevisionSourceUpdate   = "update"
+	RevisionSourcePublish  = "publish"
+)
+
+// ArticleRevision 文章历史版本快照。
+// 不对 article 建外键：文章被删除后历史版本仍保留，可恢复为新草稿。
+type ArticleRevision struct {
+	ID         uint64    `gorm:"primary_key;NOT NULL"`
+	ArticleID  uint64    `gorm:"column:article_id;NOT NULL;index:idx_article_revision_article,priority:1"`
+	Title      string    `gorm:"type:varchar(100);NOT NULL;default:''"`
+	Describe   string    `gorm:"type:varchar(200);NOT NULL;default:''"`
+	Content    string    `gorm:"type:mediumtext;NOT NULL"`
+	TagName    string    `gorm:"column:tag_name;type:varchar(255);NOT NULL;default:''"` // 多个标签以 TagNameSeparator 连接
+	Source     string    `gorm:"column:source;type:varchar(20);NOT NULL;default:''"`
+	CreateTime time.Time `gorm:"NOT NULL;index:idx_article_revision_article,priority:2"`
+}
+
+type RevisionListRecord struct {
+	ID         uint64    `gorm:"column:id"`
+	ArticleID  uint64    `gorm:"column:article_id"`
+	Title      string    `gorm:"column:title"`
+	TagName    string    `gorm:"column:tag_name"`
+	Source     string    `gorm:"column:source"`
+	CreateTime time.Time `gorm:"column:create_time"`
+}
+
+func (a *articleModel) CreateArticleRevision(ctx context.Context, revision *ArticleRevision) error {
+	if err := a.mysql.WithContext(ctx).Model(&ArticleRevision{}).Create(revision).Error; err != nil {
+		return fmt.Errorf("failed to create article revision: %w", err)
+	}
+	return nil
+}
+
+func (a *articleModel) CountArticleRevisions(ctx context.Context, articleID uint64) (int64, error) {
+	var total int64
+	if err := a.mysql.WithContext(ctx).Model(&ArticleRevision{}).
+		Where("article_id = ?", articleID).
+		Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count article revisions: %w", err)
+	}
+	return total, nil
+}
+
+func (a *articleModel) ListArticleRevisions(ctx context.Context, articleID uint64,
+	offset int, limit int) ([]RevisionListRecord, int64, error) {
+	total, err := a.CountArticleRevisions(ctx, articleID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []RevisionListRecord{}, 0, nil
+	}
+
+	rows := make([]RevisionListRecord, 0)
+	if err = a.mysql.WithContext(ctx).Model(&ArticleRevision{}).
+		Select("id, article_id, title, tag_name, source, create_time").
+		Where("article_id = ?", articleID).
+		Order("create_time DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list article revisions: %w", err)
+	}
+	return rows, total, nil
+}
+
+func (a *articleModel) GetArticleRevisionByID(ctx context.Context, id uint64) (*ArticleRevision, error) {
+	revision := &ArticleRevision{}
+	if err := a.mysql.WithContext(ctx).Model(&ArticleRevision{}).
+		Where("id = ?", id).
+		First(revision).Error; err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
@@ -0,0 +1,84 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ListDueScheduledDrafts 查询定时发布时间已到的草稿
+func (a *articleModel) ListDueScheduledDrafts(ctx context.Context, now time.Time, limit int) ([]Article, error) {
+	drafts := make([]Article, 0)
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", ArticleStatusDraft, now).
+		Order("publish_at ASC, id ASC").
+		Limit(limit).
+		Find(&drafts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list due scheduled drafts: %w", err)
+	}
+	return drafts, nil
+}
+
+// ListDueUnpublishArticles 查询定时下线时间已到的已发布文章
+func (a *articleModel) ListDueUnpublishArticles(ctx context.Context, now time.Time, limit int) ([]Article, error) {
+	articles := make([]Article, 0)
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Select("id", "unpublish_at").
+		Where("status = ? AND unpublish_at IS NOT NULL AND unpublish_at <= ?", ArticleStatusPublished, now).
+		Order("unpublish_at ASC, id ASC").
+		Limit(limit).
+		Find(&articles).Error; err != nil {
+		return nil, fmt.Errorf("failed to list due unpublish articles: %w", err)
+	}
+	return articles, nil
+}
+
+// ClearArticleDraftSchedule 清除草稿的定时发布时间
+func (a *articleModel) ClearArticleDraftSchedule(ctx context.Context, id uint64) error {
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND status = ?", id, ArticleStatusDraft).
+		Update("publish_at", nil).Error; err != nil {
+		return fmt.Errorf("failed to clear article draft schedule: %w", err)
+	}
+	return nil
+}
+
+// UpdateArticleUnpublishAt 设置或清除已发布文章的定时下线时间
+func (a *articleModel) UpdateArticleUnpublishAt(ctx context.Context, id uint64, unpublishAt *time.Time) error {
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND status = ?", id, ArticleStatusPublished).
+		Update("unpublish_at", unpublishAt).Error; err != nil {
+		return fmt.Errorf("failed to update article unpublish time: %w", err)
+	}
+	return nil
+}
+
+// UnpublishArticle 将已发布文章下线为草稿。
+// 原有的编辑草稿失去发布目标，转为独立的新草稿，避免后续发布时找不到已发布文章。
+func (a *articleModel) UnpublishArticle(ctx context.Context, id uint64, updateTime time.Time) error {
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Article{}).
+			Where("published_id = ? AND status = ?", id, ArticleStatusDraft).
+			Update("published_id", nil).Error; err != nil {
+			return fmt.Errorf("failed to detach article edit drafts: %w", err)
+		}
+		result := tx.Model(&Article{}).
+			Where("id = ? AND status = ?", id, ArticleStatusPublished).
+			Updates(map[string]any{
+				"status":       ArticleStatusDraft,
+				"published_id": nil,
+				"publish_at":   nil,
+				"unpublish_at": nil,
+				"update_time":  updateTime,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to unpublish article: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
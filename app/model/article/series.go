@@ -0,0 +1,233 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrSeriesArticleConflict 文章已属于其他系列（一篇文章最多属于一个系列）
+var ErrSeriesArticleConflict = errors.New("article already belongs to another series")
+
+// ErrSeriesArticleNotPublished 加入系列的文章不存在或未发布
+var ErrSeriesArticleNotPublished = errors.New("series article not found or not published")
+
+// Series 文章系列（多篇连载教程等有序合集）
+type Series struct {
+	ID         uint64    `gorm:"primary_key;NOT NULL"`
+	Title      string    `gorm:"type:varchar(100);NOT NULL;default:''"`
+	Describe   string    `gorm:"type:varchar(200);NOT NULL;default:''"`
+	CreateTime time.Time `gorm:"NOT NULL"`
+	UpdateTime time.Time `gorm:"NOT NULL"`
+}
+
+func (Series) TableName() string {
+	return "series"
+}
+
+// SeriesArticle 系列与文章的有序成员关系，文章 ID 唯一，保证一篇文章只属于一个系列
+type SeriesArticle struct {
+	ArticleID uint64 `gorm:"column:article_id;primaryKey;autoIncrement:false"`
+	SeriesID  uint64 `gorm:"column:series_id;NOT NULL;index:idx_series_article_sort,priority:1"`
+	SortOrder int    `gorm:"column:sort_order;NOT NULL;default:0;index:idx_series_article_sort,priority:2"`
+}
+
+func (SeriesArticle) TableName() string {
+	return "series_article"
+}
+
+type SeriesListRecord struct {
+	ID         uint64    `gorm:"column:id"`
+	Title      string    `gorm:"column:title"`
+	Describe   string    `gorm:"column:describe"`
+	ArticleNum int       `gorm:"column:article_num"`
+	CreateTime time.Time `gorm:"column:create_time"`
+	UpdateTime time.Time `gorm:"column:update_time"`
+}
+
+type SeriesArticleRecord struct {
+	ArticleID  uint64    `gorm:"column:article_id"`
+	Title      string    `gorm:"column:title"`
+	Status     string    `gorm:"column:status"`
+	SortOrder  int       `gorm:"column:sort_order"`
+	CreateTime time.Time `gorm:"column:create_time"`
+}
+
+// ListSeries 系列列表，按更新时间倒序；publicOnly 时文章数只统计已发布且公开的文章
+func (a *articleModel) ListSeries(ctx context.Context, publicOnly bool) ([]SeriesListRecord, error) {
+	query := a.mysql.WithContext(ctx).Table("series AS s").
+		Select("s.id, s.title, s.`describe`, COUNT(a.id) AS article_num, s.create_time, s.update_time").
+		Joins("LEFT JOIN series_article AS sa ON sa.series_id = s.id")
+	if publicOnly {
+		query = query.Joins("LEFT JOIN article AS a ON a.id = sa.article_id AND a.status = ? AND a.visibility = ? AND a.deleted_at IS NULL",
+			ArticleStatusPublished, ArticleVisibilityPublic)
+	} else {
+		query = query.Joins("LEFT JOIN article AS a ON a.id = sa.article_id AND a.deleted_at IS NULL")
+	}
+	rows := make([]SeriesListRecord, 0)
+	if err := query.
+		Group("s.id, s.title, s.`describe`, s.create_time, s.update_time").
+		Order("s.update_time DESC").
+		Order("s.id DESC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+	return rows, nil
+}
+
+func (a *articleModel) GetSeriesByID(ctx context.Context, id uint64) (*Series, error) {
+	series := &Series{}
+	if err := a.mysql.WithContext(ctx).Model(&Series{}).Where("id = ?", id).First(series).Error; err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// ListSeriesArticles 系列内的文章，按系列顺序返回；publicOnly 时跳过已下线或不公开的文章
+func (a *articleModel) ListSeriesArticles(ctx context.Context, seriesID uint64,
+	publicOnly bool) ([]SeriesArticleRecord, error) {
+	query := a.mysql.WithContext(ctx).Table("series_article AS sa").
+		Select("sa.article_id, a.title, a.status, sa.sort_order, a.create_time").
+		Joins("JOIN article AS a ON a.id = sa.article_id AND a.deleted_at IS NULL").
+		Where("sa.series_id = ?", seriesID)
+	if publicOnly {
+		query = query.Where("a.status = ? AND a.visibility = ?", ArticleStatusPublished, ArticleVisibilityPublic)
+	}
+	rows := make([]SeriesArticleRecord, 0)
+	if err := query.Order("sa.sort_order ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list series articles: %w", err)
+	}
+	return rows, nil
+}
+
+// FindSeriesIDByArticleID 查询文章所属系列，不属于任何系列时返回 0
+func (a *articleModel) FindSeriesIDByArticleID(ctx context.Context, articleID uint64) (uint64, error) {
+	rows := make([]SeriesArticle, 0, 1)
+	if err := a.mysql.WithContext(ctx).Model(&SeriesArticle{}).
+		Where("article_id = ?", articleID).Limit(1).Find(&rows).Error; err != nil {
+		return 0, fmt.Errorf("failed to find article series: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].SeriesID, nil
+}
+
+func (a *articleModel) CreateSeries(ctx context.Context, series *Series, articleIDs []uint64) error {
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Series{}).Create(series).Error; err != nil {
+			return fmt.Errorf("failed to create series: %w", err)
+		}
+		return replaceSeriesArticles(tx, series.ID, articleIDs)
+	})
+}
+
+func (a *articleModel) UpdateSeries(ctx context.Context, series *Series) error {
+	result := a.mysql.WithContext(ctx).Model(&Series{}).Where("id = ?", series.ID).
+		Updates(map[string]any{
+			"title":       series.Title,
+			"describe":    series.Describe,
+			"update_time": series.UpdateTime,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update series: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := a.GetSeriesByID(ctx, series.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceSeriesArticles 用 articleIDs 的顺序整体替换系列成员，返回替换前的成员，便于调用方清理缓存
+func (a *articleModel) ReplaceSeriesArticles(ctx context.Context, seriesID uint64, articleIDs []uint64,
+	updateTime time.Time) ([]uint64, error) {
+	var previous []uint64
+	err := a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Series{}).Where("id = ?", seriesID).Update("update_time", updateTime)
+		if result.Error != nil {
+			return fmt.Errorf("failed to touch series: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Model(&SeriesArticle{}).Where("series_id = ?", seriesID).
+			Pluck("article_id", &previous).Error; err != nil {
+			return fmt.Errorf("failed to list series articles: %w", err)
+		}
+		return replaceSeriesArticles(tx, seriesID, articleIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+// DeleteSeries 删除系列及其成员关系，返回原成员文章 ID
+func (a *articleModel) DeleteSeries(ctx context.Context, id uint64) ([]uint64, error) {
+	var articleIDs []uint64
+	err := a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&SeriesArticle{}).Where("series_id = ?", id).
+			Pluck("article_id", &articleIDs).Error; err != nil {
+			return fmt.Errorf("failed to list series articles: %w", err)
+		}
+		if err := tx.Where("series_id = ?", id).Delete(&SeriesArticle{}).Error; err != nil {
+			return fmt.Errorf("failed to delete series articles: %w", err)
+		}
+		result := tx.Where("id = ?", id).Delete(&Series{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete series: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return articleIDs, nil
+}
+
+// replaceSeriesArticles 需在事务内调用：校验文章均已发布且不属于其他系列，再按顺序写入
+func replaceSeriesArticles(tx *gorm.DB, seriesID uint64, articleIDs []uint64) error {
+	if len(articleIDs) > 0 {
+		var published int64
+		if err := tx.Model(&Article{}).
+			Where("id IN ? AND status = ?", articleIDs, ArticleStatusPublished).
+			Count(&published).Error; err != nil {
+			return fmt.Errorf("failed to check series articles: %w", err)
+		}
+		if int(published) != len(articleIDs) {
+			return ErrSeriesArticleNotPublished
+		}
+		var conflicts int64
+		if err := tx.Model(&SeriesArticle{}).
+			Where("article_id IN ? AND series_id <> ?", articleIDs, seriesID).
+			Count(&conflicts).Error; err != nil {
+			return fmt.Errorf("failed to check series article conflict: %w", err)
+		}
+		if conflicts > 0 {
+			return ErrSeriesArticleConflict
+		}
+	}
+
+	if err := tx.Where("series_id = ?", seriesID).Delete(&SeriesArticle{}).Error; err != nil {
+		return fmt.Errorf("failed to clear series articles: %w", err)
+	}
+	if len(articleIDs) == 0 {
+		return nil
+	}
+	rows := make([]SeriesArticle, 0, len(articleIDs))
+	for i, articleID := range articleIDs {
+		rows = append(rows, SeriesArticle{ArticleID: articleID, SeriesID: seriesID, SortOrder: i + 1})
+	}
+	if err := tx.Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to create series articles: %w", err)
+	}
+	return nil
+}
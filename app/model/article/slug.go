@@ -0,0 +1,165 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrArticleSlugConflict slug 已被其他文章使用（当前 slug 或历史 slug）
+var ErrArticleSlugConflict = errors.New("article slug already in use")
+
+// ArticleSlugHistory 文章曾经使用过的 slug，旧地址据此永久重定向到文章当前地址。
+// slug 全局唯一：一个历史 slug 只能指向一篇文章。
+type ArticleSlugHistory struct {
+	Slug       string    `gorm:"column:slug;type:varchar(100);primaryKey"`
+	ArticleID  uint64    `gorm:"column:article_id;NOT NULL;index"`
+	CreateTime time.Time `gorm:"NOT NULL"`
+}
+
+func (ArticleSlugHistory) TableName() string {
+	return "article_slug_history"
+}
+
+// ArticleSlugLookup 按 slug 查找文章的结果；Moved 表示命中的是历史 slug，CurrentSlug 为文章现在的 slug（可能为空）
+type ArticleSlugLookup struct {
+	ArticleID   uint64
+	CurrentSlug string
+	Moved       bool
+}
+
+// CheckArticleSlugAvailable 校验 slug 未被 articleID 以外的文章占用，articleID 为 0 表示新文章
+func (a *articleModel) CheckArticleSlugAvailable(ctx context.Context, articleID uint64, slug string) error {
+	return checkArticleSlugAvailable(a.mysql.WithContext(ctx), articleID, slug)
+}
+
+// setArticleSlug 需在事务内调用：修改已发布文章的 slug，旧 slug 写入历史表；slug 为空表示取消自定义地址。
+// 返回修改前的 slug，未变化时不做任何写入。
+func setArticleSlug(tx *gorm.DB, articleID uint64, slug string, now time.Time) (string, error) {
+	current := &Article{}
+	if err := tx.Model(&Article{}).Select("id", "slug").
+		Where("id = ? AND status = ?", articleID, ArticleStatusPublished).
+		First(current).Error; err != nil {
+		return "", err
+	}
+	var oldSlug string
+	if current.Slug != nil {
+		oldSlug = *current.Slug
+	}
+	if oldSlug == slug {
+		return oldSlug, nil
+	}
+	if slug != "" {
+		if err := checkArticleSlugAvailable(tx, articleID, slug); err != nil {
+			return "", err
+		}
+		// 改回曾经用过的 slug 时，它不再是历史地址
+		if err := tx.Where("slug = ? AND article_id = ?", slug, articleID).
+			Delete(&ArticleSlugHistory{}).Error; err != nil {
+			return "", fmt.Errorf("failed to delete article slug history: %w", err)
+		}
+	}
+	if oldSlug != "" {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ArticleSlugHistory{
+			Slug:       oldSlug,
+			ArticleID:  articleID,
+			CreateTime: now,
+		}).Error; err != nil {
+			return "", fmt.Errorf("failed to create article slug history: %w", err)
+		}
+	}
+	var value any
+	if slug != "" {
+		value = slug
+	}
+	if err := tx.Model(&Article{}).Where("id = ?", articleID).Update("slug", value).Error; err != nil {
+		return "", fmt.Errorf("failed to update article slug: %w", err)
+	}
+	return oldSlug, nil
+}
+
+// FindArticleBySlug 先按当前 slug 查找已发布文章，再查历史 slug；都不存在时返回 gorm.ErrRecordNotFound
+func (a *articleModel) FindArticleBySlug(ctx context.Context, slug string) (*ArticleSlugLookup, error) {
+	db := a.mysql.WithContext(ctx)
+	rows := make([]Article, 0, 1)
+	if err := db.Model(&Article{}).Select("id", "slug").
+		Where("slug = ? AND status = ?", slug, ArticleStatusPublished).
+		Limit(1).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find article by slug: %w", err)
+	}
+	if len(rows) > 0 {
+		return &ArticleSlugLookup{ArticleID: rows[0].ID, CurrentSlug: slug}, nil
+	}
+
+	moved := make([]struct {
+		ArticleID uint64  `gorm:"column:article_id"`
+		Slug      *string `gorm:"column:slug"`
+	}, 0, 1)
+	if err := db.Table("article_slug_history AS h").
+		Select("h.article_id, a.slug").
+		Joins("JOIN article AS a ON a.id = h.article_id AND a.status = ? AND a.deleted_at IS NULL", ArticleStatusPublished).
+		Where("h.slug = ?", slug).
+		Limit(1).Find(&moved).Error; err != nil {
+		return nil, fmt.Errorf("failed to find article slug history: %w", err)
+	}
+	if len(moved) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	lookup := &ArticleSlugLookup{ArticleID: moved[0].ArticleID, Moved: true}
+	if moved[0].Slug != nil {
+		lookup.CurrentSlug = *moved[0].Slug
+	}
+	return lookup, nil
+}
+
+// ListArticleURLKeys 文章详情页可被访问到的全部地址段：文章 ID、当前 slug 与历史 slug，
+// 用于 CDN 清理与 sitemap 刷新
+func (a *articleModel) ListArticleURLKeys(ctx context.Context, articleIDs []uint64) ([]string, error) {
+	keys := make([]string, 0, len(articleIDs))
+	if len(articleIDs) == 0 {
+		return keys, nil
+	}
+	for _, id := range articleIDs {
+		keys = append(keys, strconv.FormatUint(id, 10))
+	}
+	db := a.mysql.WithContext(ctx)
+	var slugs []string
+	if err := db.Model(&Article{}).
+		Where("id IN ? AND slug IS NOT NULL", articleIDs).
+		Pluck("slug", &slugs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list article slugs: %w", err)
+	}
+	var historySlugs []string
+	if err := db.Model(&ArticleSlugHistory{}).
+		Where("article_id IN ?", articleIDs).
+		Pluck("slug", &historySlugs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list article slug history: %w", err)
+	}
+	keys = append(keys, slugs...)
+	return append(keys, historySlugs...), nil
+}
+
+func checkArticleSlugAvailable(db *gorm.DB, articleID uint64, slug string) error {
+	var count int64
+	// 回收站中的文章仍占用 slug，恢复后地址保持不变
+	if err := db.Unscoped().Model(&Article{}).Where("slug = ? AND id <> ?", slug, articleID).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check article slug: %w", err)
+	}
+	if count > 0 {
+		return ErrArticleSlugConflict
+	}
+	if err := db.Model(&ArticleSlugHistory{}).Where("slug = ? AND article_id <> ?", slug, articleID).
+		Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check article slug history: %w", err)
+	}
+	if count > 0 {
+		return ErrArticleSlugConflict
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TrashArticleRecord 回收站中的文章
+type TrashArticleRecord struct {
+	ID         uint64    `gorm:"column:id"`
+	Title      string    `gorm:"column:title"`
+	CreateTime time.Time `gorm:"column:create_time"`
+	DeletedAt  time.Time `gorm:"column:deleted_at"`
+}
+
+// TrashArticleByID 把已发布文章及其编辑草稿移入回收站，标签、系列与 slug 等关联保留，便于恢复
+func (a *articleModel) TrashArticleByID(ctx context.Context, id uint64, now time.Time) error {
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Article{}).
+			Where("id = ? AND status = ?", id, ArticleStatusPublished).
+			Update("deleted_at", now)
+		if result.Error != nil {
+			return fmt.Errorf("failed to trash article: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Model(&Article{}).
+			Where("published_id = ? AND status = ?", id, ArticleStatusDraft).
+			Update("deleted_at", now).Error; err != nil {
+			return fmt.Errorf("failed to trash article drafts: %w", err)
+		}
+		return nil
+	})
+}
+
+// RestoreArticleByID 从回收站恢复文章及与其一同删除的编辑草稿
+func (a *articleModel) RestoreArticleByID(ctx context.Context, id uint64) error {
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx = tx.Unscoped()
+		result := tx.Model(&Article{}).
+			Where("id = ? AND status = ? AND deleted_at IS NOT NULL", id, ArticleStatusPublished).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore article: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Model(&Article{}).
+			Where("published_id = ? AND status = ? AND deleted_at IS NOT NULL", id, ArticleStatusDraft).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore article drafts: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetTrashedArticleByID 查询回收站中的文章
+func (a *articleModel) GetTrashedArticleByID(ctx context.Context, id uint64) (*TrashArticleRecord, error) {
+	record := &TrashArticleRecord{}
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&Article{}).
+		Select("id", "title", "create_time", "deleted_at").
+		Where("id = ? AND status = ? AND deleted_at IS NOT NULL", id, ArticleStatusPublished).
+		First(record).Error; err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// ListTrashedArticles 回收站文章列表，最近删除的在前
+func (a *articleModel) ListTrashedArticles(ctx context.Context, offset int, limit int) ([]TrashArticleRecord, int64, error) {
+	query := a.mysql.WithContext(ctx).Unscoped().Model(&Article{}).
+		Where("status = ? AND deleted_at IS NOT NULL", ArticleStatusPublished)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count trashed articles: %w", err)
+	}
+	rows := make([]TrashArticleRecord, 0)
+	if total == 0 {
+		return rows, 0, nil
+	}
+	if err := query.Select("id", "title", "create_time", "deleted_at").
+		Order("deleted_at DESC").
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list trashed articles: %w", err)
+	}
+	return rows, total, nil
+}
+
+// ListExpiredTrashedArticleIDs 删除时间早于 before 的回收站文章
+func (a *articleModel) ListExpiredTrashedArticleIDs(ctx context.Context, before time.Time, limit int) ([]uint64, error) {
+	ids := make([]uint64, 0)
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&Article{}).
+		Where("status = ? AND deleted_at IS NOT NULL AND deleted_at < ?", ArticleStatusPublished, before).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expired trashed articles: %w", err)
+	}
+	return ids, nil
+}
+
+// ArticleImageTrashRecord 回收站中的图片
+type ArticleImageTrashRecord struct {
+	ID         uint64    `gorm:"column:id"`
+	ObjectKey  string    `gorm:"column:object_key"`
+	URL        string    `gorm:"column:url"`
+	ImageName  string    `gorm:"column:image_name"`
+	Mime       string    `gorm:"column:mime"`
+	Size       int64     `gorm:"column:size"`
+	RefCount   int       `gorm:"column:ref_count"`
+	CreateTime time.Time `gorm:"column:create_time"`
+	DeletedAt  time.Time `gorm:"column:deleted_at"`
+}
+
+func (a *articleModel) GetTrashedArticleImageByID(ctx context.Context, id uint64) (*ArticleImage, error) {
+	image := &ArticleImage{}
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&ArticleImage{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(image).Error; err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// ListTrashedArticleImages 回收站图片列表，最近删除的在前
+func (a *articleModel) ListTrashedArticleImages(ctx context.Context, offset int,
+	limit int) ([]ArticleImageTrashRecord, int64, error) {
+	var total int64
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&ArticleImage{}).
+		Where("deleted_at IS NOT NULL").
+		Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count trashed article images: %w", err)
+	}
+	records := make([]ArticleImageTrashRecord, 0)
+	if total == 0 {
+		return records, 0, nil
+	}
+
+	refCountSubQuery := a.mysql.Model(&ArticleImageReference{}).
+		Select("image_id, SUM(ref_count) AS ref_count").
+		Group("image_id")
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&ArticleImage{}).
+		Select("article_image.id, article_image.object_key, article_image.url, article_image.image_name, "+
+			"article_image.mime, article_image.size, COALESCE(refs.ref_count, 0) AS ref_count, "+
+			"article_image.create_time, article_image.deleted_at").
+		Joins("LEFT JOIN (?) AS refs ON refs.image_id = article_image.id", refCountSubQuery).
+		Where("article_image.deleted_at IS NOT NULL").
+		Order("article_image.deleted_at DESC, article_image.id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&records).Error; err != nil {
+		return nil, 0, fmt.Errorf("list trashed article images: %w", err)
+	}
+	return records, total, nil
+}
+
+func (a *articleModel) RestoreArticleImage(ctx context.Context, id uint64) error {
+	result := a.mysql.WithContext(ctx).Unscoped().Model(&ArticleImage{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("restore article image: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgeArticleImage 彻底删除图片记录及其引用关系、变体，COS 对象由调用方先行删除
+func (a *articleModel) PurgeArticleImage(ctx context.Context, id uint64) error {
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("image_id = ?", id).Delete(&ArticleImageReference{}).Error; err != nil {
+			return fmt.Errorf("delete article image references: %w", err)
+		}
+		if err := tx.Where("image_id = ?", id).Delete(&ArticleImageVariant{}).Error; err != nil {
+			return fmt.Errorf("delete article image variants: %w", err)
+		}
+		if err := tx.Unscoped().Delete(&ArticleImage{}, id).Error; err != nil {
+			return fmt.Errorf("purge article image: %w", err)
+		}
+		return nil
+	})
+}
+
+func (a *articleModel) ListExpiredTrashedArticleImages(ctx context.Context, before time.Time,
+	limit int) ([]ArticleImage, error) {
+	images := make([]ArticleImage, 0)
+	if err := a.mysql.WithContext(ctx).Unscoped().Model(&ArticleImage{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&images).Error; err != nil {
+		return nil, fmt.Errorf("list expired trashed article images: %w", err)
+	}
+	return images, nil
+}
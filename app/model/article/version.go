@@ -0,0 +1,44 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ErrArticleVersionConflict 保存时携带的版本号与库中不一致，文章或草稿已被其他页面修改
+var ErrArticleVersionConflict = errors.New("article version conflict")
+
+// GetArticleVersion 已发布文章当前的版本号
+func (a *articleModel) GetArticleVersion(ctx context.Context, id uint64) (uint64, error) {
+	var version uint64
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Select("version").
+		Where("id = ? AND status = ?", id, ArticleStatusPublished).
+		Take(&version).Error; err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// bumpArticleVersion 在事务内把版本号加一并返回新版本，行锁保证同一时刻只有一个保存能通过校验。
+// expected 必须是调用方读到的版本号，不一致（包括未携带版本号）一律返回 ErrArticleVersionConflict
+func bumpArticleVersion(tx *gorm.DB, id uint64, status string, expected uint64) (uint64, error) {
+	result := tx.Model(&Article{}).
+		Where("id = ? AND status = ? AND version = ?", id, status, expected).
+		Update("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to bump article version: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return 0, ErrArticleVersionConflict
+	}
+
+	var version uint64
+	if err := tx.Model(&Article{}).Select("version").Where("id = ?", id).Take(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to get article version: %w", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,100 @@
+package article
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ArticleViewDaily 文章按天（站点时区）的浏览量与去重访客数，由定时任务从 Redis 分桶回写
+type ArticleViewDaily struct {
+	ArticleID uint64 `gorm:"column:article_id;primaryKey;autoIncrement:false"`
+	Day       string `gorm:"column:day;type:date;primaryKey;index"`
+	Views     uint64 `gorm:"column:views;NOT NULL;default:0"`
+	Visitors  uint64 `gorm:"column:visitors;NOT NULL;default:0"`
+}
+
+func (ArticleViewDaily) TableName() string {
+	return "article_view_daily"
+}
+
+// SiteViewDaily 全站按天的去重访客数与去重后的（访客, 文章）组合数；全站浏览量由 article_view_daily 汇总
+type SiteViewDaily struct {
+	Day         string `gorm:"column:day;type:date;primaryKey"`
+	Visitors    uint64 `gorm:"column:visitors;NOT NULL;default:0"`
+	UniqueViews uint64 `gorm:"column:unique_views;NOT NULL;default:0"`
+}
+
+func (SiteViewDaily) TableName() string {
+	return "site_view_daily"
+}
+
+// DailyViewCount 某一天的计数
+type DailyViewCount struct {
+	Day         string `gorm:"column:day"`
+	Views       uint64 `gorm:"column:views"`
+	Visitors    uint64 `gorm:"column:visitors"`
+	UniqueViews uint64 `gorm:"column:unique_views"`
+}
+
+// UpsertArticleViewDaily 写入按天计数。Redis 分桶保存的是当天累计值，重复回写时逐列取较大值，
+// 保证回写可重入，Redis 数据丢失后也不会把已落库的计数改小
+func (a *articleModel) UpsertArticleViewDaily(ctx context.Context, rows []ArticleViewDaily, site *SiteViewDaily) error {
+	return a.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(rows) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "article_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]any{
+					"views":    gorm.Expr("GREATEST(views, VALUES(views))"),
+					"visitors": gorm.Expr("GREATEST(visitors, VALUES(visitors))"),
+				}),
+			}).CreateInBatches(rows, 500).Error; err != nil {
+				return fmt.Errorf("failed to upsert article view daily: %w", err)
+			}
+		}
+		if site != nil {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]any{
+					"visitors":     gorm.Expr("GREATEST(visitors, VALUES(visitors))"),
+					"unique_views": gorm.Expr("GREATEST(unique_views, VALUES(unique_views))"),
+				}),
+			}).Create(site).Error; err != nil {
+				return fmt.Errorf("failed to upsert site view daily: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListArticleViewDaily 单篇文章全部的按天计数，按日期正序
+func (a *articleModel) ListArticleViewDaily(ctx context.Context, articleID uint64) ([]DailyViewCount, error) {
+	rows := make([]DailyViewCount, 0)
+	if err := a.mysql.WithContext(ctx).Model(&ArticleViewDaily{}).
+		Select("DATE_FORMAT(day, '%Y-%m-%d') AS day, views, visitors").
+		Where("article_id = ?", articleID).
+		Order("day ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list article view daily: %w", err)
+	}
+	return rows, nil
+}
+
+// ListSiteViewDaily 全站 [from, to] 日期范围内每天的计数，日期格式 2006-01-02
+func (a *articleModel) ListSiteViewDaily(ctx context.Context, from string, to string) ([]DailyViewCount, error) {
+	rows := make([]DailyViewCount, 0)
+	if err := a.mysql.WithContext(ctx).Table("(?) AS v", a.mysql.Model(&ArticleViewDaily{}).
+		Select("day, SUM(views) AS views").
+		Where("day BETWEEN ? AND ?", from, to).
+		Group("day")).
+		Select("DATE_FORMAT(v.day, '%Y-%m-%d') AS day, v.views, " +
+			"IFNULL(s.visitors, 0) AS visitors, IFNULL(s.unique_views, 0) AS unique_views").
+		Joins("LEFT JOIN site_view_daily s ON s.day = v.day").
+		Order("v.day ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list site view daily: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,61 @@
+package article
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// ArticleAccess 已发布文章的可见性与访问密码（bcrypt）
+type ArticleAccess struct {
+	ID             uint64 `gorm:"column:id"`
+	Title          string `gorm:"column:title"`
+	Visibility     string `gorm:"column:visibility"`
+	AccessPassword string `gorm:"column:access_password"`
+}
+
+// AccessVersion 访问密码哈希的短摘要，写入文章缓存与访问令牌；修改密码后旧令牌随之失效
+func AccessVersion(passwordHash string) string {
+	if passwordHash == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(passwordHash))
+	return hex.EncodeToString(sum[:8])
+}
+
+// GetArticleAccess 查询已发布文章的可见性与访问密码
+func (a *articleModel) GetArticleAccess(ctx context.Context, id uint64) (*ArticleAccess, error) {
+	access := &ArticleAccess{}
+	if err := a.mysql.WithContext(ctx).Model(&Article{}).
+		Select("id, title, visibility, access_password").
+		Where("id = ? AND status = ?", id, ArticleStatusPublished).
+		First(access).Error; err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+// UpdateArticleVisibility 修改已发布文章的可见性。passwordHash 为 nil 时保留原密码，
+// 非密码保护的可见性一律清空密码
+func (a *articleModel) UpdateArticleVisibility(ctx context.Context, id uint64, visibility string,
+	passwordHash *string) error {
+	updates := map[string]any{"visibility": visibility}
+	if visibility != ArticleVisibilityProtected {
+		updates["access_password"] = ""
+	} else if passwordHash != nil {
+		updates["access_password"] = *passwordHash
+	}
+	result := a.mysql.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND status = ?", id, ArticleStatusPublished).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update article visibility: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := a.GetArticleAccess(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
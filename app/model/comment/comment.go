@@ -7,6 +7,8 @@ import (
 
 	"meta-api/app/model/article"
 	"meta-api/common/utils"
+
+	"gorm.io/gorm"
 )
 
 const (
@@ -34,10 +36,12 @@ type Comment struct {
 	AuthorName        string          `gorm:"type:varchar(80);NOT NULL"`
 	Content           string          `gorm:"type:varchar(1000);NOT NULL"`
 	Status            string          `gorm:"type:varchar(20);NOT NULL;default:pending;index:idx_comment_article_status_time,priority:2"`
+	ModerationStatus  string          `gorm:"column:moderation_status;type:varchar(20);NOT NULL;default:''"`
 	ModerationReasons string          `gorm:"column:moderation_reasons;type:text"`
 	IP                string          `gorm:"type:varchar(64)"`
-	CreateTime        time.Time       `gorm:"column:create_time;NOT NULL;index:idx_comment_article_status_time,priority:3"`
+	CreateTime        time.Time       `gorm:"column:create_time;NOT NULL;index:idx_comment_article_status_time,priority:3;index"`
 	UpdateTime        time.Time       `gorm:"column:update_time;NOT NULL"`
+	DeletedAt         gorm.DeletedAt  `gorm:"column:deleted_at;index"`
 	Article           article.Article `gorm:"foreignKey:ArticleID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
 
@@ -116,7 +120,7 @@ func (m *commentModel) ListApprovedByArticleID(ctx context.Context, articleID ui
 	if err := m.mysql.WithContext(ctx).Model(&Comment{}).Table("comment as c").
 		Joins("LEFT JOIN `user` as u ON u.id = c.user_id").
 		Joins("LEFT JOIN `user` as ru ON ru.id = c.reply_to_user_id").
-		Joins("LEFT JOIN `comment` as rc ON rc.id = c.reply_to_comment_id").
+		Joins("LEFT JOIN `comment` as rc ON rc.id = c.reply_to_comment_id AND rc.deleted_at IS NULL").
 		Where("c.article_id = ? AND c.status = ?", articleID, StatusApproved).
 		Select("c.id, c.article_id, c.parent_id, c.user_id, c.reply_to_user_id, c.reply_to_comment_id, ru.display_name as reply_to_author_name, ru.handle as reply_to_author_handle, rc.content as reply_to_content, c.author_name, u.handle as author_handle, u.avatar_url, c.content, c.create_time").
 		Order("c.create_time ASC").
@@ -169,7 +173,7 @@ func (m *commentModel) ListApprovedRepliesByParentID(ctx context.Context, parent
 	if err := query.
 		Joins("LEFT JOIN `user` as u ON u.id = c.user_id").
 		Joins("LEFT JOIN `user` as ru ON ru.id = c.reply_to_user_id").
-		Joins("LEFT JOIN `comment` as rc ON rc.id = c.reply_to_comment_id").
+		Joins("LEFT JOIN `comment` as rc ON rc.id = c.reply_to_comment_id AND rc.deleted_at IS NULL").
 		Select("c.id, c.article_id, c.parent_id, c.user_id, c.reply_to_user_id, c.reply_to_comment_id, ru.display_name as reply_to_author_name, ru.handle as reply_to_author_handle, rc.content as reply_to_content, c.author_name, u.handle as author_handle, u.avatar_url, c.content, c.create_time").
 		Order("c.create_time ASC").
 		Offset(offset).
@@ -23,6 +23,16 @@ type Model interface {
 	UpdateCommentStatus(ctx context.Context, id uint64, status string, updateTime time.Time) error
 	DeleteComment(ctx context.Context, id uint64) error
 	DeleteComments(ctx context.Context, ids []uint64) error
+
+	ListTrashedComments(ctx context.Context, offset int, limit int) ([]TrashListItem, int64, error)
+	GetTrashedCommentsByIDs(ctx context.Context, ids []uint64) ([]*Comment, error)
+	RestoreComments(ctx context.Context, ids []uint64) error
+	PurgeComments(ctx context.Context, ids []uint64) error
+	ListExpiredTrashedCommentIDs(ctx context.Context, before time.Time, limit int) ([]uint64, error)
+
+	CountCommentsByDay(ctx context.Context, start time.Time, end time.Time) ([]DailyStatusCount, error)
+	CountCommentReportsByDay(ctx context.Context, start time.Time, end time.Time) ([]DailyStatusCount, error)
+	CountPendingCommentReports(ctx context.Context) (int64, error)
 }
 
 type commentModel struct {
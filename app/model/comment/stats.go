@@ -0,0 +1,56 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DailyStatusCount 某一天按状态分组的数量，日期格式 2006-01-02。
+// ModerationStatus 为提交时自动审核的结果，不随后台处理变化；早于该字段的历史评论为空
+type DailyStatusCount struct {
+	Day              string `gorm:"column:day"`
+	Status           string `gorm:"column:status"`
+	ModerationStatus string `gorm:"column:moderation_status"`
+	Count            int64  `gorm:"column:count"`
+}
+
+// CountCommentsByDay [start, end) 内按创建日期、当前状态与自动审核结果分组的评论数。
+// 回收站中的评论同样计入，避免清理垃圾评论后历史趋势被改写
+func (m *commentModel) CountCommentsByDay(ctx context.Context, start time.Time, end time.Time) ([]DailyStatusCount, error) {
+	rows := make([]DailyStatusCount, 0)
+	if err := m.mysql.WithContext(ctx).Unscoped().Model(&Comment{}).
+		Select("DATE_FORMAT(create_time, '%Y-%m-%d') AS day, status, moderation_status, COUNT(*) AS count").
+		Where("create_time >= ? AND create_time < ?", start, end).
+		Group("day, status, moderation_status").
+		Order("day ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count comments by day: %w", err)
+	}
+	return rows, nil
+}
+
+// CountCommentReportsByDay [start, end) 内按举报日期与处理状态分组的举报数
+func (m *commentModel) CountCommentReportsByDay(ctx context.Context, start time.Time, end time.Time) ([]DailyStatusCount, error) {
+	rows := make([]DailyStatusCount, 0)
+	if err := m.mysql.WithContext(ctx).Model(&CommentReport{}).
+		Select("DATE_FORMAT(create_time, '%Y-%m-%d') AS day, status, COUNT(*) AS count").
+		Where("create_time >= ? AND create_time < ?", start, end).
+		Group("day, status").
+		Order("day ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count comment reports by day: %w", err)
+	}
+	return rows, nil
+}
+
+// CountPendingCommentReports 当前仍待处理的举报总数，不限时间范围
+func (m *commentModel) CountPendingCommentReports(ctx context.Context) (int64, error) {
+	var total int64
+	if err := m.mysql.WithContext(ctx).Model(&CommentReport{}).
+		Where("status = ?", ReportStatusPending).
+		Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count pending comment reports: %w", err)
+	}
+	return total, nil
+}
@@ -0,0 +1,102 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TrashListItem struct {
+	ID           uint64    `gorm:"column:id"`
+	ArticleID    uint64    `gorm:"column:article_id"`
+	ArticleTitle string    `gorm:"column:article_title"`
+	AuthorName   string    `gorm:"column:author_name"`
+	Content      string    `gorm:"column:content"`
+	Status       string    `gorm:"column:status"`
+	CreateTime   time.Time `gorm:"column:create_time"`
+	DeletedAt    time.Time `gorm:"column:deleted_at"`
+}
+
+// ListTrashedComments 回收站评论列表，最近删除的在前
+func (m *commentModel) ListTrashedComments(ctx context.Context, offset int, limit int) ([]TrashListItem, int64, error) {
+	query := m.mysql.WithContext(ctx).Unscoped().Model(&Comment{}).Table("comment as c").
+		Where("c.deleted_at IS NOT NULL")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count trashed comments: %w", err)
+	}
+
+	rows := make([]TrashListItem, 0)
+	if total == 0 {
+		return rows, 0, nil
+	}
+
+	if err := query.
+		Joins("LEFT JOIN article as a ON a.id = c.article_id").
+		Select("c.id, c.article_id, a.title as article_title, c.author_name, c.content, c.status, c.create_time, c.deleted_at").
+		Order("c.deleted_at DESC").
+		Order("c.id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list trashed comments: %w", err)
+	}
+	return rows, total, nil
+}
+
+func (m *commentModel) GetTrashedCommentsByIDs(ctx context.Context, ids []uint64) ([]*Comment, error) {
+	items := make([]*Comment, 0, len(ids))
+	if len(ids) == 0 {
+		return items, nil
+	}
+	if err := m.mysql.WithContext(ctx).Unscoped().Model(&Comment{}).
+		Where("id IN ? AND deleted_at IS NOT NULL", ids).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (m *commentModel) RestoreComments(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := m.mysql.WithContext(ctx).Unscoped().Model(&Comment{}).
+		Where("id IN ? AND deleted_at IS NOT NULL", ids).
+		Update("deleted_at", nil).Error; err != nil {
+		return fmt.Errorf("failed to restore comments: %w", err)
+	}
+	return nil
+}
+
+// PurgeComments 彻底删除回收站中的评论及其举报记录
+func (m *commentModel) PurgeComments(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return m.mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id IN ?", ids).Delete(&CommentReport{}).Error; err != nil {
+			return fmt.Errorf("failed to delete comment reports: %w", err)
+		}
+		if err := tx.Unscoped().Where("id IN ? AND deleted_at IS NOT NULL", ids).
+			Delete(&Comment{}).Error; err != nil {
+			return fmt.Errorf("failed to purge comments: %w", err)
+		}
+		return nil
+	})
+}
+
+func (m *commentModel) ListExpiredTrashedCommentIDs(ctx context.Context, before time.Time, limit int) ([]uint64, error) {
+	ids := make([]uint64, 0)
+	if err := m.mysql.WithContext(ctx).Unscoped().Model(&Comment{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expired trashed comments: %w", err)
+	}
+	return ids, nil
+}
@@ -45,12 +45,14 @@ func (t *tagModel) FindTagByName(ctx context.Context, tagName string) (*Tag, err
 	return tagInfo, nil
 }
 
-// GetArticleCountWithTagName 获取标签名称下的文章数量
+// GetArticleCountWithTagName 获取标签名称下的文章数量，只统计已发布且公开的文章
 func (t *tagModel) GetArticleCountWithTagName(ctx context.Context) ([]ArticleCountWithTag, error) {
 	tagList := make([]ArticleCountWithTag, 0)
 	if err := t.mysql.WithContext(ctx).Model(&Tag{}).Table("tag as t").
 		Select("t.name, COUNT(a.id) AS count").
-		Joins("JOIN article as a ON a.tag_id = t.id AND a.status = ?", constants.ArticleStatusPublished).
+		Joins("JOIN article_tag as at ON at.tag_id = t.id").
+		Joins("JOIN article as a ON a.id = at.article_id AND a.status = ? AND a.visibility = ? AND a.deleted_at IS NULL",
+			constants.ArticleStatusPublished, constants.ArticleVisibilityPublic).
 		Group("t.id").
 		Having("COUNT(a.id) > 0").
 		Order("count DESC").
@@ -65,7 +67,8 @@ func (t *tagModel) GetArticleListByTagName(ctx context.Context, tagName string)
 	articleList := make([]ArticleListByTagName, 0)
 	if err := t.mysql.WithContext(ctx).Model(&Tag{}).Table("tag as t").
 		Select("a.id, a.create_time").
-		Joins("JOIN article as a ON a.tag_id = t.id AND a.status = ?", constants.ArticleStatusPublished).
+		Joins("JOIN article_tag as at ON at.tag_id = t.id").
+		Joins("JOIN article as a ON a.id = at.article_id AND a.status = ? AND a.deleted_at IS NULL", constants.ArticleStatusPublished).
 		Where("t.name = ?", tagName).
 		Find(&articleList).Error; err != nil {
 		return nil, err
@@ -14,6 +14,7 @@ type Model interface {
 	ListUsers(ctx context.Context, filter AdminListFilter) ([]AdminListItem, int64, error)
 	UpdateCommentPermission(ctx context.Context, id uint64, disabled bool, reason string, disabledUntil *time.Time, updateTime time.Time) error
 	IncrementSessionVersion(ctx context.Context, id uint64, updateTime time.Time) error
+	CountUsersByDay(ctx context.Context, start time.Time, end time.Time) ([]DailyCount, error)
 }
 
 type userModel struct {
@@ -0,0 +1,27 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DailyCount 某一天的数量，日期格式 2006-01-02
+type DailyCount struct {
+	Day   string `gorm:"column:day"`
+	Count int64  `gorm:"column:count"`
+}
+
+// CountUsersByDay [start, end) 内按注册日期分组的新用户数
+func (m *userModel) CountUsersByDay(ctx context.Context, start time.Time, end time.Time) ([]DailyCount, error) {
+	rows := make([]DailyCount, 0)
+	if err := m.mysql.WithContext(ctx).Model(&User{}).
+		Select("DATE_FORMAT(create_time, '%Y-%m-%d') AS day, COUNT(*) AS count").
+		Where("create_time >= ? AND create_time < ?", start, end).
+		Group("day").
+		Order("day ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count users by day: %w", err)
+	}
+	return rows, nil
+}
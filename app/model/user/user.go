@@ -22,7 +22,7 @@ type User struct {
 	CommentDisabledReason string     `gorm:"column:comment_disabled_reason;type:varchar(200);NOT NULL;default:''"`
 	CommentDisabledUntil  *time.Time `gorm:"column:comment_disabled_until"`
 	SessionVersion        int64      `gorm:"column:session_version;NOT NULL;default:1"`
-	CreateTime            time.Time  `gorm:"column:create_time;NOT NULL"`
+	CreateTime            time.Time  `gorm:"column:create_time;NOT NULL;index"`
 	UpdateTime            time.Time  `gorm:"column:update_time;NOT NULL"`
 }
 
@@ -37,6 +37,11 @@ func registerAdminAuthRoutes(group *gin.RouterGroup, handlers *routeHandlers) {
 	group.PUT("/article/update", handlers.article.AdminUpdateArticle)
 	group.DELETE("/article/delete", handlers.article.AdminDeleteArticle)
 
+	// 文章回收站
+	group.GET("/article/trash/list", handlers.article.AdminGetArticleTrashList)
+	group.POST("/article/trash/restore", handlers.article.AdminRestoreArticle)
+	group.DELETE("/article/trash/purge", handlers.article.AdminPurgeArticle)
+
 	// 文章草稿
 	group.GET("/article/draft/list", handlers.article.AdminGetArticleDraftList)
 	group.GET("/article/draft/detail", handlers.article.AdminGetArticleDraftDetail)
@@ -44,11 +49,48 @@ func registerAdminAuthRoutes(group *gin.RouterGroup, handlers *routeHandlers) {
 	group.POST("/article/draft/publish", handlers.article.AdminPublishArticleDraft)
 	group.DELETE("/article/draft/delete", handlers.article.AdminDeleteArticleDraft)
 
+	// 编辑租约：打开编辑器时获取，定时续期，关闭时释放；只用于提示「正在被其他会话编辑」
+	group.POST("/article/edit-lease/acquire", handlers.article.AdminAcquireArticleEditLease)
+	group.POST("/article/edit-lease/renew", handlers.article.AdminRenewArticleEditLease)
+	group.POST("/article/edit-lease/release", handlers.article.AdminReleaseArticleEditLease)
+
+	// 文章历史版本
+	group.GET("/article/revision/list", handlers.article.AdminGetArticleRevisionList)
+	group.GET("/article/revision/diff", handlers.article.AdminGetArticleRevisionDiff)
+	group.POST("/article/revision/restore", handlers.article.AdminRestoreArticleRevision)
+
+	// 浏览量统计
+	group.GET("/article/stats/views", handlers.article.AdminGetArticleViewStats)
+	group.GET("/stats/views", handlers.article.AdminGetSiteViewStats)
+
+	// 文章导入导出
+	group.GET("/article/export", handlers.article.AdminExportArticle)
+	group.POST("/article/import", handlers.article.AdminImportArticle)
+
 	// 文章图片
 	group.POST("/article/image/upload", handlers.article.AdminUploadArticleImage)
 	group.GET("/article/image/list", handlers.article.AdminGetArticleImageList)
 	group.GET("/article/image/detail", handlers.article.AdminGetArticleImageDetail)
 	group.DELETE("/article/image/delete", handlers.article.AdminDeleteArticleImage)
+	group.GET("/article/image/trash/list", handlers.article.AdminGetArticleImageTrashList)
+	group.POST("/article/image/trash/restore", handlers.article.AdminRestoreArticleImage)
+	group.DELETE("/article/image/trash/purge", handlers.article.AdminPurgeArticleImage)
+	// 未引用图片回收预览（dry-run），实际删除由每日定时任务执行
+	group.GET("/article/image/gc/report", handlers.article.AdminGetArticleImageGCReport)
+
+	// 文章系列
+	group.GET("/series/list", handlers.article.AdminGetSeriesList)
+	group.GET("/series/detail", handlers.article.AdminGetSeriesDetail)
+	group.POST("/series/add", handlers.article.AdminAddSeries)
+	group.PUT("/series/update", handlers.article.AdminUpdateSeries)
+	group.PUT("/series/articles", handlers.article.AdminUpdateSeriesArticles)
+	group.DELETE("/series/delete", handlers.article.AdminDeleteSeries)
+
+	// 置顶与精选文章：kind 为 pinned / featured，按 articleIDs 顺序整体替换
+	group.GET("/article/promotion", handlers.article.AdminGetArticlePromotion)
+	group.PUT("/article/promotion", handlers.article.AdminUpdateArticlePromotion)
+	// 文章可见性：public / unlisted / protected，protected 需设置访问密码
+	group.PUT("/article/visibility", handlers.article.AdminUpdateArticleVisibility)
 
 	// 标签管理
 	group.GET("/tag/list", handlers.tag.AdminGetTagList)
@@ -72,6 +114,9 @@ func registerAdminAuthRoutes(group *gin.RouterGroup, handlers *routeHandlers) {
 	group.GET("/comment/list", handlers.comment.AdminGetCommentList)
 	group.PUT("/comment/status", handlers.comment.AdminUpdateCommentStatus)
 	group.DELETE("/comment/delete", handlers.comment.AdminDeleteComment)
+	group.GET("/comment/trash/list", handlers.comment.AdminGetCommentTrashList)
+	group.POST("/comment/trash/restore", handlers.comment.AdminRestoreComment)
+	group.DELETE("/comment/trash/purge", handlers.comment.AdminPurgeComment)
 	group.POST("/comment/moderation-preview", handlers.comment.AdminPreviewCommentModeration)
 	group.GET("/comment/report-list", handlers.comment.AdminGetCommentReportList)
 	group.PUT("/comment/report", handlers.comment.AdminHandleCommentReport)
@@ -81,6 +126,9 @@ func registerAdminAuthRoutes(group *gin.RouterGroup, handlers *routeHandlers) {
 	group.PUT("/user/comment-permission", handlers.admin.AdminUpdateUserCommentPermission)
 	group.PUT("/user/force-logout", handlers.admin.AdminForceUserLogout)
 
+	// 数据看板：from / to 或 days 指定统计范围，结果缓存 5 分钟，refresh=true 时重新统计
+	group.GET("/dashboard/overview", handlers.dashboard.AdminGetDashboard)
+
 	// 站点资料
 	group.PUT("/about-me", handlers.admin.AdminUpdateAboutMe)
 }
@@ -8,6 +8,7 @@ import (
 	"meta-api/app/handler/admin"
 	"meta-api/app/handler/article"
 	"meta-api/app/handler/comment"
+	"meta-api/app/handler/dashboard"
 	"meta-api/app/handler/jsonshare"
 	"meta-api/app/handler/link"
 	"meta-api/app/handler/sitedynamic"
@@ -20,6 +21,7 @@ type routeHandlers struct {
 	admin       admin.Handler
 	article     article.Handler
 	comment     comment.Handler
+	dashboard   dashboard.Handler
 	jsonShare   jsonshare.Handler
 	link        link.Handler
 	siteDynamic sitedynamic.Handler
@@ -35,6 +37,7 @@ func resolveHandlers(container *dig.Container) (*routeHandlers, error) {
 		adminHandler admin.Handler,
 		articleHandler article.Handler,
 		commentHandler comment.Handler,
+		dashboardHandler dashboard.Handler,
 		jsonShareHandler jsonshare.Handler,
 		linkHandler link.Handler,
 		siteDynamicHandler sitedynamic.Handler,
@@ -45,6 +48,7 @@ func resolveHandlers(container *dig.Container) (*routeHandlers, error) {
 		handlers.admin = adminHandler
 		handlers.article = articleHandler
 		handlers.comment = commentHandler
+		handlers.dashboard = dashboardHandler
 		handlers.jsonShare = jsonShareHandler
 		handlers.link = linkHandler
 		handlers.siteDynamic = siteDynamicHandler
@@ -1,10 +1,13 @@
 package router
 
 import (
+	"fmt"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/dig"
 
 	"meta-api/bootstrap"
+	"meta-api/pkg/storage"
 )
 
 // SetUpRouter 启动路由
@@ -22,6 +25,11 @@ func SetUpRouter(bs *bootstrap.Bootstrap, container *dig.Container) (*gin.Engine
 		return nil, err
 	}
 
+	// 本地磁盘存储的图片由本服务提供静态访问
+	if err = registerImageStorageRoutes(r, container); err != nil {
+		return nil, err
+	}
+
 	// 注册管理员路由
 	registerAdminRoutes(r.Group("/admin"), handlers)
 	// 注册用户路由
@@ -29,3 +37,19 @@ func SetUpRouter(bs *bootstrap.Bootstrap, container *dig.Container) (*gin.Engine
 
 	return r, nil
 }
+
+// registerImageStorageRoutes 存储后端为本地磁盘时挂载图片静态目录，其他后端不注册
+func registerImageStorageRoutes(r *gin.Engine, container *dig.Container) error {
+	err := container.Invoke(func(store storage.ObjectStore) {
+		local, ok := store.(*storage.Local)
+		if !ok || local.Root() == "" {
+			return
+		}
+		// 不列出目录内容
+		r.StaticFS(local.RoutePrefix(), gin.Dir(local.Root(), false))
+	})
+	if err != nil {
+		return fmt.Errorf("register image storage routes: %w", err)
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ func RouterEngine(bs *bootstrap.Bootstrap) (*gin.Engine, error) {
 		middlewares.TimeoutMiddleware(3*time.Second, timeoutOverrides()...),
 		middlewares.GinLogger(bs.Logger),
 		middlewares.GinRecovery(bs.Logger, true),
+		middlewares.ResponseCacheMiddleware(responseCacheRules()...),
 	)
 
 	return r, nil
@@ -38,3 +39,15 @@ func timeoutOverrides() []middlewares.TimeoutOverride {
 		{Prefix: "/user/bug-feedback", Timeout: 10 * time.Second},
 	}
 }
+
+// responseCacheRules 前台读接口生成 ETag 并压缩较大的响应；后台接口不做处理。
+// 前缀按顺序匹配第一条，更具体的前缀放在前面
+func responseCacheRules() []middlewares.ResponseCacheRule {
+	return []middlewares.ResponseCacheRule{
+		// 登录跳转与当前用户信息只压缩，不参与条件请求
+		{Prefix: "/user/auth/", CompressMinSize: 1024},
+		// 订阅源由处理函数自行生成 ETag 与 Cache-Control
+		{Prefix: "/user/feed/", ETag: true, CompressMinSize: 1024},
+		{Prefix: "/user/", ETag: true, CacheControl: "no-cache", CompressMinSize: 1024},
+	}
+}
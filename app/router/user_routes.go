@@ -12,10 +12,24 @@ func registerUserRoutes(group *gin.RouterGroup, handlers *routeHandlers) {
 	group.GET("/article/list", handlers.article.UserGetArticleList)
 	group.GET("/article/search", handlers.article.UserSearchArticle)
 	group.GET("/article/hot", handlers.article.UserGetHotArticle)
+	group.GET("/article/trending", handlers.article.UserGetTrendingArticle)
+	group.GET("/article/featured", handlers.article.UserGetFeaturedArticle)
 	group.GET("/article/detail", handlers.article.UserGetArticleDetail)
+	group.POST("/article/unlock", handlers.article.UserUnlockArticle)
+	group.GET("/article/related", handlers.article.UserGetRelatedArticle)
 	group.GET("/article/timeline", handlers.article.UserGetTimeline)
+	// 按月归档：先取年月目录与计数，再按月分页加载文章
+	group.GET("/article/archive", handlers.article.UserGetArticleArchive)
+	group.GET("/article/archive/month", handlers.article.UserGetArticleArchiveMonth)
 	group.POST("/article/view-log/:id", handlers.viewLog.PostViewLog)
 
+	// 订阅源：/feed/rss、/feed/atom、/feed/json，?tag= 输出单个标签的订阅
+	group.GET("/feed/:format", handlers.article.UserGetArticleFeed)
+
+	// 文章系列
+	group.GET("/series/list", handlers.article.UserGetSeriesList)
+	group.GET("/series/detail", handlers.article.UserGetSeriesDetail)
+
 	// 标签与友链
 	group.GET("/tag/list", handlers.tag.UserGetTagList)
 	group.GET("/tag/article-list", handlers.tag.UserGetArticleListByTag)
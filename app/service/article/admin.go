@@ -2,6 +2,8 @@ package article
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"mime"
@@ -14,14 +16,15 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 
 	"meta-api/app/model/article"
-	"meta-api/app/model/tag"
 	"meta-api/common/cachekey"
 	"meta-api/common/constants"
 	"meta-api/common/idutil"
 	"meta-api/common/types"
-	"meta-api/pkg/cos"
+	"meta-api/pkg/imageproc"
+	"meta-api/pkg/storage"
 )
 
 var dangerousSVGPattern = regexp.MustCompile(`(?is)<\s*(script|iframe|object|embed|link|meta)\b|\bon[a-z]+\s*=|javascript:`)
@@ -73,7 +76,7 @@ func (a *articleService) AdminGetArticleList(ctx context.Context,
 				return response, err
 			}
 			articleItem.Title = result[0].(string)
-			articleItem.Tag = result[1].(string)
+			articleItem.Tags = article.SplitTagNames(result[1].(string))
 			viewNumStr := result[2].(string)
 			articleItem.ViewNum, _ = strconv.Atoi(viewNumStr)
 			articleItem.CreateTime = result[3].(string)[:16]
@@ -91,22 +94,12 @@ func (a *articleService) AdminGetArticleList(ctx context.Context,
 				return response, err
 			}
 			articleItem.Title = articleModel.Title
-			articleItem.Tag = articleModel.TagName
+			articleItem.Tags = nonNilTagNames(articleModel.TagNames)
 			articleItem.ViewNum = int(articleModel.ViewNum)
 			articleItem.CreateTime = articleModel.CreateTime.Format(constants.TimeLayoutToMinute)
 			articleItem.UpdateTime = articleModel.UpdateTime.Format(constants.TimeLayoutToMinute)
 
-			mapData := map[string]any{
-				"id":         articleModel.ID,
-				"title":      articleModel.Title,
-				"describe":   articleModel.Describe,
-				"content":    articleModel.Content,
-				"viewNum":    articleModel.ViewNum,
-				"createTime": articleModel.CreateTime.Format(constants.TimeLayoutToSecond),
-				"updateTime": articleModel.UpdateTime.Format(constants.TimeLayoutToSecond),
-				"tagID":      articleTagIDValue(articleModel.TagID),
-				"tagName":    articleModel.TagName,
-			}
+			mapData := articleModel.HashData()
 			a.redis.HMSet(ctx, cachekey.ArticleHash(articleItem.ID).String(), mapData)
 		}
 		articleList = append(articleList, articleItem)
@@ -126,7 +119,7 @@ func (a *articleService) AdminGetArticleDetail(ctx context.Context,
 	hashKey := cachekey.ArticleHash(request.ID).String()
 	if exist := a.redis.Exists(ctx, hashKey); exist.Val() == 1 {
 		// redis 当中存在该数据
-		fields := []string{"id", "title", "tagName", "describe", "content"}
+		fields := []string{"id", "title", "tagName", "describe", "content", "slug", "visibility"}
 		result, err := a.redis.HMGet(ctx, hashKey, fields...).Result()
 		if err != nil {
 			a.logger.Error("HMGET error", zap.Error(err))
@@ -134,9 +127,25 @@ func (a *articleService) AdminGetArticleDetail(ctx context.Context,
 		}
 		response.ID = result[0].(string)
 		response.Title = result[1].(string)
-		response.Tag = result[2].(string)
+		response.Tags = article.SplitTagNames(result[2].(string))
 		response.Describe = result[3].(string)
 		response.Content = result[4].(string)
+		// slug 字段上线前写入的 Hash 中没有该字段
+		response.Slug, _ = result[5].(string)
+		if visibility, ok := result[6].(string); ok {
+			response.Visibility = visibility
+		} else {
+			response.Visibility = article.ArticleVisibilityPublic
+		}
+		// 版本号随每次保存变化，不进缓存，始终以库中为准
+		id, err := idutil.ParseID("articleID", request.ID)
+		if err != nil {
+			return response, err
+		}
+		if response.Version, err = a.articleModel.GetArticleVersion(ctx, id); err != nil {
+			a.logger.Error("get article version error", zap.Error(err))
+			return response, err
+		}
 	} else {
 		// redis当中不存在该数据，从数据库当中获取数据
 		id, err := idutil.ParseID("articleID", request.ID)
@@ -151,26 +160,19 @@ func (a *articleService) AdminGetArticleDetail(ctx context.Context,
 		}
 
 		// 缓存文章信息
-		mapData := map[string]any{
-			"id":         articleInfo.ID,
-			"title":      articleInfo.Title,
-			"describe":   articleInfo.Describe,
-			"content":    articleInfo.Content,
-			"viewNum":    articleInfo.ViewNum,
-			"createTime": articleInfo.CreateTime.Format(constants.TimeLayoutToSecond),
-			"updateTime": articleInfo.UpdateTime.Format(constants.TimeLayoutToSecond),
-			"tagID":      articleTagIDValue(articleInfo.TagID),
-			"tagName":    articleInfo.TagName,
-		}
+		mapData := articleInfo.HashData()
 		if err = a.redis.HMSet(ctx, cachekey.ArticleHash(request.ID).String(), mapData).Err(); err != nil {
 			return response, err
 		}
 
 		response.ID = strconv.FormatUint(articleInfo.ID, 10)
 		response.Title = articleInfo.Title
-		response.Tag = articleInfo.TagName
+		response.Tags = nonNilTagNames(articleInfo.TagNames)
 		response.Describe = articleInfo.Describe
 		response.Content = articleInfo.Content
+		response.Slug = articleInfo.Slug
+		response.Visibility = articleInfo.Visibility
+		response.Version = articleInfo.Version
 	}
 
 	return response, nil
@@ -180,28 +182,23 @@ func (a *articleService) AdminGetArticleDetail(ctx context.Context,
 func (a *articleService) AdminAddArticle(ctx context.Context,
 	request *types.AdminAddArticleRequest) (*types.AdminSaveArticleResponse, error) {
 
-	// 获取 tag
-	tagInfo, err := a.tagModel.FindTagByName(ctx, request.Tag)
+	slug, err := normalizeArticleSlug(request.Slug)
 	if err != nil {
-		a.logger.Error("failed to find tag", zap.Error(err))
-		return nil, fmt.Errorf("failed to find tag, error: %w", err)
+		return nil, err
 	}
-	if tagInfo == nil || tagInfo.ID == 0 {
-		tagID, err := a.idGenerator.NextID()
-		if err != nil {
-			a.logger.Error("generate tag id error", zap.Error(err))
-			return nil, fmt.Errorf("generate tag id error: %w", err)
-		}
-		tagInfo = &tag.Tag{
-			ID:   tagID,
-			Name: request.Tag,
-		}
-		if err = a.tagModel.CreateTag(ctx, tagInfo); err != nil {
-			a.logger.Error("failed to create tag", zap.Error(err))
-			return nil, fmt.Errorf("failed to create tag: %w", err)
+	if slug != "" {
+		if err = a.articleModel.CheckArticleSlugAvailable(ctx, 0, slug); err != nil {
+			a.logger.Error("failed to check article slug", zap.Error(err))
+			return nil, err
 		}
 	}
 
+	// 获取 tag，不存在的标签自动创建
+	tagIDs, tagNames, err := a.ensureTags(ctx, request.Tags)
+	if err != nil {
+		return nil, err
+	}
+
 	// 创建文章
 	articleID, err := a.idGenerator.NextID()
 	if err != nil {
@@ -224,8 +221,12 @@ func (a *articleService) AdminAddArticle(ctx context.Context,
 		PublishedTime: &now,
 		CreateTime:    now,
 		UpdateTime:    now,
-		TagID:         &tagInfo.ID,
+		TagIDs:        tagIDs,
 	}
+	if slug != "" {
+		articleInfo.Slug = &slug
+	}
+	applyArticleContent(articleInfo)
 	if err = a.articleModel.CreateArticle(ctx, articleInfo); err != nil {
 		a.logger.Error("failed to create article", zap.Error(err))
 		return nil, fmt.Errorf("failed to create article, error: %w", err)
@@ -251,39 +252,34 @@ func (a *articleService) AdminAddArticle(ctx context.Context,
 		return nil, err
 	}
 
-	// 有序集合：按标签对应的文章数量排序
-	tagArticleNumKey := cachekey.TagArticleNumZSet().String()
-	tagName := tagInfo.Name
-	err = a.redis.ZScore(ctx, tagArticleNumKey, tagName).Err()
-	switch {
-	case errors.Is(err, redis.Nil):
-		if err = a.redis.ZAdd(ctx, tagArticleNumKey, redis.Z{Score: 1, Member: tagName}).Err(); err != nil {
-			a.logger.Error("failed to add tagIDArticleKey", zap.Error(err))
-			return nil, err
+	// 有序集合：按标签对应的文章数量排序；标签下的文章按创建时间排序
+	for _, tagName := range tagNames {
+		if isPublicArticle(articleInfo) {
+			if err = a.increaseTagArticleNum(ctx, tagName); err != nil {
+				return nil, err
+			}
 		}
-	case err != nil:
-		a.logger.Error("failed to query tagIDArticleKey", zap.Error(err))
-		return nil, err
-	default:
-		if err = a.redis.ZIncrBy(ctx, tagArticleNumKey, 1, tagName).Err(); err != nil {
+
+		if err = a.redis.ZAdd(ctx, cachekey.TagArticleListZSet(tagName).String(), timeMember...).Err(); err != nil {
 			a.logger.Error("failed to add tagIDArticleKey", zap.Error(err))
 			return nil, err
 		}
 	}
-
-	// 有序集合：按标签下的文章的创建时间排序
-	timeMember = []redis.Z{
-		{Score: cachekey.ArticleTimeScore(articleInfo.CreateTime), Member: articleInfo.ID},
-	}
-	if err = a.redis.ZAdd(ctx, cachekey.TagArticleListZSet(tagName).String(), timeMember...).Err(); err != nil {
-		a.logger.Error("failed to add tagIDArticleKey", zap.Error(err))
+	if err = a.redis.Del(ctx, cachekey.TagListCache().String()).Err(); err != nil {
+		a.logger.Error("failed to delete tag list cache", zap.Error(err))
 		return nil, err
 	}
 
+	a.indexPublishedArticle(ctx, articleInfo, articleInfo.CreateTime)
+	a.updateRelatedArticles(ctx, articleInfo, tagNames)
+	a.recordArticleRevision(ctx, articleInfo.ID, articleInfo.Title, articleInfo.Describe, articleInfo.Content,
+		article.JoinTagNames(tagNames), article.RevisionSourceCreate)
 	articleIDString := strconv.FormatUint(articleID, 10)
 
 	// 刷新 sitemap 内部缓存，让新增文章 URL 尽快出现在 sitemap.xml。
-	a.sitemap.RefreshArticles(articleIDString)
+	a.sitemap.RefreshArticles(a.articleURLKeys(ctx, articleID)...)
+	a.invalidateArticleFeeds(ctx)
+	a.invalidateArticleArchive(ctx)
 
 	return &types.AdminSaveArticleResponse{ID: articleIDString}, nil
 }
@@ -298,6 +294,19 @@ func (a *articleService) AdminUpdateArticle(ctx context.Context,
 		a.logger.Error("invalid article id", zap.Error(err))
 		return nil, err
 	}
+	unpublishAt, err := parseArticleScheduleTime(request.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateArticleSchedule(nil, unpublishAt); err != nil {
+		return nil, err
+	}
+	var slug string
+	if request.Slug != nil {
+		if slug, err = normalizeArticleSlug(*request.Slug); err != nil {
+			return nil, err
+		}
+	}
 
 	// 在更新之前先查出旧文章信息，主要是为了拿到旧 tagName
 	oldArticle, err := a.articleModel.GetArticleDetailByID(ctx, id)
@@ -305,30 +314,17 @@ func (a *articleService) AdminUpdateArticle(ctx context.Context,
 		a.logger.Error("failed to get old article info", zap.Error(err))
 		return nil, fmt.Errorf("failed to get old article info: %w", err)
 	}
-	oldTagName := oldArticle.TagName
+	oldTagNames := oldArticle.TagNames
+	// 提前校验版本以尽早返回冲突，写入时再由 UpdateArticle 在事务内原子校验一次
+	if request.Version != oldArticle.Version {
+		return nil, newArticleVersionConflictError(oldArticle)
+	}
 
 	// 处理 Tag
-	tagInfo, err := a.tagModel.FindTagByName(ctx, request.Tag)
+	tagIDs, newTagNames, err := a.ensureTags(ctx, request.Tags)
 	if err != nil {
-		a.logger.Error("failed to find tag", zap.Error(err))
-		return nil, fmt.Errorf("failed to find tag, error: %w", err)
-	}
-	if tagInfo == nil || tagInfo.ID == 0 {
-		tagID, err := a.idGenerator.NextID()
-		if err != nil {
-			a.logger.Error("generate tag id error", zap.Error(err))
-			return nil, fmt.Errorf("generate tag id error: %w", err)
-		}
-		tagInfo = &tag.Tag{
-			ID:   tagID,
-			Name: request.Tag,
-		}
-		if err = a.tagModel.CreateTag(ctx, tagInfo); err != nil {
-			a.logger.Error("failed to create tag", zap.Error(err))
-			return nil, fmt.Errorf("failed to create tag: %w", err)
-		}
+		return nil, err
 	}
-	newTagName := tagInfo.Name
 
 	// 需要获取当前文章的浏览量，避免浏览量丢失
 	viewNum, err := a.redis.ZScore(ctx, cachekey.ArticleViewZSet().String(), request.ID).Result()
@@ -342,6 +338,8 @@ func (a *articleService) AdminUpdateArticle(ctx context.Context,
 		return nil, fmt.Errorf("failed to load location: %w", err)
 	}
 
+	a.ensureBaselineRevision(ctx, oldArticle)
+
 	// 更新文章
 	articleInfo := &article.Article{
 		ID:         id,
@@ -350,55 +348,81 @@ func (a *articleService) AdminUpdateArticle(ctx context.Context,
 		Content:    request.Content,
 		ViewNum:    uint64(viewNum),
 		UpdateTime: time.Now().In(loc),
-		TagID:      &tagInfo.ID,
+		TagIDs:     tagIDs,
+		Version:    request.Version,
+	}
+	// slug 与正文在同一事务内写入：版本冲突或 slug 冲突时整体回滚，避免文章被部分更新
+	var newSlug *string
+	if request.Slug != nil && slug != oldArticle.Slug {
+		newSlug = &slug
 	}
-	if err = a.articleModel.UpdateArticle(ctx, articleInfo); err != nil {
+	applyArticleContent(articleInfo)
+	previousSlug, err := a.articleModel.UpdateArticle(ctx, articleInfo, newSlug)
+	if err != nil {
+		if errors.Is(err, article.ErrArticleVersionConflict) {
+			return nil, a.articleVersionConflict(ctx, id, false)
+		}
+		if errors.Is(err, article.ErrArticleSlugConflict) {
+			return nil, err
+		}
 		a.logger.Error("failed to update article", zap.Error(err))
 		return nil, fmt.Errorf("failed to update article: %w", err)
 	}
+	if newSlug != nil {
+		a.removeArticleSlugCache(ctx, previousSlug, slug)
+	}
+	if err = a.articleModel.UpdateArticleUnpublishAt(ctx, id, unpublishAt); err != nil {
+		a.logger.Error("failed to update article unpublish time", zap.Error(err))
+		return nil, err
+	}
 	if err = a.syncPublishedArticleImageReferences(ctx, articleInfo.ID, articleInfo.Content); err != nil {
 		a.logger.Error("failed to sync article image references", zap.Error(err))
 		return nil, fmt.Errorf("failed to sync article image references: %w", err)
 	}
 
 	// 处理缓存数据
-	if err = a.redis.Del(ctx, cachekey.ArticleHash(request.ID).String()).Err(); err != nil {
+	if err = a.redis.Del(ctx, articleCacheKeys(request.ID)...).Err(); err != nil {
 		a.logger.Error("failed to delete hash", zap.Error(err))
 		return nil, fmt.Errorf("failed to delete hash: %w", err)
 	}
-	if err = a.redis.Del(ctx, cachekey.TagArticleNumZSet().String()).Err(); err != nil {
+	if err = a.redis.Del(ctx, cachekey.TagArticleNumZSet().String(), cachekey.TagListCache().String()).Err(); err != nil {
 		a.logger.Error("failed to delete tag:articleNum:ZSet", zap.Error(err))
 		return nil, fmt.Errorf("failed to delete tag:articleNum:ZSet: %w", err)
 	}
 
-	// 清理「标签下的文章列表」ZSet 缓存：
-	if err = a.redis.Del(ctx, cachekey.TagArticleListZSet(oldTagName).String()).Err(); err != nil {
-		a.logger.Error("failed to delete oldTagName:article:ZSet",
-			zap.String("oldTagName", oldTagName), zap.Error(err))
-		return nil, fmt.Errorf("failed to delete oldTagName:article:ZSet: %w", err)
-	}
-	if newTagName != oldTagName {
-		if err = a.redis.Del(ctx, cachekey.TagArticleListZSet(newTagName).String()).Err(); err != nil {
-			a.logger.Error("failed to delete newTagName:article:ZSet",
-				zap.String("newTagName", newTagName), zap.Error(err))
-			return nil, fmt.Errorf("failed to delete newTagName:article:ZSet: %w", err)
+	// 清理「标签下的文章列表」ZSet 缓存：新旧标签都要清理
+	for _, tagName := range unionTagNames(oldTagNames, newTagNames) {
+		if err = a.redis.Del(ctx, cachekey.TagArticleListZSet(tagName).String()).Err(); err != nil {
+			a.logger.Error("failed to delete tagName:article:ZSet",
+				zap.String("tagName", tagName), zap.Error(err))
+			return nil, fmt.Errorf("failed to delete tagName:article:ZSet: %w", err)
 		}
 	}
 
-	// 刷新 sitemap 内部缓存，让文章 lastmod 或标签变更尽快反映到 sitemap.xml。
-	a.sitemap.RefreshArticles(request.ID)
+	a.indexPublishedArticle(ctx, articleInfo, oldArticle.CreateTime)
+	a.updateRelatedArticles(ctx, articleInfo, newTagNames)
+	a.recordArticleRevision(ctx, articleInfo.ID, articleInfo.Title, articleInfo.Describe, articleInfo.Content,
+		article.JoinTagNames(newTagNames), article.RevisionSourceUpdate)
 
-	// 清理 CDN 上 /article-detail/<id> 的文章详情 HTML 缓存。
+	// 刷新 sitemap 内部缓存，让文章 lastmod、标签或 slug 变更尽快反映到 sitemap.xml。
+	urlKeys := a.articleURLKeys(ctx, id)
+	a.sitemap.RefreshArticles(urlKeys...)
+	a.invalidateArticleFeeds(ctx)
+	a.invalidateArticleArchive(ctx)
+	a.refreshArticleSeries(ctx, id)
+	a.invalidateArticlePromotions(ctx)
+
+	// 清理 CDN 上 /article-detail/<id 或 slug> 的文章详情 HTML 缓存。
 	// 文章标题、正文、摘要或标签变化后，旧 HTML 命中边缘节点会继续展示旧内容。
-	if err = a.cdn.PurgeArticles(request.ID); err != nil {
+	if err = a.cdn.PurgeArticles(urlKeys...); err != nil {
 		a.logger.Error("failed to purge article CDN cache", zap.String("article_id", request.ID), zap.Error(err))
 		return nil, fmt.Errorf("failed to purge article CDN cache: %w", err)
 	}
 
-	return &types.AdminSaveArticleResponse{ID: request.ID}, nil
+	return &types.AdminSaveArticleResponse{ID: request.ID, Version: articleInfo.Version}, nil
 }
 
-// AdminDeleteArticle 删除文章
+// AdminDeleteArticle 把文章移入回收站
 func (a *articleService) AdminDeleteArticle(ctx context.Context, request *types.AdminDeleteArticleRequest) error {
 	articleID := request.ID
 	id, err := idutil.ParseID("articleID", request.ID)
@@ -406,15 +430,21 @@ func (a *articleService) AdminDeleteArticle(ctx context.Context, request *types.
 		a.logger.Error("invalid article id", zap.Error(err))
 		return err
 	}
-	tagName, err := a.articleModel.GetArticleDeleteInfo(ctx, id)
+	articleInfo, err := a.articleModel.GetArticleDetailByID(ctx, id)
 	if err != nil {
 		a.logger.Error("failed to get article delete info", zap.Error(err))
 		return fmt.Errorf("failed to get article delete info: %w", err)
 	}
+	seriesID, err := a.articleModel.FindSeriesIDByArticleID(ctx, id)
+	if err != nil {
+		a.logger.Error("failed to find article series", zap.Error(err))
+		return fmt.Errorf("failed to find article series: %w", err)
+	}
 
-	// 删除文章前先清理 CDN 上 /article-detail/<id> 的文章详情 HTML 缓存。
+	// 删除文章前先清理 CDN 上 /article-detail/<id 或 slug> 的文章详情 HTML 缓存。
 	// 若 CDN 清理失败，保留数据库记录，避免后台提示失败但文章已被删除。
-	if err = a.cdn.PurgeArticles(articleID); err != nil {
+	urlKeys := a.articleURLKeys(ctx, id)
+	if err = a.cdn.PurgeArticles(urlKeys...); err != nil {
 		a.logger.Error("failed to purge article CDN cache", zap.String("article_id", articleID), zap.Error(err))
 		return fmt.Errorf("failed to purge article CDN cache: %w", err)
 	}
@@ -424,44 +454,39 @@ func (a *articleService) AdminDeleteArticle(ctx context.Context, request *types.
 		return fmt.Errorf("failed to clear article image references: %w", err)
 	}
 
-	if err = a.articleModel.DeleteArticleByID(ctx, id); err != nil {
-		a.logger.Error("failed to delete article", zap.Error(err))
-		return fmt.Errorf("failed to delete article: %w", err)
-	}
-
-	// 删除文章的 hash
-	if err = a.redis.Del(ctx, cachekey.ArticleHash(articleID).String()).Err(); err != nil {
-		a.logger.Error("failed to delete hash", zap.Error(err))
+	// 移入回收站前落库 Redis 中的阅读量，恢复时从数据库重建
+	viewNum, err := a.currentArticleViewNum(ctx, articleID, articleInfo.ViewNum)
+	if err != nil {
 		return err
 	}
-
-	// 删除article:time:ZSet里面的成员
-	if err = a.redis.ZRem(ctx, cachekey.ArticleTimeZSet().String(), articleID).Err(); err != nil {
-		a.logger.Error("failed to delete article:time:ZSet", zap.Error(err))
-		return err
+	if err = a.articleModel.UpdateArticleViewNum(ctx, articleID, viewNum); err != nil {
+		a.logger.Error("failed to persist article view count", zap.Error(err))
+		return fmt.Errorf("failed to persist article view count: %w", err)
 	}
 
-	// 删除article:view:ZSet里面的成员
-	if err = a.redis.ZRem(ctx, cachekey.ArticleViewZSet().String(), articleID).Err(); err != nil {
-		a.logger.Error("failed to delete article:view:ZSet", zap.Error(err))
-		return err
+	if err = a.articleModel.TrashArticleByID(ctx, id, articleNow()); err != nil {
+		a.logger.Error("failed to trash article", zap.Error(err))
+		return fmt.Errorf("failed to trash article: %w", err)
 	}
+	a.searchIndex.Remove(id)
+	a.removeRelatedArticles(ctx, id)
 
-	// 删除tag:articleNum:ZSet整个有序集合
-	if err = a.redis.Del(ctx, cachekey.TagArticleNumZSet().String()).Err(); err != nil {
-		a.logger.Error("failed to delete tag:articleNum:ZSet", zap.Error(err))
+	if err = a.removePublishedArticleCache(ctx, articleID, articleInfo.TagNames); err != nil {
 		return err
 	}
 
-	// 删除tagID:article:ZSet整个有序集合
-	tagNameArticleKey := cachekey.TagArticleListZSet(tagName).String()
-	if err = a.redis.Del(ctx, tagNameArticleKey).Err(); err != nil {
-		a.logger.Error("failed to delete tagIDArticleKey", zap.Error(err))
-		return err
-	}
+	a.removeArticleSlugCache(ctx, articleInfo.Slug)
 
 	// 刷新 sitemap 内部缓存，让被删文章 URL 尽快从 sitemap.xml 移除。
-	a.sitemap.RefreshArticles(articleID)
+	a.sitemap.RefreshArticles(urlKeys...)
+	a.invalidateArticleFeeds(ctx)
+	a.invalidateArticleArchive(ctx)
+	// 系列成员关系保留到彻底删除，这里只让系列缓存跳过该文章
+	if seriesID != 0 {
+		if err = a.invalidateSeriesCache(ctx, seriesID, []uint64{id}); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -484,6 +509,13 @@ func (a *articleService) AdminUploadArticleImage(ctx context.Context, fileName s
 		return nil, fmt.Errorf("unsafe svg content")
 	}
 
+	content, width, height, err := normalizeArticleImage(imageType, content)
+	if err != nil {
+		return nil, err
+	}
+	contentHash := sha256.Sum256(content)
+	hashHex := hex.EncodeToString(contentHash[:])
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -491,11 +523,19 @@ func (a *articleService) AdminUploadArticleImage(ctx context.Context, fileName s
 	}
 
 	now := time.Now()
+	existing, err := a.articleModel.FindArticleImageByContentHash(ctx, hashHex)
+	if err == nil {
+		return a.reuseArticleImage(ctx, existing, now)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
 	storedName := fmt.Sprintf("%s%03d%s", now.Format("20060102150405"), now.Nanosecond()/int(time.Millisecond), imageType.ext)
 	objectName := storedName
 	publicURL, err := a.imageStore.Upload(ctx, objectName, content, imageType.mime)
 	if err != nil {
-		if errors.Is(err, cos.ErrDisabled) {
+		if errors.Is(err, storage.ErrDisabled) {
 			return nil, fmt.Errorf("article image storage is not configured: %w", err)
 		}
 		return nil, err
@@ -504,28 +544,91 @@ func (a *articleService) AdminUploadArticleImage(ctx context.Context, fileName s
 	if err != nil {
 		return nil, fmt.Errorf("generate article image id: %w", err)
 	}
-	if err = a.articleModel.CreateArticleImage(ctx, &article.ArticleImage{
-		ID:         imageID,
-		ObjectKey:  a.imageStore.ObjectKey(objectName),
-		URL:        publicURL,
-		ImageName:  storedName,
-		Mime:       imageType.mime,
-		Size:       int64(len(content)),
-		Status:     article.ImageStatusUnused,
-		CreateTime: now,
-		UpdateTime: now,
-	}); err != nil {
-		return nil, err
+	image := &article.ArticleImage{
+		ID:          imageID,
+		ObjectKey:   a.imageStore.ObjectKey(objectName),
+		URL:         publicURL,
+		ImageName:   storedName,
+		Mime:        imageType.mime,
+		Size:        int64(len(content)),
+		Width:       width,
+		Height:      height,
+		ContentHash: &hashHex,
+		Status:      article.ImageStatusUnused,
+		CreateTime:  now,
+		UpdateTime:  now,
+	}
+	variants := a.uploadArticleImageVariants(ctx, imageID, storedName, imageType, content, now)
+	if err = a.articleModel.CreateArticleImage(ctx, image); err != nil {
+		a.deleteArticleImageVariantObjects(ctx, variants)
+		if !errors.Is(err, article.ErrArticleImageContentExists) {
+			return nil, err
+		}
+		// 相同内容被并发上传抢先写入：删除本次上传的对象，复用已有记录
+		if err = a.imageStore.Delete(ctx, image.ObjectKey); err != nil {
+			a.logger.Warn("failed to delete duplicated article image",
+				zap.String("object_key", image.ObjectKey), zap.Error(err))
+		}
+		existing, err := a.articleModel.FindArticleImageByContentHash(ctx, hashHex)
+		if err != nil {
+			return nil, err
+		}
+		return a.reuseArticleImage(ctx, existing, now)
 	}
+	// 变体记录引用原图，原图记录写入后再保存
+	variants = a.saveArticleImageVariants(ctx, variants)
 
+	sources, srcSet := articleImageSources(image, variants)
 	return &types.AdminUploadArticleImageResponse{
 		URL:       publicURL,
 		ImageName: storedName,
-		Size:      int64(len(content)),
+		Size:      image.Size,
 		Mime:      imageType.mime,
+		Sources:   sources,
+		SrcSet:    srcSet,
 	}, nil
 }
 
+// reuseArticleImage 内容相同的图片已上传过时直接返回原记录，不再重复存储。
+// 回收站中的图片随之恢复；刷新 update_time，避免尚未被文章引用就被未引用图片回收删除
+func (a *articleService) reuseArticleImage(ctx context.Context, image *article.ArticleImage,
+	now time.Time) (*types.AdminUploadArticleImageResponse, error) {
+	image.DeletedAt = gorm.DeletedAt{}
+	image.UpdateTime = now
+	if err := a.articleModel.ReuseArticleImage(ctx, image.ID, now); err != nil {
+		return nil, err
+	}
+	variants, err := a.articleModel.ListArticleImageVariants(ctx, image.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, srcSet := articleImageSources(image, variants)
+	return &types.AdminUploadArticleImageResponse{
+		URL:       image.URL,
+		ImageName: image.ImageName,
+		Size:      image.Size,
+		Mime:      image.Mime,
+		Sources:   sources,
+		SrcSet:    srcSet,
+		Duplicate: true,
+	}, nil
+}
+
+// normalizeArticleImage 去除 EXIF、XMP 等元数据并读取宽高，去重哈希基于去除后的内容计算，
+// 同一张图片只是拍摄信息或编辑软件写入的注释不同时也能命中
+func normalizeArticleImage(imageType articleImageType, content []byte) ([]byte, int, int, error) {
+	normalized, err := imageproc.StripMetadata(imageType.mime, content)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid %s content: %w", imageType.mime, err)
+	}
+	width, height, err := imageproc.Dimensions(imageType.mime, normalized)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("invalid %s content: %w", imageType.mime, err)
+	}
+	return normalized, width, height, nil
+}
+
 func detectArticleImageType(fileName string, contentType string, content []byte) (articleImageType, error) {
 	normalizedContentType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
 	if normalizedContentType == "image/jpg" {
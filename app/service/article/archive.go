@@ -0,0 +1,300 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/app/service/article/archive"
+	"meta-api/common/constants"
+	"meta-api/common/types"
+)
+
+var (
+	errArticleExportTagNotFound = errors.New("export tag not found")
+	errArticleArchiveInvalid    = errors.New("invalid article archive")
+)
+
+// 导入时与手动编辑保持一致的字段限制
+const (
+	importTitleMaxRunes    = 100
+	importDescribeMaxRunes = 200
+	importTagMaxRunes      = 20
+	importMaxTags          = 5
+)
+
+// AdminExportArticles 把已发布文章导出为 zip：每篇一个带 front matter 的 Markdown 文件，
+// 文章引用的 COS 图片下载到 assets 目录并改写为相对路径；下载失败的图片保留原地址
+func (a *articleService) AdminExportArticles(ctx context.Context,
+	request *types.AdminExportArticleRequest) (*types.AdminExportArticleResponse, error) {
+	tagName := strings.TrimSpace(request.Tag)
+	if tagName != "" {
+		tagInfo, err := a.tagModel.FindTagByName(ctx, tagName)
+		if err != nil {
+			a.logger.Error("failed to find tag", zap.Error(err))
+			return nil, fmt.Errorf("failed to find tag: %w", err)
+		}
+		if tagInfo == nil || tagInfo.ID == 0 {
+			return nil, errArticleExportTagNotFound
+		}
+	}
+
+	records, err := a.articleModel.ListExportArticles(ctx, tagName)
+	if err != nil {
+		a.logger.Error("failed to list export articles", zap.Error(err))
+		return nil, err
+	}
+	ids := make([]uint64, 0, len(records))
+	objectKeys := make([]string, 0)
+	for _, record := range records {
+		ids = append(ids, record.ID)
+		for _, rawURL := range archive.ImageURLs(record.Content) {
+			if objectKey, ok := a.imageStore.ObjectKeyFromPublicURL(rawURL); ok {
+				objectKeys = append(objectKeys, objectKey)
+			}
+		}
+	}
+	tagNames, err := a.articleModel.ListArticleTagNames(ctx, ids)
+	if err != nil {
+		a.logger.Error("failed to list article tag names", zap.Error(err))
+		return nil, err
+	}
+	// 只打包图片库中登记过的对象，避免把同域名下的其他文件一并下载
+	knownImages, err := a.articleModel.FindArticleImagesByObjectKeys(ctx, objectKeys)
+	if err != nil {
+		a.logger.Error("failed to find article images", zap.Error(err))
+		return nil, err
+	}
+
+	files := make([]archive.File, 0, len(records))
+	assetNames := make(map[string]string)
+	usedNames := make(map[string]struct{})
+	failedKeys := make(map[string]struct{})
+	for _, record := range records {
+		body := archive.RewriteImageURLs(record.Content, func(rawURL string) (string, bool) {
+			objectKey, ok := a.imageStore.ObjectKeyFromPublicURL(rawURL)
+			if !ok {
+				return "", false
+			}
+			if name, ok := assetNames[objectKey]; ok {
+				return archive.AssetsDir + "/" + name, true
+			}
+			if _, ok = knownImages[objectKey]; !ok {
+				return "", false
+			}
+			if _, ok = failedKeys[objectKey]; ok {
+				return "", false
+			}
+			content, err := a.imageStore.Download(ctx, objectKey, constants.MaxArticleImageSize)
+			if err != nil {
+				a.logger.Warn("failed to download article image for export",
+					zap.String("object_key", objectKey), zap.Error(err))
+				failedKeys[objectKey] = struct{}{}
+				return "", false
+			}
+			name := uniqueAssetName(path.Base(objectKey), usedNames)
+			assetNames[objectKey] = name
+			files = append(files, archive.File{Path: archive.AssetsDir + "/" + name, Content: content})
+			return archive.AssetsDir + "/" + name, true
+		})
+
+		data, err := archive.Render(archive.FrontMatter{
+			Title:    record.Title,
+			Describe: record.Describe,
+			Tags:     tagNames[record.ID],
+			Created:  record.CreateTime,
+			Updated:  record.UpdateTime,
+			Slug:     record.Slug,
+		}, body)
+		if err != nil {
+			return nil, err
+		}
+		fileName := record.Slug
+		if fileName == "" {
+			fileName = strconv.FormatUint(record.ID, 10)
+		}
+		files = append(files, archive.File{Path: fileName + ".md", Content: data})
+	}
+
+	now := articleNow()
+	body, err := archive.Write(files, now)
+	if err != nil {
+		a.logger.Error("failed to write article archive", zap.Error(err))
+		return nil, err
+	}
+	return &types.AdminExportArticleResponse{
+		FileName: "articles-" + now.Format("20060102150405") + ".zip",
+		Body:     body,
+	}, nil
+}
+
+// AdminImportArticles 把 zip 归档（本站导出的归档或打包的 Hexo / Hugo 内容目录）中的 Markdown 导入为草稿。
+// 归档内引用的图片按路径上传一次并改写为 COS 地址，单篇失败不影响其他文章
+func (a *articleService) AdminImportArticles(ctx context.Context,
+	content []byte) (*types.AdminImportArticleResponse, error) {
+	arc, err := archive.Read(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errArticleArchiveInvalid, err)
+	}
+	if len(arc.Documents) == 0 {
+		return nil, fmt.Errorf("%w: no markdown documents", errArticleArchiveInvalid)
+	}
+
+	loc := articleNow().Location()
+	uploaded := make(map[string]string)
+	failedAssets := make(map[string]string)
+	response := &types.AdminImportArticleResponse{
+		Rows:  make([]types.AdminImportArticleItem, 0, len(arc.Documents)),
+		Total: len(arc.Documents),
+	}
+	for _, docPath := range arc.Documents {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		item := types.AdminImportArticleItem{Path: docPath}
+		data, _ := arc.Content(docPath)
+		meta, body, err := archive.Parse(docPath, data, loc)
+		if err != nil {
+			item.Title = path.Base(docPath)
+			item.Error = "front matter 解析失败：" + err.Error()
+			response.Rows = append(response.Rows, item)
+			continue
+		}
+		item.Title = truncateRunes(meta.Title, importTitleMaxRunes)
+		item.Slug = meta.Slug
+
+		body = archive.ConvertHexoAssetImages(body)
+		body = archive.RewriteImageURLs(body, func(ref string) (string, bool) {
+			assetPath, ok := arc.ResolveAsset(docPath, ref)
+			if !ok {
+				return "", false
+			}
+			if publicURL, ok := uploaded[assetPath]; ok {
+				item.Images++
+				return publicURL, true
+			}
+			if reason, ok := failedAssets[assetPath]; ok {
+				item.Warnings = append(item.Warnings, fmt.Sprintf("图片 %s 上传失败：%s", assetPath, reason))
+				return "", false
+			}
+			assetContent, _ := arc.Content(assetPath)
+			result, err := a.AdminUploadArticleImage(ctx, path.Base(assetPath), "", assetContent)
+			if err != nil {
+				a.logger.Warn("failed to upload imported article image",
+					zap.String("path", assetPath), zap.Error(err))
+				failedAssets[assetPath] = err.Error()
+				item.Warnings = append(item.Warnings, fmt.Sprintf("图片 %s 上传失败：%s", assetPath, err.Error()))
+				return "", false
+			}
+			uploaded[assetPath] = result.URL
+			item.Images++
+			return result.URL, true
+		})
+		if int64(len(body)) > constants.MaxFileSize {
+			item.Error = "文章内容超过64KB"
+			response.Rows = append(response.Rows, item)
+			continue
+		}
+
+		tagIDs, _, err := a.ensureTags(ctx, importTags(meta.Tags, &item))
+		if err != nil {
+			item.Error = "创建标签失败"
+			response.Rows = append(response.Rows, item)
+			continue
+		}
+
+		draftID, err := a.idGenerator.NextID()
+		if err != nil {
+			return nil, fmt.Errorf("generate article draft id: %w", err)
+		}
+		createTime := meta.Created
+		if createTime.IsZero() {
+			createTime = articleNow()
+		}
+		updateTime := meta.Updated
+		if updateTime.IsZero() || updateTime.Before(createTime) {
+			updateTime = createTime
+		}
+		if item.Title == "" {
+			if item.Title, err = a.nextDraftTitle(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if err = a.articleModel.CreateArticleDraft(ctx, &article.Article{
+			ID:         draftID,
+			Title:      item.Title,
+			Describe:   truncateRunes(meta.Describe, importDescribeMaxRunes),
+			Content:    body,
+			Status:     article.ArticleStatusDraft,
+			CreateTime: createTime,
+			UpdateTime: updateTime,
+			TagIDs:     tagIDs,
+		}); err != nil {
+			a.logger.Error("failed to create imported article draft", zap.String("path", docPath), zap.Error(err))
+			item.Error = "创建草稿失败"
+			response.Rows = append(response.Rows, item)
+			continue
+		}
+		item.DraftID = strconv.FormatUint(draftID, 10)
+		response.Imported++
+		response.Rows = append(response.Rows, item)
+	}
+
+	a.logger.Info("article archive imported",
+		zap.Int("total", response.Total),
+		zap.Int("imported", response.Imported),
+		zap.Int("images", len(uploaded)))
+	return response, nil
+}
+
+// importTags 丢弃不符合标签规则的项并记录警告，最多保留 importMaxTags 个
+func importTags(tags []string, item *types.AdminImportArticleItem) []string {
+	result := make([]string, 0, len(tags))
+	for _, tagName := range tags {
+		if strings.Contains(tagName, ",") || utf8.RuneCountInString(tagName) > importTagMaxRunes {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("标签 %q 不符合规则，已忽略", tagName))
+			continue
+		}
+		if len(result) == importMaxTags {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("标签超过%d个，已忽略 %q", importMaxTags, tagName))
+			continue
+		}
+		result = append(result, tagName)
+	}
+	return result
+}
+
+func uniqueAssetName(name string, used map[string]struct{}) string {
+	candidate := name
+	ext := path.Ext(name)
+	for i := 1; ; i++ {
+		if _, ok := used[candidate]; !ok {
+			used[candidate] = struct{}{}
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, ext), i, ext)
+	}
+}
+
+func truncateRunes(value string, limit int) string {
+	value = strings.TrimSpace(value)
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	return string([]rune(value)[:limit])
+}
+
+func IsArticleExportTagNotFoundError(err error) bool {
+	return errors.Is(err, errArticleExportTagNotFound)
+}
+
+func IsArticleArchiveInvalidError(err error) bool {
+	return errors.Is(err, errArticleArchiveInvalid)
+}
@@ -0,0 +1,284 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AssetsDir 导出归档中存放图片的目录
+const AssetsDir = "assets"
+
+const (
+	// MaxEntries 导入归档允许的文件数上限
+	MaxEntries = 5000
+	// MaxUncompressedSize 导入归档解压后的总大小上限，防止压缩炸弹
+	MaxUncompressedSize = int64(256 << 20)
+)
+
+var (
+	ErrTooManyEntries = errors.New("archive has too many entries")
+	ErrTooLarge       = errors.New("archive uncompressed size exceeds limit")
+)
+
+// File 归档中的一个文件
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Write 把文件打包为 zip，文件按传入顺序写入
+func Write(files []File, modified time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, file := range files {
+		header := &zip.FileHeader{
+			Name:     file.Path,
+			Method:   zip.Deflate,
+			Modified: modified,
+		}
+		w, err := writer.CreateHeader(header)
+		if err != nil {
+			return nil, fmt.Errorf("create zip entry %s: %w", file.Path, err)
+		}
+		if _, err = w.Write(file.Content); err != nil {
+			return nil, fmt.Errorf("write zip entry %s: %w", file.Path, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("close zip writer: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Archive 解压后的导入归档
+type Archive struct {
+	// Documents 待导入的 Markdown 文件路径，按路径排序
+	Documents []string
+	files     map[string][]byte
+	paths     []string
+}
+
+// skippedDirs Hexo / Hugo 站点目录中不属于文章内容的目录
+var skippedDirs = map[string]struct{}{
+	"node_modules": {},
+	"themes":       {},
+	"public":       {},
+	"resources":    {},
+	"scaffolds":    {},
+	"layouts":      {},
+	"__macosx":     {},
+}
+
+// Read 解压 zip 归档，跳过目录、隐藏文件与站点生成物目录
+func Read(data []byte) (*Archive, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open zip archive: %w", err)
+	}
+	if len(reader.File) > MaxEntries {
+		return nil, ErrTooManyEntries
+	}
+
+	result := &Archive{files: make(map[string][]byte)}
+	var total int64
+	for _, entry := range reader.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		name, ok := cleanEntryPath(entry.Name)
+		if !ok || skippedPath(name) {
+			continue
+		}
+		content, err := readEntry(entry, MaxUncompressedSize-total)
+		if err != nil {
+			return nil, err
+		}
+		total += int64(len(content))
+		result.files[name] = content
+		result.paths = append(result.paths, name)
+		if isDocument(name) {
+			result.Documents = append(result.Documents, name)
+		}
+	}
+	sort.Strings(result.paths)
+	sort.Strings(result.Documents)
+	return result, nil
+}
+
+func readEntry(entry *zip.File, remaining int64) ([]byte, error) {
+	rc, err := entry.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open zip entry %s: %w", entry.Name, err)
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(io.LimitReader(rc, remaining+1))
+	if err != nil {
+		return nil, fmt.Errorf("read zip entry %s: %w", entry.Name, err)
+	}
+	if int64(len(content)) > remaining {
+		return nil, ErrTooLarge
+	}
+	return content, nil
+}
+
+func cleanEntryPath(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	cleaned := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if cleaned == "" || cleaned == "." {
+		return "", false
+	}
+	return cleaned, true
+}
+
+func skippedPath(name string) bool {
+	for _, segment := range strings.Split(name, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+		if _, ok := skippedDirs[strings.ToLower(segment)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isDocument 判断是否为待导入的文章；Hugo 的 _index.md 是栏目页，README 等说明文件也不导入
+func isDocument(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	if ext != ".md" && ext != ".markdown" {
+		return false
+	}
+	base := strings.ToLower(strings.TrimSuffix(path.Base(name), path.Ext(name)))
+	switch base {
+	case "_index", "readme", "changelog", "license":
+		return false
+	}
+	return true
+}
+
+// Content 返回归档内文件内容
+func (a *Archive) Content(name string) ([]byte, bool) {
+	content, ok := a.files[name]
+	return content, ok
+}
+
+// ResolveAsset 把文章中的图片地址解析为归档内的文件路径，依次尝试：
+//  1. 相对文章所在目录（导出的 assets/、Hugo page bundle）；
+//  2. Hexo 文章资源目录（与文章同名的目录）；
+//  3. 站点根路径（Hexo source/、Hugo static/ 等），按路径后缀匹配最短的文件。
+//
+// 带 scheme 的外部地址与 data URI 不解析。
+func (a *Archive) ResolveAsset(docPath string, ref string) (string, bool) {
+	ref = strings.TrimSpace(strings.Trim(strings.TrimSpace(ref), "<>"))
+	if ref == "" || strings.HasPrefix(ref, "//") {
+		return "", false
+	}
+	parsed, err := url.Parse(ref)
+	if err != nil || parsed.Scheme != "" || parsed.Host != "" {
+		return "", false
+	}
+	refPath := parsed.Path
+	if refPath == "" {
+		return "", false
+	}
+
+	docDir := path.Dir(docPath)
+	if !strings.HasPrefix(refPath, "/") {
+		candidates := []string{
+			path.Join(docDir, refPath),
+			path.Join(docDir, strings.TrimSuffix(path.Base(docPath), path.Ext(docPath)), refPath),
+		}
+		for _, candidate := range candidates {
+			if _, ok := a.files[candidate]; ok {
+				return candidate, true
+			}
+		}
+	}
+
+	suffix := strings.TrimPrefix(path.Clean("/"+refPath), "/")
+	if suffix == "" {
+		return "", false
+	}
+	best := ""
+	for _, name := range a.paths {
+		if name != suffix && !strings.HasSuffix(name, "/"+suffix) {
+			continue
+		}
+		if best == "" || len(name) < len(best) {
+			best = name
+		}
+	}
+	return best, best != ""
+}
+
+var (
+	markdownImagePattern = regexp.MustCompile(`!\[[^\]]*]\(\s*(<[^>]+>|[^\s)]+)(?:\s+["'][^"']*["'])?\s*\)`)
+	htmlImageSrcPattern  = regexp.MustCompile(`(?i)<img\b[^>]*?\bsrc\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	hexoAssetImgPattern  = regexp.MustCompile(`\{%\s*asset_img\s+(\S+)(?:\s+(?:"([^"]*)"|'([^']*)'|([^%]*?)))?\s*%}`)
+)
+
+// ConvertHexoAssetImages 把 Hexo 的 {% asset_img name title %} 标签转换为 Markdown 图片
+func ConvertHexoAssetImages(body string) string {
+	return hexoAssetImgPattern.ReplaceAllStringFunc(body, func(match string) string {
+		groups := hexoAssetImgPattern.FindStringSubmatch(match)
+		alt := strings.TrimSpace(groups[2] + groups[3] + groups[4])
+		return "![" + alt + "](" + groups[1] + ")"
+	})
+}
+
+// RewriteImageURLs 改写 Markdown 图片与 HTML img src 中的地址，mapper 返回 false 时保持原样
+func RewriteImageURLs(body string, mapper func(string) (string, bool)) string {
+	body = replaceSubmatch(body, markdownImagePattern, func(raw string) (string, bool) {
+		return mapper(strings.Trim(raw, "<>"))
+	})
+	return replaceSubmatch(body, htmlImageSrcPattern, mapper)
+}
+
+// ImageURLs 返回正文中 Markdown 图片与 HTML img src 引用的地址（去重，按出现顺序）
+func ImageURLs(body string) []string {
+	urls := make([]string, 0)
+	seen := make(map[string]struct{})
+	RewriteImageURLs(body, func(raw string) (string, bool) {
+		if _, ok := seen[raw]; !ok {
+			seen[raw] = struct{}{}
+			urls = append(urls, raw)
+		}
+		return "", false
+	})
+	return urls
+}
+
+// replaceSubmatch 只替换各匹配中第一个非空捕获组，保留其余文本
+func replaceSubmatch(body string, pattern *regexp.Regexp, mapper func(string) (string, bool)) string {
+	matches := pattern.FindAllStringSubmatchIndex(body, -1)
+	if len(matches) == 0 {
+		return body
+	}
+	var buf strings.Builder
+	last := 0
+	for _, match := range matches {
+		for group := 1; group*2 < len(match); group++ {
+			start, end := match[group*2], match[group*2+1]
+			if start < 0 {
+				continue
+			}
+			if replacement, ok := mapper(body[start:end]); ok {
+				buf.WriteString(body[last:start])
+				buf.WriteString(replacement)
+				last = end
+			}
+			break
+		}
+	}
+	buf.WriteString(body[last:])
+	return buf.String()
+}
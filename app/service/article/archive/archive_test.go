@@ -0,0 +1,201 @@
+package archive
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var shanghai = time.FixedZone("CST", 8*3600)
+
+func TestRenderParseRoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 1, 9, 30, 0, 0, shanghai)
+	meta := FrontMatter{
+		Title:    "Redis: ZSet 实践",
+		Describe: "排行榜 # 时间线",
+		Tags:     []string{"Redis", "数据库"},
+		Created:  created,
+		Updated:  created.Add(time.Hour),
+		Slug:     "redis-zset",
+	}
+	data, err := Render(meta, "# 标题\n\n正文")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "---\ntitle: ") {
+		t.Fatalf("unexpected header: %q", data)
+	}
+
+	parsed, body, err := Parse("redis-zset.md", data, shanghai)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if body != "# 标题\n\n正文\n" {
+		t.Fatalf("body = %q", body)
+	}
+	if parsed.Title != meta.Title || parsed.Describe != meta.Describe || parsed.Slug != meta.Slug {
+		t.Fatalf("parsed = %+v", parsed)
+	}
+	if !reflect.DeepEqual(parsed.Tags, meta.Tags) {
+		t.Fatalf("tags = %v", parsed.Tags)
+	}
+	if !parsed.Created.Equal(meta.Created) || !parsed.Updated.Equal(meta.Updated) {
+		t.Fatalf("times = %v / %v", parsed.Created, parsed.Updated)
+	}
+}
+
+func TestParseHexoFrontMatter(t *testing.T) {
+	data := "---\ntitle: Hello Hexo\ndate: 2019-08-01 12:00:00\ntags: [Go, Hexo, Go]\ndescription: 旧博客\n---\n正文 <!-- more --> 更多\n"
+	meta, body, err := Parse("source/_posts/hello.md", []byte(data), shanghai)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if meta.Title != "Hello Hexo" || meta.Describe != "旧博客" {
+		t.Fatalf("meta = %+v", meta)
+	}
+	if !reflect.DeepEqual(meta.Tags, []string{"Go", "Hexo"}) {
+		t.Fatalf("tags = %v", meta.Tags)
+	}
+	want := time.Date(2019, 8, 1, 12, 0, 0, 0, shanghai)
+	if !meta.Created.Equal(want) {
+		t.Fatalf("created = %v, want %v", meta.Created, want)
+	}
+	if body != "正文 <!-- more --> 更多\n" {
+		t.Fatalf("body = %q", body)
+	}
+}
+
+func TestParseHugoTOMLFrontMatter(t *testing.T) {
+	data := "+++\ntitle = \"Hugo \\\"bundle\\\"\"\ndate = 2021-02-03T04:05:06+08:00\nlastmod = '2021-03-01'\ntags = [\"a, b\", 'c']\nslug = \"hugo-bundle\"\ndraft = false\n[params]\nauthor = \"x\"\n+++\n\nbody\n"
+	meta, body, err := Parse("content/posts/hugo-bundle/index.md", []byte(data), shanghai)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if meta.Title != `Hugo "bundle"` || meta.Slug != "hugo-bundle" {
+		t.Fatalf("meta = %+v", meta)
+	}
+	if !reflect.DeepEqual(meta.Tags, []string{"a, b", "c"}) {
+		t.Fatalf("tags = %v", meta.Tags)
+	}
+	if !meta.Created.Equal(time.Date(2021, 2, 3, 4, 5, 6, 0, shanghai)) {
+		t.Fatalf("created = %v", meta.Created)
+	}
+	if !meta.Updated.Equal(time.Date(2021, 3, 1, 0, 0, 0, 0, shanghai)) {
+		t.Fatalf("updated = %v", meta.Updated)
+	}
+	if body != "body\n" {
+		t.Fatalf("body = %q", body)
+	}
+}
+
+func TestParseWithoutFrontMatterUsesFileName(t *testing.T) {
+	meta, body, err := Parse("notes/plain-note.md", []byte("just text"), shanghai)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if meta.Title != "plain-note" || body != "just text" {
+		t.Fatalf("meta = %+v body = %q", meta, body)
+	}
+
+	bundle, _, err := Parse("content/posts/my-trip/index.md", []byte("x"), shanghai)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if bundle.Title != "my-trip" {
+		t.Fatalf("bundle title = %q", bundle.Title)
+	}
+}
+
+func TestWriteReadRoundTripSkipsSiteFiles(t *testing.T) {
+	data, err := Write([]File{
+		{Path: "a.md", Content: []byte("a")},
+		{Path: "assets/x.png", Content: []byte("png")},
+		{Path: "README.md", Content: []byte("readme")},
+		{Path: "content/posts/_index.md", Content: []byte("section")},
+		{Path: "node_modules/pkg/doc.md", Content: []byte("dep")},
+		{Path: ".git/HEAD", Content: []byte("ref")},
+		{Path: "../escape.md", Content: []byte("escape")},
+	}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	archive, err := Read(data)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !reflect.DeepEqual(archive.Documents, []string{"a.md", "escape.md"}) {
+		t.Fatalf("documents = %v", archive.Documents)
+	}
+	if content, ok := archive.Content("assets/x.png"); !ok || string(content) != "png" {
+		t.Fatalf("asset content = %q, %v", content, ok)
+	}
+	if _, ok := archive.Content("node_modules/pkg/doc.md"); ok {
+		t.Fatal("node_modules should be skipped")
+	}
+}
+
+func TestResolveAsset(t *testing.T) {
+	data, err := Write([]File{
+		{Path: "blog/source/_posts/hello.md", Content: []byte("x")},
+		{Path: "blog/source/_posts/hello/cover.png", Content: []byte("1")},
+		{Path: "blog/source/images/logo.png", Content: []byte("2")},
+		{Path: "blog/themes/next/source/images/logo.png", Content: []byte("3")},
+		{Path: "site/content/posts/trip/index.md", Content: []byte("x")},
+		{Path: "site/content/posts/trip/photo.jpg", Content: []byte("4")},
+		{Path: "export/post.md", Content: []byte("x")},
+		{Path: "export/assets/a.png", Content: []byte("5")},
+	}, time.Now())
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	archive, err := Read(data)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+
+	cases := []struct {
+		doc, ref, want string
+	}{
+		{"blog/source/_posts/hello.md", "cover.png", "blog/source/_posts/hello/cover.png"},
+		{"blog/source/_posts/hello.md", "/images/logo.png?v=2", "blog/source/images/logo.png"},
+		{"site/content/posts/trip/index.md", "photo.jpg", "site/content/posts/trip/photo.jpg"},
+		{"export/post.md", "<assets/a.png>", "export/assets/a.png"},
+		{"export/post.md", "https://cdn.example.com/a.png", ""},
+		{"export/post.md", "missing.png", ""},
+	}
+	for _, tc := range cases {
+		got, ok := archive.ResolveAsset(tc.doc, tc.ref)
+		if got != tc.want || ok != (tc.want != "") {
+			t.Errorf("ResolveAsset(%q, %q) = %q, %v; want %q", tc.doc, tc.ref, got, ok, tc.want)
+		}
+	}
+}
+
+func TestRewriteImageURLs(t *testing.T) {
+	body := "![a](img/a.png \"t\") ![b](<img/b c.png>) [link](img/a.png)\n<img alt=\"x\" src='img/a.png'> ![ext](https://x/y.png)"
+	got := RewriteImageURLs(body, func(raw string) (string, bool) {
+		if strings.HasPrefix(raw, "img/") {
+			return "https://cdn/" + strings.TrimPrefix(raw, "img/"), true
+		}
+		return "", false
+	})
+	want := "![a](https://cdn/a.png \"t\") ![b](https://cdn/b c.png) [link](img/a.png)\n<img alt=\"x\" src='https://cdn/a.png'> ![ext](https://x/y.png)"
+	if got != want {
+		t.Fatalf("got  %q\nwant %q", got, want)
+	}
+
+	urls := ImageURLs(body)
+	if !reflect.DeepEqual(urls, []string{"img/a.png", "img/b c.png", "https://x/y.png"}) {
+		t.Fatalf("urls = %v", urls)
+	}
+}
+
+func TestConvertHexoAssetImages(t *testing.T) {
+	got := ConvertHexoAssetImages(`{% asset_img cover.png "封面 图" %} and {% asset_img b.jpg %}`)
+	want := "![封面 图](cover.png) and ![](b.jpg)"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
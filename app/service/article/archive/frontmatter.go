@@ -0,0 +1,355 @@
+// Package archive 负责文章 Markdown 归档的打包与解析：带 front matter 的 Markdown 文件、
+// assets 图片目录，以及导入 Hexo / Hugo 内容目录时的图片路径解析与 URL 改写。
+//
+// 这里只处理纯数据转换，数据库读写、COS 上传下载与草稿创建由 article service 负责。
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TimeLayout front matter 中 created / updated 的输出格式
+const TimeLayout = "2006-01-02 15:04:05"
+
+// FrontMatter 文章元信息
+type FrontMatter struct {
+	Title    string
+	Describe string
+	Tags     []string
+	Created  time.Time
+	Updated  time.Time
+	Slug     string
+}
+
+// frontMatterYAML 导出时的字段顺序与命名
+type frontMatterYAML struct {
+	Title    string   `yaml:"title"`
+	Describe string   `yaml:"describe"`
+	Tags     []string `yaml:"tags"`
+	Created  string   `yaml:"created"`
+	Updated  string   `yaml:"updated"`
+	Slug     string   `yaml:"slug,omitempty"`
+}
+
+// Render 把 front matter 与正文拼成一个 Markdown 文件
+func Render(meta FrontMatter, body string) ([]byte, error) {
+	tags := meta.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	header, err := yaml.Marshal(frontMatterYAML{
+		Title:    meta.Title,
+		Describe: meta.Describe,
+		Tags:     tags,
+		Created:  formatTime(meta.Created),
+		Updated:  formatTime(meta.Updated),
+		Slug:     meta.Slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal front matter: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n\n")
+	buf.WriteString(strings.TrimLeft(body, "\r\n"))
+	if !strings.HasSuffix(body, "\n") {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// Parse 解析 Markdown 文件的 front matter，支持 YAML（---）与 Hugo 的 TOML（+++）。
+// 没有 front matter 时整个文件视为正文；标题缺失时取文件名（Hugo page bundle 取目录名）。
+// 不带时区的时间按 loc 解析。
+func Parse(filePath string, data []byte, loc *time.Location) (FrontMatter, string, error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	fields := map[string]any{}
+	body := text
+	switch {
+	case strings.HasPrefix(text, "---\n"):
+		header, rest, ok := splitFrontMatter(text, "---")
+		if ok {
+			parsed, err := parseYAML(header)
+			if err != nil {
+				return FrontMatter{}, "", fmt.Errorf("parse yaml front matter: %w", err)
+			}
+			fields = parsed
+			body = rest
+		}
+	case strings.HasPrefix(text, "+++\n"):
+		header, rest, ok := splitFrontMatter(text, "+++")
+		if ok {
+			parsed, err := parseTOML(header)
+			if err != nil {
+				return FrontMatter{}, "", fmt.Errorf("parse toml front matter: %w", err)
+			}
+			fields = parsed
+			body = rest
+		}
+	}
+
+	meta := FrontMatter{
+		Title:    stringField(fields, "title"),
+		Describe: stringField(fields, "describe", "description", "summary", "excerpt"),
+		Tags:     listField(fields, "tags"),
+		Created:  timeField(fields, loc, "created", "date"),
+		Updated:  timeField(fields, loc, "updated", "lastmod", "modified"),
+		Slug:     stringField(fields, "slug"),
+	}
+	if meta.Title == "" {
+		meta.Title = titleFromPath(filePath)
+	}
+	return meta, strings.TrimLeft(body, "\n"), nil
+}
+
+// parseYAML 标量一律保留原始文本：YAML 会把不带时区的 date 解析成 UTC，
+// 而 Hexo 的时间是站点本地时间，交给 timeField 按 loc 解析
+func parseYAML(header string) (map[string]any, error) {
+	nodes := make(map[string]yaml.Node)
+	if err := yaml.Unmarshal([]byte(header), &nodes); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]any, len(nodes))
+	for key, node := range nodes {
+		switch node.Kind {
+		case yaml.ScalarNode:
+			fields[key] = node.Value
+		case yaml.SequenceNode:
+			items := make([]any, 0, len(node.Content))
+			for _, item := range node.Content {
+				if item.Kind == yaml.ScalarNode {
+					items = append(items, item.Value)
+				}
+			}
+			fields[key] = items
+		}
+	}
+	return fields, nil
+}
+
+func splitFrontMatter(text string, fence string) (string, string, bool) {
+	rest := text[len(fence)+1:]
+	if strings.HasPrefix(rest, fence+"\n") || rest == fence {
+		return "", strings.TrimPrefix(rest, fence), true
+	}
+	end := strings.Index(rest, "\n"+fence+"\n")
+	if end < 0 {
+		if strings.HasSuffix(rest, "\n"+fence) {
+			return rest[:len(rest)-len(fence)-1], "", true
+		}
+		return "", "", false
+	}
+	return rest[:end], rest[end+len(fence)+2:], true
+}
+
+func titleFromPath(filePath string) string {
+	name := strings.TrimSuffix(path.Base(filePath), path.Ext(filePath))
+	if strings.EqualFold(name, "index") {
+		if dir := path.Base(path.Dir(filePath)); dir != "." && dir != "/" {
+			name = dir
+		}
+	}
+	return name
+}
+
+func stringField(fields map[string]any, keys ...string) string {
+	for _, key := range keys {
+		switch value := fields[key].(type) {
+		case string:
+			if trimmed := strings.TrimSpace(value); trimmed != "" {
+				return trimmed
+			}
+		case int, int64, float64, bool:
+			return fmt.Sprint(value)
+		}
+	}
+	return ""
+}
+
+// listField 兼容 tags: a、tags: [a, b] 与 YAML 列表三种写法
+func listField(fields map[string]any, key string) []string {
+	result := make([]string, 0)
+	seen := make(map[string]struct{})
+	add := func(value string) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return
+		}
+		if _, ok := seen[value]; ok {
+			return
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+	switch value := fields[key].(type) {
+	case string:
+		for _, item := range strings.Split(value, ",") {
+			add(item)
+		}
+	case []any:
+		for _, item := range value {
+			add(fmt.Sprint(item))
+		}
+	case []string:
+		for _, item := range value {
+			add(item)
+		}
+	}
+	return result
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	TimeLayout,
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func timeField(fields map[string]any, loc *time.Location, keys ...string) time.Time {
+	for _, key := range keys {
+		switch value := fields[key].(type) {
+		case string:
+			value = strings.TrimSpace(value)
+			for _, layout := range timeLayouts {
+				if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+					return t.In(loc)
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(TimeLayout)
+}
+
+// parseTOML 只解析 front matter 常见的顶层 key = value：字符串、数字、布尔、日期与字符串数组，
+// 表（[params]）之后的内容忽略。
+func parseTOML(header string) (map[string]any, error) {
+	fields := make(map[string]any)
+	for lineNo, line := range strings.Split(header, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			break
+		}
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing '='", lineNo+1)
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"'`)
+		value, err := parseTOMLValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo+1, err)
+		}
+		fields[key] = value
+	}
+	return fields, nil
+}
+
+func parseTOMLValue(raw string) (any, error) {
+	switch {
+	case strings.HasPrefix(raw, "["):
+		end := strings.LastIndex(raw, "]")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		items := make([]any, 0)
+		for _, part := range splitTOMLArray(raw[1:end]) {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			value, err := parseTOMLValue(part)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case strings.HasPrefix(raw, `"`):
+		end := closingQuote(raw)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		value, err := strconv.Unquote(raw[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid string: %w", err)
+		}
+		return value, nil
+	case strings.HasPrefix(raw, "'"):
+		end := strings.Index(raw[1:], "'")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated string")
+		}
+		return raw[1 : end+1], nil
+	}
+	if comment := strings.Index(raw, " #"); comment >= 0 {
+		raw = strings.TrimSpace(raw[:comment])
+	}
+	switch raw {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil {
+		return f, nil
+	}
+	// 其余按裸日期等字面量原样返回，由 timeField 解析
+	return raw, nil
+}
+
+// closingQuote 返回双引号字符串结束引号的下标，跳过转义
+func closingQuote(raw string) int {
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func splitTOMLArray(raw string) []string {
+	parts := make([]string, 0)
+	var quote rune
+	start := 0
+	for i, r := range raw {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			parts = append(parts, raw[start:i])
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	return append(parts, raw[start:])
+}
@@ -20,12 +20,13 @@ const (
 	warmUpBatchSize = 1000
 )
 
-// WarmUpCache 启动时预热文章 ZSet 缓存：清空旧数据并按时间/浏览量重新构建
+// WarmUpCache 启动时预热文章 ZSet 缓存：清空旧数据并按时间/浏览量重新构建，同时重建不公开文章集合
 // 使用 Pipeline + 分批写入，将 N 次 RTT 压缩为 ⌈N/batch⌉ 次
 func (a *articleService) WarmUpCache(ctx context.Context) error {
 	timeKey := cachekey.ArticleTimeZSet().String()
 	viewKey := cachekey.ArticleViewZSet().String()
-	if err := a.redis.Del(ctx, timeKey, viewKey).Err(); err != nil {
+	hiddenKey := cachekey.ArticleHiddenSet().String()
+	if err := a.redis.Del(ctx, timeKey, viewKey, hiddenKey).Err(); err != nil {
 		a.logger.Error("failed to clear article ZSet", zap.Error(err))
 		return fmt.Errorf("failed to clear article ZSet: %w", err)
 	}
@@ -53,7 +54,11 @@ func (a *articleService) WarmUpCache(ctx context.Context) error {
 
 		timeMembers := make([]redis.Z, 0, end-start)
 		viewMembers := make([]redis.Z, 0, end-start)
+		hiddenMembers := make([]any, 0)
 		for _, d := range list[start:end] {
+			if d.Visibility != article.ArticleVisibilityPublic {
+				hiddenMembers = append(hiddenMembers, d.ID)
+			}
 			timeMembers = append(timeMembers, redis.Z{
 				Score:  cachekey.ArticleTimeScore(d.CreateTime),
 				Member: d.ID,
@@ -67,6 +72,9 @@ func (a *articleService) WarmUpCache(ctx context.Context) error {
 		pipe := a.redis.Pipeline()
 		pipe.ZAdd(ctx, timeKey, timeMembers...)
 		pipe.ZAdd(ctx, viewKey, viewMembers...)
+		if len(hiddenMembers) > 0 {
+			pipe.SAdd(ctx, hiddenKey, hiddenMembers...)
+		}
 		if _, err = pipe.Exec(ctx); err != nil {
 			a.logger.Error("failed to warm up article ZSet",
 				zap.Int("start", start), zap.Int("end", end), zap.Error(err))
@@ -131,7 +139,7 @@ func toIDString(member any) (string, bool) {
 func (a *articleService) RegisterCronJobs(c *cron.Cron) ([]cron.EntryID, error) {
 	entryID, err := c.AddFunc(constants.Spec, func() {
 		// 每次 cron 触发都使用独立的超时 ctx，避免长任务卡住调度器
-		ctx, cancel := context.WithTimeout(context.Background(), 30 * time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		if err := a.PersistViewCount(ctx); err != nil {
@@ -141,6 +149,107 @@ func (a *articleService) RegisterCronJobs(c *cron.Cron) ([]cron.EntryID, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to register article cron jobs: %w", err)
 	}
-	a.logger.Info("article cron jobs registered", zap.String("spec", constants.Spec))
-	return []cron.EntryID{entryID}, nil
+
+	searchEntryID, err := c.AddFunc(constants.SearchIndexRebuildSpec, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := a.RebuildSearchIndex(ctx); err != nil {
+			a.logger.Error("cron rebuild search index failed", zap.Error(err))
+		}
+	})
+	if err != nil {
+		c.Remove(entryID)
+		return nil, fmt.Errorf("failed to register article search index cron job: %w", err)
+	}
+
+	scheduleEntryID, err := c.AddFunc(constants.ArticleScheduleSpec, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Second)
+		defer cancel()
+
+		if err := a.RunScheduledPublishing(ctx); err != nil {
+			a.logger.Error("cron run scheduled publishing failed", zap.Error(err))
+		}
+	})
+	if err != nil {
+		c.Remove(entryID)
+		c.Remove(searchEntryID)
+		return nil, fmt.Errorf("failed to register article schedule cron job: %w", err)
+	}
+
+	trashEntryID, err := c.AddFunc(constants.TrashPurgeSpec, func() {
+		// 过期文章与图片逐条清理并删除 COS 对象，给予更宽裕的超时
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		if err := a.PurgeExpiredTrash(ctx); err != nil {
+			a.logger.Error("cron purge expired article trash failed", zap.Error(err))
+		}
+	})
+	if err != nil {
+		c.Remove(entryID)
+		c.Remove(searchEntryID)
+		c.Remove(scheduleEntryID)
+		return nil, fmt.Errorf("failed to register article trash cron job: %w", err)
+	}
+
+	viewStatsEntryID, err := c.AddFunc(constants.ViewStatsFlushSpec, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := a.FlushViewStats(ctx); err != nil {
+			a.logger.Error("cron flush article view stats failed", zap.Error(err))
+		}
+	})
+	if err != nil {
+		c.Remove(entryID)
+		c.Remove(searchEntryID)
+		c.Remove(scheduleEntryID)
+		c.Remove(trashEntryID)
+		return nil, fmt.Errorf("failed to register article view stats cron job: %w", err)
+	}
+
+	trendingEntryID, err := c.AddFunc(constants.TrendingRescoreSpec, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := a.RescoreTrending(ctx); err != nil {
+			a.logger.Error("cron rescore trending articles failed", zap.Error(err))
+		}
+	})
+	if err != nil {
+		c.Remove(entryID)
+		c.Remove(searchEntryID)
+		c.Remove(scheduleEntryID)
+		c.Remove(trashEntryID)
+		c.Remove(viewStatsEntryID)
+		return nil, fmt.Errorf("failed to register article trending cron job: %w", err)
+	}
+	imageGCEntryID, err := c.AddFunc(constants.ArticleImageGCSpec, func() {
+		// 需要扫描全部文章内容并逐个删除存储对象，给予更宽裕的超时
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		if err := a.CollectOrphanedArticleImages(ctx); err != nil {
+			a.logger.Error("cron collect orphaned article images failed", zap.Error(err))
+		}
+	})
+	if err != nil {
+		c.Remove(entryID)
+		c.Remove(searchEntryID)
+		c.Remove(scheduleEntryID)
+		c.Remove(trashEntryID)
+		c.Remove(viewStatsEntryID)
+		c.Remove(trendingEntryID)
+		return nil, fmt.Errorf("failed to register article image gc cron job: %w", err)
+	}
+	a.logger.Info("article cron jobs registered", zap.String("spec", constants.Spec),
+		zap.String("searchIndexSpec", constants.SearchIndexRebuildSpec),
+		zap.String("scheduleSpec", constants.ArticleScheduleSpec),
+		zap.String("trashPurgeSpec", constants.TrashPurgeSpec),
+		zap.String("viewStatsFlushSpec", constants.ViewStatsFlushSpec),
+		zap.String("trendingRescoreSpec", constants.TrendingRescoreSpec),
+		zap.String("imageGCSpec", constants.ArticleImageGCSpec))
+	return []cron.EntryID{entryID, searchEntryID, scheduleEntryID, trashEntryID, viewStatsEntryID, trendingEntryID,
+		imageGCEntryID}, nil
 }
@@ -0,0 +1,246 @@
+package article
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/common/types"
+)
+
+const (
+	// 阅读速度：中日韩文字约 300 字/分钟，英文等约 200 词/分钟
+	cjkCharsPerMinute   = 300
+	latinWordsPerMinute = 200
+
+	contentStatsBackfillBatch = 100
+)
+
+var (
+	contentFencePattern      = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	contentHeadingPattern    = regexp.MustCompile(`^\s{0,3}(#{1,6})(?:[ \t]+(.*?))?[ \t]*$`)
+	contentClosingHashes     = regexp.MustCompile(`(^|[ \t]+)#+$`)
+	contentImagePattern      = regexp.MustCompile(`!\[([^\]]*)]\([^)]*\)`)
+	contentLinkPattern       = regexp.MustCompile(`\[([^\]]*)]\([^)]*\)`)
+	contentHTMLTagPattern    = regexp.MustCompile(`<[^>]+>`)
+	contentLinePrefixPattern = regexp.MustCompile(`^\s{0,3}(#{1,6}\s+|>\s?|[-*+]\s+|\d+\.\s+)`)
+	contentMarkPattern       = regexp.MustCompile("[*_`~|]+")
+	contentSpacePattern      = regexp.MustCompile(`[ \t]+`)
+)
+
+type contentHeading struct {
+	level int
+	text  string
+}
+
+// processArticleContent 文章内容处理流程：从 Markdown 正文生成目录树、纯文本、字数与阅读时长。
+// 发布草稿、新增和更新文章时调用，结果随文章写入 MySQL 与 article:{id}:Hash。
+func processArticleContent(content string) article.ContentStats {
+	headings := make([]contentHeading, 0)
+	lines := make([]string, 0)
+	inFence := false
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if contentFencePattern.MatchString(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			// 代码保留在纯文本中，便于按函数名等搜索，但不识别其中的标题
+			lines = append(lines, line)
+			continue
+		}
+		if match := contentHeadingPattern.FindStringSubmatch(line); match != nil {
+			text := contentClosingHashes.ReplaceAllString(match[2], "")
+			text = plainInlineText(text)
+			if text != "" {
+				headings = append(headings, contentHeading{level: len(match[1]), text: text})
+			}
+			lines = append(lines, text)
+			continue
+		}
+		lines = append(lines, plainInlineText(contentLinePrefixPattern.ReplaceAllString(line, "")))
+	}
+
+	plainText := joinPlainTextLines(lines)
+	cjk, words := countContentWords(plainText)
+	stats := article.ContentStats{
+		PlainText:   plainText,
+		WordCount:   cjk + words,
+		CharCount:   countContentChars(plainText),
+		ReadingTime: estimateReadingTime(cjk, words),
+	}
+	toc, err := sonic.MarshalString(buildContentTOC(headings))
+	if err != nil {
+		toc = "[]"
+	}
+	stats.TOC = toc
+	return stats
+}
+
+// applyArticleContent 把内容处理结果写到待保存的文章上
+func applyArticleContent(articleInfo *article.Article) {
+	stats := processArticleContent(articleInfo.Content)
+	articleInfo.TOC = stats.TOC
+	articleInfo.PlainText = stats.PlainText
+	articleInfo.WordCount = stats.WordCount
+	articleInfo.CharCount = stats.CharCount
+	articleInfo.ReadingTime = stats.ReadingTime
+}
+
+// BackfillArticleContent 为功能上线前发布的文章补算派生字段，启动时执行
+func (a *articleService) BackfillArticleContent(ctx context.Context) error {
+	total := 0
+	for {
+		list, err := a.articleModel.ListArticlesWithoutContentStats(ctx, contentStatsBackfillBatch)
+		if err != nil {
+			a.logger.Error("failed to list articles without content stats", zap.Error(err))
+			return err
+		}
+		for _, item := range list {
+			if err = a.articleModel.UpdateArticleContentStats(ctx, item.ID, processArticleContent(item.Content)); err != nil {
+				a.logger.Error("failed to backfill article content stats", zap.Uint64("articleID", item.ID), zap.Error(err))
+				return err
+			}
+		}
+		total += len(list)
+		if len(list) < contentStatsBackfillBatch {
+			break
+		}
+	}
+	if total > 0 {
+		a.logger.Info("article content stats backfilled", zap.Int("total", total))
+	}
+	return nil
+}
+
+// parseArticleTOC 反序列化文章上保存的目录树，异常时返回空目录
+func parseArticleTOC(value string) []types.ArticleTOCNode {
+	toc := make([]types.ArticleTOCNode, 0)
+	if value == "" {
+		return toc
+	}
+	if err := sonic.UnmarshalString(value, &toc); err != nil {
+		return make([]types.ArticleTOCNode, 0)
+	}
+	return toc
+}
+
+// plainInlineText 去掉行内的图片、链接、HTML 标签与强调符号
+func plainInlineText(text string) string {
+	text = contentImagePattern.ReplaceAllString(text, "$1")
+	text = contentLinkPattern.ReplaceAllString(text, "$1")
+	text = contentHTMLTagPattern.ReplaceAllString(text, " ")
+	text = contentMarkPattern.ReplaceAllString(text, " ")
+	text = contentSpacePattern.ReplaceAllString(text, " ")
+	return strings.TrimSpace(text)
+}
+
+// joinPlainTextLines 去掉空行，段落之间保留一个换行
+func joinPlainTextLines(lines []string) string {
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// buildContentTOC 按标题级别构建目录树：级别更深的标题挂到前一个更浅的标题下，
+// 跳级（h1 后直接 h3）同样视为子节点。锚点按 GitHub 的规则生成，重复时追加 -1、-2。
+func buildContentTOC(headings []contentHeading) []types.ArticleTOCNode {
+	anchors := make(map[string]int, len(headings))
+	nodes, _ := buildContentTOCLevel(headings, 0, 0, anchors)
+	return nodes
+}
+
+func buildContentTOCLevel(headings []contentHeading, index int, parentLevel int,
+	anchors map[string]int) ([]types.ArticleTOCNode, int) {
+	nodes := make([]types.ArticleTOCNode, 0)
+	for index < len(headings) && headings[index].level > parentLevel {
+		heading := headings[index]
+		node := types.ArticleTOCNode{
+			Level:  heading.level,
+			Text:   heading.text,
+			Anchor: uniqueHeadingAnchor(heading.text, anchors),
+		}
+		node.Children, index = buildContentTOCLevel(headings, index+1, heading.level, anchors)
+		nodes = append(nodes, node)
+	}
+	return nodes, index
+}
+
+func uniqueHeadingAnchor(text string, anchors map[string]int) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || r == '-' || r == '_':
+			builder.WriteRune(r)
+		case unicode.IsSpace(r):
+			builder.WriteByte('-')
+		}
+	}
+	anchor := builder.String()
+	if anchor == "" {
+		anchor = "section"
+	}
+	count := anchors[anchor]
+	anchors[anchor] = count + 1
+	if count > 0 {
+		return anchor + "-" + strconv.Itoa(count)
+	}
+	return anchor
+}
+
+// countContentWords 中日韩文字每个字计一次，其他连续的字母数字计为一个词
+func countContentWords(text string) (cjk int, words int) {
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isCJKRune(r):
+			cjk++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if !inWord {
+				words++
+				inWord = true
+			}
+		case r == '\'' || r == '-':
+			// don't、well-known 这类词内符号不拆词
+		default:
+			inWord = false
+		}
+	}
+	return cjk, words
+}
+
+func countContentChars(text string) int {
+	count := 0
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			count++
+		}
+	}
+	return count
+}
+
+func isCJKRune(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// estimateReadingTime 预计阅读分钟数，向上取整，有内容时至少 1 分钟
+func estimateReadingTime(cjk int, words int) int {
+	if cjk == 0 && words == 0 {
+		return 0
+	}
+	minutes := float64(cjk)/cjkCharsPerMinute + float64(words)/latinWordsPerMinute
+	return max(1, int(math.Ceil(minutes)))
+}
@@ -0,0 +1,85 @@
+package article
+
+import (
+	"strings"
+	"testing"
+
+	"meta-api/common/types"
+)
+
+func TestProcessArticleContentTOC(t *testing.T) {
+	content := strings.Join([]string{
+		"# Redis 入门",
+		"正文",
+		"## 安装",
+		"### Linux ###",
+		"```bash",
+		"# 这是注释，不是标题",
+		"apt install redis",
+		"```",
+		"## 安装",
+		"# [数据结构](https://redis.io) 概览",
+	}, "\n")
+	stats := processArticleContent(content)
+	toc := parseArticleTOC(stats.TOC)
+
+	if len(toc) != 2 {
+		t.Fatalf("expected 2 top level headings, got %+v", toc)
+	}
+	first := toc[0]
+	if first.Text != "Redis 入门" || first.Anchor != "redis-入门" || len(first.Children) != 2 {
+		t.Fatalf("unexpected first heading: %+v", first)
+	}
+	install := first.Children[0]
+	if install.Anchor != "安装" || len(install.Children) != 1 || install.Children[0].Text != "Linux" {
+		t.Fatalf("unexpected install heading: %+v", install)
+	}
+	if first.Children[1].Anchor != "安装-1" {
+		t.Fatalf("duplicated heading anchor not deduplicated: %+v", first.Children[1])
+	}
+	if toc[1].Text != "数据结构 概览" {
+		t.Fatalf("inline markup not stripped from heading: %+v", toc[1])
+	}
+	if !strings.Contains(stats.PlainText, "apt install redis") || strings.Contains(stats.PlainText, "https://") {
+		t.Fatalf("unexpected plain text: %q", stats.PlainText)
+	}
+}
+
+func TestProcessArticleContentCounts(t *testing.T) {
+	stats := processArticleContent("**你好**，world! It's a well-known [demo](https://example.com).")
+	// 你、好 2 个汉字 + world / It's / a / well-known / demo 5 个词
+	if stats.WordCount != 7 {
+		t.Fatalf("unexpected word count: %d (%q)", stats.WordCount, stats.PlainText)
+	}
+	if stats.CharCount != len([]rune(strings.ReplaceAll(stats.PlainText, " ", ""))) {
+		t.Fatalf("unexpected char count: %d", stats.CharCount)
+	}
+	if stats.ReadingTime != 1 {
+		t.Fatalf("short article should take 1 minute, got %d", stats.ReadingTime)
+	}
+
+	long := processArticleContent(strings.Repeat("字", 900))
+	if long.WordCount != 900 || long.ReadingTime != 3 {
+		t.Fatalf("unexpected long article stats: %+v", long)
+	}
+
+	empty := processArticleContent("")
+	if empty.ReadingTime != 0 || empty.WordCount != 0 || empty.TOC != "[]" {
+		t.Fatalf("unexpected empty article stats: %+v", empty)
+	}
+}
+
+func TestBuildContentTOCSkippedLevel(t *testing.T) {
+	toc := buildContentTOC([]contentHeading{{level: 2, text: "A"}, {level: 4, text: "B"}, {level: 3, text: "C"},
+		{level: 1, text: "D"}})
+	want := []types.ArticleTOCNode{
+		{Level: 2, Text: "A", Anchor: "a", Children: []types.ArticleTOCNode{
+			{Level: 4, Text: "B", Anchor: "b", Children: []types.ArticleTOCNode{}},
+			{Level: 3, Text: "C", Anchor: "c", Children: []types.ArticleTOCNode{}},
+		}},
+		{Level: 1, Text: "D", Anchor: "d", Children: []types.ArticleTOCNode{}},
+	}
+	if len(toc) != len(want) || len(toc[0].Children) != 2 || toc[0].Children[1].Text != "C" || toc[1].Text != "D" {
+		t.Fatalf("unexpected toc: %+v", toc)
+	}
+}
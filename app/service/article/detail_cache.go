@@ -0,0 +1,87 @@
+package article
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"meta-api/app/model/article"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/idutil"
+	"meta-api/common/readcache"
+	"meta-api/common/types"
+)
+
+// 文章详情读穿缓存策略：写路径主动删除，TTL 只兜底；过期后 5 分钟内先返回旧数据再后台刷新
+const (
+	articleDetailCacheTTL      = 30 * time.Minute
+	articleDetailCacheStaleTTL = 5 * time.Minute
+	articleDetailNegativeTTL   = time.Minute
+	articleDetailCacheJitter   = 0.1
+)
+
+// articleDetailCacheEntry 详情缓存内容，不含单独维护的系列上下文
+type articleDetailCacheEntry struct {
+	Response      types.UserGetArticleDetailResponse `json:"response"`
+	UpdateTime    time.Time                          `json:"updateTime"`
+	Visibility    string                             `json:"visibility"`
+	AccessVersion string                             `json:"accessVersion"`
+}
+
+func newArticleDetailCache(store readcache.Store, logger *zap.Logger) *readcache.Cache[articleDetailCacheEntry] {
+	return readcache.New[articleDetailCacheEntry](store, readcache.Options{
+		TTL:         articleDetailCacheTTL,
+		StaleTTL:    articleDetailCacheStaleTTL,
+		NegativeTTL: articleDetailNegativeTTL,
+		Jitter:      articleDetailCacheJitter,
+		IsNotFound: func(err error) bool {
+			return errors.Is(err, sql.ErrNoRows) || errors.Is(err, gorm.ErrRecordNotFound)
+		},
+		OnError: func(key string, err error) {
+			logger.Warn("article detail cache degraded", zap.String("key", key), zap.Error(err))
+		},
+	})
+}
+
+// loadArticleDetail 从 MySQL 加载已发布文章的详情
+func (a *articleService) loadArticleDetail(ctx context.Context, articleID string) (articleDetailCacheEntry, error) {
+	id, err := idutil.ParseID("articleID", articleID)
+	if err != nil {
+		return articleDetailCacheEntry{}, err
+	}
+	articleInfo, err := a.articleModel.GetArticleDetailByID(ctx, id)
+	if err != nil {
+		return articleDetailCacheEntry{}, err
+	}
+	return articleDetailCacheEntry{
+		Response: types.UserGetArticleDetailResponse{
+			ID:          articleID,
+			Slug:        articleInfo.Slug,
+			Title:       articleInfo.Title,
+			Tags:        nonNilTagNames(articleInfo.TagNames),
+			Content:     articleInfo.Content,
+			CreateTime:  articleInfo.CreateTime.Format(constants.TimeLayoutToMinute),
+			UpdateTime:  articleInfo.UpdateTime.Format(constants.TimeLayoutToMinute),
+			TOC:         parseArticleTOC(articleInfo.TOC),
+			WordCount:   articleInfo.WordCount,
+			CharCount:   articleInfo.CharCount,
+			ReadingTime: articleInfo.ReadingTime,
+		},
+		UpdateTime:    articleInfo.UpdateTime,
+		Visibility:    articleInfo.Visibility,
+		AccessVersion: article.AccessVersion(articleInfo.AccessPassword),
+	}, nil
+}
+
+// articleCacheKeys 单篇文章的 Hash 与详情缓存，文章内容、可见性变化时一起删除
+func articleCacheKeys(articleID string) []string {
+	return []string{
+		cachekey.ArticleHash(articleID).String(),
+		cachekey.ArticleDetailCache(articleID).String(),
+	}
+}
@@ -28,8 +28,20 @@ func (a *articleService) AdminGetArticleDraftList(ctx context.Context,
 		return nil, err
 	}
 
-	rows := make([]types.AdminArticleDraftListItem, 0, len(records))
+	leaseKeys := make([]string, 0, len(records))
 	for _, record := range records {
+		leaseKeys = append(leaseKeys, cachekey.ArticleEditLeaseHash(articleEditLeaseKindDraft,
+			strconv.FormatUint(record.ID, 10)).String())
+	}
+	// 编辑状态只是提示，读取失败不影响草稿列表
+	leases, err := a.articleEditLeases(ctx, leaseKeys)
+	if err != nil {
+		a.logger.Warn("failed to get article draft edit leases", zap.Error(err))
+		leases = make([]*types.AdminArticleEditLeaseItem, len(records))
+	}
+
+	rows := make([]types.AdminArticleDraftListItem, 0, len(records))
+	for i, record := range records {
 		draftType := "new"
 		if record.PublishedID != nil {
 			draftType = "edit"
@@ -38,10 +50,14 @@ func (a *articleService) AdminGetArticleDraftList(ctx context.Context,
 			ID:         strconv.FormatUint(record.ID, 10),
 			DraftType:  draftType,
 			Title:      record.Title,
-			Tag:        record.TagName,
+			Tags:       nonNilTagNames(record.TagNames),
+			Scheduled:  record.PublishAt != nil,
 			CreateTime: record.CreateTime.Format(constants.TimeLayoutToMinute),
 			UpdateTime: record.UpdateTime.Format(constants.TimeLayoutToMinute),
+			Lock:       leases[i],
 		}
+		item.PublishAt = formatArticleScheduleTime(record.PublishAt)
+		item.UnpublishAt = formatArticleScheduleTime(record.UnpublishAt)
 		if record.PublishedID != nil {
 			item.ArticleID = strconv.FormatUint(*record.PublishedID, 10)
 		}
@@ -65,11 +81,14 @@ func (a *articleService) AdminGetArticleDraftDetail(ctx context.Context,
 	}
 
 	response := &types.AdminGetArticleDraftDetailResponse{
-		ID:       strconv.FormatUint(draft.ID, 10),
-		Title:    draft.Title,
-		Tag:      draft.TagName,
-		Describe: draft.Describe,
-		Content:  draft.Content,
+		ID:          strconv.FormatUint(draft.ID, 10),
+		Title:       draft.Title,
+		Tags:        nonNilTagNames(draft.TagNames),
+		Describe:    draft.Describe,
+		Content:     draft.Content,
+		PublishAt:   formatArticleScheduleTime(draft.PublishAt),
+		UnpublishAt: formatArticleScheduleTime(draft.UnpublishAt),
+		Version:     draft.Version,
 	}
 	if draft.PublishedID != nil {
 		response.ArticleID = strconv.FormatUint(*draft.PublishedID, 10)
@@ -95,14 +114,21 @@ func (a *articleService) AdminSaveArticleDraft(ctx context.Context,
 		publishedID = &publishedIDValue
 	}
 
-	var tagID *uint64
-	tagName := strings.TrimSpace(request.Tag)
-	if tagName != "" {
-		tagInfo, err := a.ensureTag(ctx, tagName)
-		if err != nil {
-			return nil, err
-		}
-		tagID = &tagInfo.ID
+	publishAt, err := parseArticleScheduleTime(request.PublishAt)
+	if err != nil {
+		return nil, err
+	}
+	unpublishAt, err := parseArticleScheduleTime(request.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateArticleSchedule(publishAt, unpublishAt); err != nil {
+		return nil, err
+	}
+
+	tagIDs, _, err := a.ensureTags(ctx, request.Tags)
+	if err != nil {
+		return nil, err
 	}
 
 	now := articleNow()
@@ -133,17 +159,21 @@ func (a *articleService) AdminSaveArticleDraft(ctx context.Context,
 			Content:     request.Content,
 			ViewNum:     0,
 			Status:      article.ArticleStatusDraft,
+			Version:     1,
 			PublishedID: publishedID,
+			PublishAt:   publishAt,
+			UnpublishAt: unpublishAt,
 			CreateTime:  now,
 			UpdateTime:  now,
-			TagID:       tagID,
+			TagIDs:      tagIDs,
 		}
 		if err = a.articleModel.CreateArticleDraft(ctx, draft); err != nil {
 			return nil, err
 		}
 		return &types.AdminSaveArticleResponse{
-			ID:    strconv.FormatUint(newID, 10),
-			Title: title,
+			ID:      strconv.FormatUint(newID, 10),
+			Title:   title,
+			Version: draft.Version,
 		}, nil
 	}
 
@@ -151,6 +181,9 @@ func (a *articleService) AdminSaveArticleDraft(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if request.Version != existingDraft.Version {
+		return nil, a.articleVersionConflict(ctx, draftID, true)
+	}
 	if publishedID == nil {
 		publishedID = existingDraft.PublishedID
 	}
@@ -169,15 +202,22 @@ func (a *articleService) AdminSaveArticleDraft(ctx context.Context,
 		Describe:    strings.TrimSpace(request.Describe),
 		Content:     request.Content,
 		PublishedID: publishedID,
+		PublishAt:   publishAt,
+		UnpublishAt: unpublishAt,
 		UpdateTime:  now,
-		TagID:       tagID,
+		TagIDs:      tagIDs,
+		Version:     request.Version,
 	}
 	if err = a.articleModel.UpdateArticleDraft(ctx, draft); err != nil {
+		if errors.Is(err, article.ErrArticleVersionConflict) {
+			return nil, a.articleVersionConflict(ctx, draftID, true)
+		}
 		return nil, err
 	}
 	return &types.AdminSaveArticleResponse{
-		ID:    strconv.FormatUint(draftID, 10),
-		Title: title,
+		ID:      strconv.FormatUint(draftID, 10),
+		Title:   title,
+		Version: draft.Version,
 	}, nil
 }
 
@@ -191,38 +231,78 @@ func (a *articleService) AdminPublishArticleDraft(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	unpublishAt, err := parseArticleScheduleTime(request.UnpublishAt)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateArticleSchedule(nil, unpublishAt); err != nil {
+		return nil, err
+	}
+
+	return a.publishArticleDraft(ctx, draft, articlePublishInput{
+		title:       strings.TrimSpace(request.Title),
+		tags:        request.Tags,
+		describe:    strings.TrimSpace(request.Describe),
+		content:     request.Content,
+		unpublishAt: unpublishAt,
+	})
+}
+
+// articlePublishInput 发布草稿时写入已发布文章的内容，手动发布取自请求，定时发布取自草稿本身
+type articlePublishInput struct {
+	title       string
+	tags        []string
+	describe    string
+	content     string
+	unpublishAt *time.Time
+}
 
-	tagInfo, err := a.ensureTag(ctx, strings.TrimSpace(request.Tag))
+// publishArticleDraft 草稿发布的唯一路径，手动发布与定时发布共用，
+// 包含缓存、全文索引、历史版本、sitemap 与 CDN 等全部副作用。
+func (a *articleService) publishArticleDraft(ctx context.Context, draft *article.Article,
+	input articlePublishInput) (*types.AdminSaveArticleResponse, error) {
+	draftID := draft.ID
+	tagIDs, tagNames, err := a.ensureTags(ctx, input.tags)
 	if err != nil {
 		return nil, err
 	}
+	if len(tagIDs) == 0 {
+		return nil, fmt.Errorf("article must have at least one tag")
+	}
 	now := articleNow()
-	tagID := tagInfo.ID
 
 	if draft.PublishedID == nil {
 		published := &article.Article{
 			ID:            draft.ID,
-			Title:         strings.TrimSpace(request.Title),
-			Describe:      strings.TrimSpace(request.Describe),
-			Content:       request.Content,
+			Title:         input.title,
+			Describe:      input.describe,
+			Content:       input.content,
 			ViewNum:       0,
 			Status:        article.ArticleStatusPublished,
 			PublishedTime: &now,
+			UnpublishAt:   input.unpublishAt,
 			CreateTime:    now,
 			UpdateTime:    now,
-			TagID:         &tagID,
+			TagIDs:        tagIDs,
 		}
+		applyArticleContent(published)
 		if err = a.articleModel.PublishNewArticleDraft(ctx, published); err != nil {
 			return nil, err
 		}
 		if err = a.syncPublishedArticleImageReferences(ctx, published.ID, published.Content); err != nil {
 			return nil, fmt.Errorf("failed to sync article image references: %w", err)
 		}
-		if err = a.addPublishedArticleCache(ctx, published, tagInfo.Name); err != nil {
+		if err = a.addPublishedArticleCache(ctx, published, tagNames); err != nil {
 			return nil, err
 		}
+		a.indexPublishedArticle(ctx, published, published.CreateTime)
+		a.updateRelatedArticles(ctx, published, tagNames)
+		a.recordArticleRevision(ctx, published.ID, published.Title, published.Describe, published.Content,
+			article.JoinTagNames(tagNames), article.RevisionSourcePublish)
 		articleID := strconv.FormatUint(published.ID, 10)
 		a.sitemap.RefreshArticles(articleID)
+		a.invalidateArticleFeeds(ctx)
+		a.invalidateArticleArchive(ctx)
 		return &types.AdminSaveArticleResponse{ID: articleID}, nil
 	}
 
@@ -235,16 +315,19 @@ func (a *articleService) AdminPublishArticleDraft(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	a.ensureBaselineRevision(ctx, oldArticle)
 	published := &article.Article{
 		ID:            articleID,
-		Title:         strings.TrimSpace(request.Title),
-		Describe:      strings.TrimSpace(request.Describe),
-		Content:       request.Content,
+		Title:         input.title,
+		Describe:      input.describe,
+		Content:       input.content,
 		ViewNum:       uint64(viewNum),
 		PublishedTime: &now,
+		UnpublishAt:   input.unpublishAt,
 		UpdateTime:    now,
-		TagID:         &tagID,
+		TagIDs:        tagIDs,
 	}
+	applyArticleContent(published)
 	if err = a.articleModel.PublishArticleDraftToPublished(ctx, draftID, published); err != nil {
 		return nil, err
 	}
@@ -252,11 +335,20 @@ func (a *articleService) AdminPublishArticleDraft(ctx context.Context,
 		return nil, fmt.Errorf("failed to sync article image references: %w", err)
 	}
 	articleIDString := strconv.FormatUint(articleID, 10)
-	if err = a.invalidateUpdatedArticleCache(ctx, articleIDString, oldArticle.TagName, tagInfo.Name); err != nil {
+	if err = a.invalidateUpdatedArticleCache(ctx, articleIDString, oldArticle.TagNames, tagNames); err != nil {
 		return nil, err
 	}
-	a.sitemap.RefreshArticles(articleIDString)
-	if err = a.cdn.PurgeArticles(articleIDString); err != nil {
+	a.indexPublishedArticle(ctx, published, oldArticle.CreateTime)
+	a.updateRelatedArticles(ctx, published, tagNames)
+	a.recordArticleRevision(ctx, published.ID, published.Title, published.Describe, published.Content,
+		article.JoinTagNames(tagNames), article.RevisionSourcePublish)
+	urlKeys := a.articleURLKeys(ctx, articleID)
+	a.sitemap.RefreshArticles(urlKeys...)
+	a.invalidateArticleFeeds(ctx)
+	a.invalidateArticleArchive(ctx)
+	a.refreshArticleSeries(ctx, articleID)
+	a.invalidateArticlePromotions(ctx)
+	if err = a.cdn.PurgeArticles(urlKeys...); err != nil {
 		a.logger.Error("failed to purge article CDN cache", zap.String("article_id", articleIDString), zap.Error(err))
 		return nil, fmt.Errorf("failed to purge article CDN cache: %w", err)
 	}
@@ -272,6 +364,31 @@ func (a *articleService) AdminDeleteArticleDraft(ctx context.Context,
 	return a.articleModel.DeleteArticleDraftByID(ctx, id)
 }
 
+// ensureTags 按填写顺序查找或创建标签，去除空白与重复项，返回标签 ID 与规范化后的标签名
+func (a *articleService) ensureTags(ctx context.Context, tagNames []string) ([]uint64, []string, error) {
+	ids := make([]uint64, 0, len(tagNames))
+	names := make([]string, 0, len(tagNames))
+	seen := make(map[uint64]struct{}, len(tagNames))
+	for _, tagName := range tagNames {
+		tagName = strings.TrimSpace(tagName)
+		if tagName == "" {
+			continue
+		}
+		tagInfo, err := a.ensureTag(ctx, tagName)
+		if err != nil {
+			return nil, nil, err
+		}
+		// 标签名按库的排序规则比较（大小写不敏感），"Go" 与 "go" 归为同一个标签
+		if _, ok := seen[tagInfo.ID]; ok {
+			continue
+		}
+		seen[tagInfo.ID] = struct{}{}
+		ids = append(ids, tagInfo.ID)
+		names = append(names, tagInfo.Name)
+	}
+	return ids, names, nil
+}
+
 func (a *articleService) ensureTag(ctx context.Context, tagName string) (*tag.Tag, error) {
 	if tagName == "" {
 		return nil, fmt.Errorf("empty tag name")
@@ -298,7 +415,7 @@ func (a *articleService) ensureTag(ctx context.Context, tagName string) (*tag.Ta
 	return tagInfo, nil
 }
 
-func (a *articleService) addPublishedArticleCache(ctx context.Context, articleInfo *article.Article, tagName string) error {
+func (a *articleService) addPublishedArticleCache(ctx context.Context, articleInfo *article.Article, tagNames []string) error {
 	timeMember := []redis.Z{
 		{Score: cachekey.ArticleTimeScore(articleInfo.CreateTime), Member: articleInfo.ID},
 	}
@@ -313,6 +430,29 @@ func (a *articleService) addPublishedArticleCache(ctx context.Context, articleIn
 		return err
 	}
 
+	// 文章计入它的每一个标签；不公开的文章不计数，避免从标签文章数推断出它的存在
+	for _, tagName := range tagNames {
+		if isPublicArticle(articleInfo) {
+			if err := a.increaseTagArticleNum(ctx, tagName); err != nil {
+				return err
+			}
+		}
+
+		if err := a.redis.ZAdd(ctx, cachekey.TagArticleListZSet(tagName).String(), timeMember...).Err(); err != nil {
+			a.logger.Error("failed to add tag article list", zap.Error(err))
+			return err
+		}
+	}
+	// 标签文章数变化，前台标签列表缓存随之失效
+	if err := a.redis.Del(ctx, cachekey.TagListCache().String()).Err(); err != nil {
+		a.logger.Error("failed to delete tag list cache", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// increaseTagArticleNum 标签文章数加一，标签不在 ZSet 中时以 1 写入
+func (a *articleService) increaseTagArticleNum(ctx context.Context, tagName string) error {
 	tagArticleNumKey := cachekey.TagArticleNumZSet().String()
 	err := a.redis.ZScore(ctx, tagArticleNumKey, tagName).Err()
 	switch {
@@ -330,36 +470,61 @@ func (a *articleService) addPublishedArticleCache(ctx context.Context, articleIn
 			return err
 		}
 	}
+	return nil
+}
+
+// removePublishedArticleCache 文章删除或下线后清理其在 Redis 中的全部缓存
+func (a *articleService) removePublishedArticleCache(ctx context.Context, articleID string, tagNames []string) error {
+	// 删除文章的 hash
+	if err := a.redis.Del(ctx, articleCacheKeys(articleID)...).Err(); err != nil {
+		a.logger.Error("failed to delete hash", zap.Error(err))
+		return err
+	}
+
+	// 删除article:time:ZSet里面的成员
+	if err := a.redis.ZRem(ctx, cachekey.ArticleTimeZSet().String(), articleID).Err(); err != nil {
+		a.logger.Error("failed to delete article:time:ZSet", zap.Error(err))
+		return err
+	}
 
-	if err = a.redis.ZAdd(ctx, cachekey.TagArticleListZSet(tagName).String(), timeMember...).Err(); err != nil {
-		a.logger.Error("failed to add tag article list", zap.Error(err))
+	// 删除article:view:ZSet里面的成员
+	if err := a.redis.ZRem(ctx, cachekey.ArticleViewZSet().String(), articleID).Err(); err != nil {
+		a.logger.Error("failed to delete article:view:ZSet", zap.Error(err))
 		return err
 	}
+
+	// 删除tag:articleNum:ZSet整个有序集合
+	if err := a.redis.Del(ctx, cachekey.TagArticleNumZSet().String(), cachekey.TagListCache().String()).Err(); err != nil {
+		a.logger.Error("failed to delete tag:articleNum:ZSet", zap.Error(err))
+		return err
+	}
+
+	// 删除文章所属各标签的 {tagName}:article:ZSet
+	for _, tagName := range tagNames {
+		if err := a.redis.Del(ctx, cachekey.TagArticleListZSet(tagName).String()).Err(); err != nil {
+			a.logger.Error("failed to delete tagIDArticleKey", zap.Error(err))
+			return err
+		}
+	}
 	return nil
 }
 
 func (a *articleService) invalidateUpdatedArticleCache(ctx context.Context,
-	articleID string, oldTagName string, newTagName string) error {
-	if err := a.redis.Del(ctx, cachekey.ArticleHash(articleID).String()).Err(); err != nil {
+	articleID string, oldTagNames []string, newTagNames []string) error {
+	if err := a.redis.Del(ctx, articleCacheKeys(articleID)...).Err(); err != nil {
 		a.logger.Error("failed to delete hash", zap.Error(err))
 		return fmt.Errorf("failed to delete hash: %w", err)
 	}
-	if err := a.redis.Del(ctx, cachekey.TagArticleNumZSet().String()).Err(); err != nil {
+	if err := a.redis.Del(ctx, cachekey.TagArticleNumZSet().String(), cachekey.TagListCache().String()).Err(); err != nil {
 		a.logger.Error("failed to delete tag article count", zap.Error(err))
 		return fmt.Errorf("failed to delete tag article count: %w", err)
 	}
-	if oldTagName != "" {
-		if err := a.redis.Del(ctx, cachekey.TagArticleListZSet(oldTagName).String()).Err(); err != nil {
-			a.logger.Error("failed to delete old tag article list",
-				zap.String("oldTagName", oldTagName), zap.Error(err))
-			return fmt.Errorf("failed to delete old tag article list: %w", err)
-		}
-	}
-	if newTagName != "" && newTagName != oldTagName {
-		if err := a.redis.Del(ctx, cachekey.TagArticleListZSet(newTagName).String()).Err(); err != nil {
-			a.logger.Error("failed to delete new tag article list",
-				zap.String("newTagName", newTagName), zap.Error(err))
-			return fmt.Errorf("failed to delete new tag article list: %w", err)
+	// 新旧标签的并集都需要重建标签下的文章列表
+	for _, tagName := range unionTagNames(oldTagNames, newTagNames) {
+		if err := a.redis.Del(ctx, cachekey.TagArticleListZSet(tagName).String()).Err(); err != nil {
+			a.logger.Error("failed to delete tag article list",
+				zap.String("tagName", tagName), zap.Error(err))
+			return fmt.Errorf("failed to delete tag article list: %w", err)
 		}
 	}
 	return nil
@@ -385,13 +550,6 @@ func articleNow() time.Time {
 	return time.Now().In(loc)
 }
 
-func articleTagIDValue(tagID *uint64) uint64 {
-	if tagID == nil {
-		return 0
-	}
-	return *tagID
-}
-
 func (a *articleService) nextDraftTitle(ctx context.Context) (string, error) {
 	total, err := a.articleModel.CountArticleDrafts(ctx)
 	if err != nil {
@@ -411,3 +569,26 @@ func parseOptionalID(name string, value string) (uint64, error) {
 	}
 	return id, nil
 }
+
+func unionTagNames(lists ...[]string) []string {
+	seen := make(map[string]struct{})
+	union := make([]string, 0)
+	for _, list := range lists {
+		for _, name := range list {
+			if _, ok := seen[name]; ok || name == "" {
+				continue
+			}
+			seen[name] = struct{}{}
+			union = append(union, name)
+		}
+	}
+	return union
+}
+
+// nonNilTagNames 保证 JSON 输出为 [] 而不是 null
+func nonNilTagNames(names []string) []string {
+	if names == nil {
+		return []string{}
+	}
+	return names
+}
@@ -0,0 +1,154 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/idutil"
+	"meta-api/common/types"
+)
+
+const (
+	// articleEditLeaseTTL 编辑器每 30 秒续期一次，连续错过两次后租约自动失效
+	articleEditLeaseTTL = 90 * time.Second
+
+	articleEditLeaseKindDraft = "draft"
+)
+
+// acquireEditLeaseScript 无人持有、本会话持有或强制接管时写入并续期；被其他会话持有时返回 0
+var acquireEditLeaseScript = redis.NewScript(`
+local holder = redis.call("HGET", KEYS[1], "sessionID")
+if holder and holder ~= ARGV[1] and ARGV[3] ~= "1" then
+	return 0
+end
+if holder ~= ARGV[1] then
+	redis.call("DEL", KEYS[1])
+	redis.call("HSET", KEYS[1], "sessionID", ARGV[1], "userID", ARGV[4], "acquiredAt", ARGV[5])
+end
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+return 1
+`)
+
+// renewEditLeaseScript 只有持有者能续期
+var renewEditLeaseScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "sessionID") ~= ARGV[1] then
+	return 0
+end
+redis.call("PEXPIRE", KEYS[1], ARGV[2])
+return 1
+`)
+
+// releaseEditLeaseScript 只有持有者能释放，避免页面关闭时误删已被接管的租约
+var releaseEditLeaseScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], "sessionID") ~= ARGV[1] then
+	return 0
+end
+return redis.call("DEL", KEYS[1])
+`)
+
+// AdminAcquireArticleEditLease 打开编辑器时获取编辑租约。租约只用于提示，不阻止保存，
+// 并发保存由版本号校验兜底
+func (a *articleService) AdminAcquireArticleEditLease(ctx context.Context, userID string,
+	request *types.AdminArticleEditLeaseRequest) (*types.AdminArticleEditLeaseResponse, error) {
+	id, err := idutil.ParseID("articleID", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Kind == articleEditLeaseKindDraft {
+		_, err = a.articleModel.GetArticleDraftByID(ctx, id)
+	} else {
+		_, err = a.articleModel.GetArticleVersion(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	force := "0"
+	if request.Force {
+		force = "1"
+	}
+	key := cachekey.ArticleEditLeaseHash(request.Kind, request.ID).String()
+	held, err := acquireEditLeaseScript.Run(ctx, a.redis, []string{key}, request.SessionID,
+		articleEditLeaseTTL.Milliseconds(), force, userID, articleNow().UnixMilli()).Int()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire article edit lease: %w", err)
+	}
+	return a.articleEditLeaseResponse(ctx, key, held == 1)
+}
+
+// AdminRenewArticleEditLease 编辑器心跳。租约已过期或被其他会话接管时 Held 为 false，
+// 编辑器据此提示，需要继续编辑时重新获取
+func (a *articleService) AdminRenewArticleEditLease(ctx context.Context,
+	request *types.AdminArticleEditLeaseRequest) (*types.AdminArticleEditLeaseResponse, error) {
+	if _, err := idutil.ParseID("articleID", request.ID); err != nil {
+		return nil, err
+	}
+	key := cachekey.ArticleEditLeaseHash(request.Kind, request.ID).String()
+	held, err := renewEditLeaseScript.Run(ctx, a.redis, []string{key}, request.SessionID,
+		articleEditLeaseTTL.Milliseconds()).Int()
+	if err != nil {
+		return nil, fmt.Errorf("failed to renew article edit lease: %w", err)
+	}
+	return a.articleEditLeaseResponse(ctx, key, held == 1)
+}
+
+// AdminReleaseArticleEditLease 关闭编辑器时释放租约，租约不属于该会话时忽略
+func (a *articleService) AdminReleaseArticleEditLease(ctx context.Context,
+	request *types.AdminArticleEditLeaseRequest) error {
+	if _, err := idutil.ParseID("articleID", request.ID); err != nil {
+		return err
+	}
+	key := cachekey.ArticleEditLeaseHash(request.Kind, request.ID).String()
+	if err := releaseEditLeaseScript.Run(ctx, a.redis, []string{key}, request.SessionID).Err(); err != nil {
+		return fmt.Errorf("failed to release article edit lease: %w", err)
+	}
+	return nil
+}
+
+func (a *articleService) articleEditLeaseResponse(ctx context.Context, key string,
+	held bool) (*types.AdminArticleEditLeaseResponse, error) {
+	leases, err := a.articleEditLeases(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	return &types.AdminArticleEditLeaseResponse{Held: held, Lease: leases[0]}, nil
+}
+
+// articleEditLeases 批量读取租约持有者，顺序与 keys 一致，无人持有的位置为 nil
+func (a *articleService) articleEditLeases(ctx context.Context,
+	keys []string) ([]*types.AdminArticleEditLeaseItem, error) {
+	pipe := a.redis.Pipeline()
+	fields := make([]*redis.MapStringStringCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		fields[i] = pipe.HGetAll(ctx, key)
+		ttls[i] = pipe.PTTL(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get article edit leases: %w", err)
+	}
+
+	now := articleNow()
+	leases := make([]*types.AdminArticleEditLeaseItem, len(keys))
+	for i := range keys {
+		values := fields[i].Val()
+		ttl := ttls[i].Val()
+		if values["sessionID"] == "" || ttl <= 0 {
+			continue
+		}
+		acquiredAt, _ := strconv.ParseInt(values["acquiredAt"], 10, 64)
+		leases[i] = &types.AdminArticleEditLeaseItem{
+			SessionID:  values["sessionID"],
+			UserID:     values["userID"],
+			AcquiredAt: time.UnixMilli(acquiredAt).In(now.Location()).Format(constants.TimeLayoutToSecond),
+			ExpireAt:   now.Add(ttl).Format(constants.TimeLayoutToSecond),
+		}
+	}
+	return leases, nil
+}
@@ -0,0 +1,296 @@
+package article
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/app/service/article/feed"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/idutil"
+	"meta-api/common/types"
+)
+
+const (
+	// feedCacheTTL 渲染结果的兜底过期时间，正常情况下由版本号自增失效
+	feedCacheTTL     = 30 * time.Minute
+	defaultFeedLimit = 20
+	// articleFeedPath 订阅源路由，与 registerUserRoutes 中的 /user/feed/:format 一致
+	articleFeedPath = "/user/feed/"
+	maxFeedLimit    = 100
+)
+
+var errFeedTagNotFound = errors.New("feed tag not found")
+
+// UserGetArticleFeed 获取 RSS / Atom / JSON Feed 订阅源。
+//
+// 文章列表来自 article:time:ZSet（或标签下的文章 ZSet），详情来自文章 Hash。
+// 渲染结果按订阅源版本号缓存，文章发布、更新、删除时自增版本号即可整体失效，无需逐个删除各格式/各标签的缓存。
+func (a *articleService) UserGetArticleFeed(ctx context.Context,
+	request *types.UserGetArticleFeedRequest) (*types.UserGetArticleFeedResponse, error) {
+
+	tagName := strings.TrimSpace(request.Tag)
+	cfg := a.config.FeedSnapshot()
+	siteURL := strings.TrimRight(strings.TrimSpace(cfg.SiteURL), "/")
+	if siteURL == "" {
+		// 站点地址未配置（本地开发）时链接按请求 Host 推导，结果随请求头变化，不能写入共享缓存
+		f, err := a.buildArticleFeed(ctx, request.Format, tagName, strings.TrimRight(request.Origin, "/"), request.Origin)
+		if err != nil {
+			return nil, err
+		}
+		return renderArticleFeed(f, request.Format)
+	}
+
+	version, err := a.redis.Get(ctx, cachekey.ArticleFeedVersion().String()).Result()
+	if errors.Is(err, redis.Nil) {
+		version = "0"
+	} else if err != nil {
+		a.logger.Error("failed to get article feed version", zap.Error(err))
+		return nil, err
+	}
+
+	key := cachekey.ArticleFeedHash(version, request.Format, tagName).String()
+	cached, err := a.redis.HGetAll(ctx, key).Result()
+	if err != nil {
+		a.logger.Error("failed to get article feed cache", zap.Error(err))
+		return nil, err
+	}
+	if body, ok := cached["body"]; ok {
+		lastModified, _ := strconv.ParseInt(cached["lastModified"], 10, 64)
+		return &types.UserGetArticleFeedResponse{
+			ContentType:  feed.ContentType(request.Format),
+			Body:         []byte(body),
+			ETag:         cached["etag"],
+			LastModified: time.Unix(lastModified, 0),
+		}, nil
+	}
+
+	apiURL := strings.TrimRight(strings.TrimSpace(cfg.APIURL), "/")
+	if apiURL == "" {
+		apiURL = siteURL
+	}
+	f, err := a.buildArticleFeed(ctx, request.Format, tagName, siteURL, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	response, err := renderArticleFeed(f, request.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := a.redis.TxPipeline()
+	pipe.HSet(ctx, key, map[string]any{
+		"body":         response.Body,
+		"etag":         response.ETag,
+		"lastModified": f.Updated.Unix(),
+	})
+	pipe.Expire(ctx, key, feedCacheTTL)
+	if _, err = pipe.Exec(ctx); err != nil {
+		// 缓存失败不影响本次响应
+		a.logger.Warn("failed to cache article feed", zap.Error(err))
+	}
+	return response, nil
+}
+
+// renderArticleFeed 渲染订阅源并以内容摘要作为 ETag
+func renderArticleFeed(f *feed.Feed, format string) (*types.UserGetArticleFeedResponse, error) {
+	body, err := feed.Render(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render article feed: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return &types.UserGetArticleFeedResponse{
+		ContentType:  feed.ContentType(format),
+		Body:         body,
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: f.Updated,
+	}, nil
+}
+
+// articleFeedURL 订阅源自身的规范地址，只由格式与标签拼成，不带请求中的其他参数
+func articleFeedURL(apiURL string, format string, tagName string) string {
+	feedURL := strings.TrimRight(apiURL, "/") + articleFeedPath + format
+	if tagName != "" {
+		feedURL += "?tag=" + url.QueryEscape(tagName)
+	}
+	return feedURL
+}
+
+// buildArticleFeed siteURL 用于拼接文章链接，apiURL 用于拼接订阅源自身地址
+func (a *articleService) buildArticleFeed(ctx context.Context, format string, tagName string,
+	siteURL string, apiURL string) (*feed.Feed, error) {
+
+	cfg := a.config.FeedSnapshot()
+	limit := cfg.Limit
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+	limit = min(limit, maxFeedLimit)
+
+	articleIDs, err := a.feedArticleIDs(ctx, tagName, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &feed.Feed{
+		Title:       cfg.Title,
+		Description: cfg.Description,
+		Language:    cfg.Language,
+		Author:      cfg.Author,
+		Link:        siteURL + "/",
+		FeedURL:     articleFeedURL(apiURL, format, tagName),
+		Items:       make([]feed.Item, 0, len(articleIDs)),
+	}
+	if tagName != "" {
+		f.Title = cfg.Title + " - " + tagName
+	}
+
+	fields := []string{"title", "describe", "tagName", "createTime", "updateTime"}
+	loc := articleNow().Location()
+	for _, articleID := range articleIDs {
+		result, err := a.redis.HMGet(ctx, cachekey.ArticleHash(articleID).String(), fields...).Result()
+		if err != nil {
+			a.logger.Error("get article info HMGet error", zap.Error(err))
+			return nil, err
+		}
+		values := make([]string, len(fields))
+		complete := true
+		for i, value := range result {
+			s, ok := value.(string)
+			if !ok {
+				complete = false
+				break
+			}
+			values[i] = s
+		}
+		if !complete {
+			if values, err = a.loadArticleHashForFeed(ctx, articleID); err != nil {
+				return nil, err
+			}
+		}
+
+		createTime, err := time.ParseInLocation(constants.TimeLayoutToSecond, values[3], loc)
+		if err != nil {
+			return nil, fmt.Errorf("parse article create time: %w", err)
+		}
+		updateTime, err := time.ParseInLocation(constants.TimeLayoutToSecond, values[4], loc)
+		if err != nil {
+			return nil, fmt.Errorf("parse article update time: %w", err)
+		}
+		f.Items = append(f.Items, feed.Item{
+			ID:         articleID,
+			Title:      values[0],
+			Link:       siteURL + "/article-detail/" + articleID,
+			Summary:    values[1],
+			Categories: article.SplitTagNames(values[2]),
+			Published:  createTime,
+			Updated:    updateTime,
+		})
+		if updateTime.After(f.Updated) {
+			f.Updated = updateTime
+		}
+	}
+	if f.Updated.IsZero() {
+		f.Updated = time.Unix(0, 0).In(loc)
+	}
+	return f, nil
+}
+
+// feedArticleIDs 取最新的 limit 篇公开文章 ID；标签 ZSet 不存在时从 MySQL 回源并写回缓存
+func (a *articleService) feedArticleIDs(ctx context.Context, tagName string, limit int) ([]string, error) {
+	hidden, err := a.hiddenArticleIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 多取不公开文章的篇数，过滤后仍能凑满 limit 篇
+	stop := int64(limit + len(hidden) - 1)
+	if tagName == "" {
+		ids, err := a.redis.ZRevRange(ctx, cachekey.ArticleTimeZSet().String(), 0, stop).Result()
+		if err != nil {
+			a.logger.Error("failed to get article:time:ZSet", zap.Error(err))
+			return nil, err
+		}
+		return filterHiddenArticleIDs(ids, hidden, limit), nil
+	}
+
+	key := cachekey.TagArticleListZSet(tagName).String()
+	ids, err := a.redis.ZRevRange(ctx, key, 0, stop).Result()
+	if err != nil {
+		a.logger.Error("failed to get tag article list", zap.Error(err))
+		return nil, err
+	}
+	if len(ids) > 0 {
+		return filterHiddenArticleIDs(ids, hidden, limit), nil
+	}
+
+	articleList, err := a.articleModel.GetArticleListByTagName(ctx, tagName)
+	if err != nil {
+		a.logger.Error("failed to get article list by tag name", zap.Error(err))
+		return nil, err
+	}
+	if len(articleList) == 0 {
+		return nil, errFeedTagNotFound
+	}
+	members := make([]redis.Z, 0, len(articleList))
+	for _, v := range articleList {
+		members = append(members, redis.Z{Score: cachekey.ArticleTimeScore(v.CreateTime), Member: v.ID})
+	}
+	if err = a.redis.ZAdd(ctx, key, members...).Err(); err != nil {
+		a.logger.Error("failed to write tag article list", zap.Error(err))
+		return nil, err
+	}
+	if ids, err = a.redis.ZRevRange(ctx, key, 0, stop).Result(); err != nil {
+		return nil, err
+	}
+	return filterHiddenArticleIDs(ids, hidden, limit), nil
+}
+
+// loadArticleHashForFeed 文章 Hash 缺失时回源 MySQL 并写回缓存，按 title/describe/tagName/createTime/updateTime 顺序返回
+func (a *articleService) loadArticleHashForFeed(ctx context.Context, articleID string) ([]string, error) {
+	id, err := idutil.ParseID("articleID", articleID)
+	if err != nil {
+		a.logger.Error("invalid article id", zap.Error(err))
+		return nil, err
+	}
+	articleInfo, err := a.articleModel.GetArticleDetailByID(ctx, id)
+	if err != nil {
+		a.logger.Error("get article detail by id error", zap.Error(err))
+		return nil, fmt.Errorf("get article detail by id error, err: %w", err)
+	}
+
+	mapData := articleInfo.HashData()
+	if err = a.redis.HMSet(ctx, cachekey.ArticleHash(articleID).String(), mapData).Err(); err != nil {
+		a.logger.Error("redis set article hash error", zap.Error(err))
+		return nil, fmt.Errorf("redis set article hash error: %w", err)
+	}
+	return []string{
+		articleInfo.Title,
+		articleInfo.Describe,
+		article.JoinTagNames(articleInfo.TagNames),
+		articleInfo.CreateTime.Format(constants.TimeLayoutToSecond),
+		articleInfo.UpdateTime.Format(constants.TimeLayoutToSecond),
+	}, nil
+}
+
+// invalidateArticleFeeds 与 sitemap 刷新走同一批写路径，自增版本号使全部订阅源缓存失效。
+// 失败只记日志：订阅缓存最迟在 feedCacheTTL 后自然过期。
+func (a *articleService) invalidateArticleFeeds(ctx context.Context) {
+	if err := a.redis.Incr(ctx, cachekey.ArticleFeedVersion().String()).Err(); err != nil {
+		a.logger.Error("failed to bump article feed version", zap.Error(err))
+	}
+}
+
+func IsArticleFeedTagNotFoundError(err error) bool {
+	return errors.Is(err, errFeedTagNotFound)
+}
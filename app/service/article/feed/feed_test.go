@@ -0,0 +1,102 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleFeed() *Feed {
+	published := time.Date(2025, 3, 1, 10, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	return &Feed{
+		Title:       "测试博客",
+		Description: "Go & 后端",
+		Language:    "zh-CN",
+		Author:      "bing",
+		Link:        "https://example.com",
+		FeedURL:     "https://example.com/api/user/feed/rss",
+		Updated:     published.Add(time.Hour),
+		Items: []Item{{
+			ID:         "1001",
+			Title:      "Redis <ZSet> 实践",
+			Link:       "https://example.com/article-detail/1001",
+			Summary:    "排行榜 & 时间线",
+			Categories: []string{"Redis", "数据库"},
+			Published:  published,
+			Updated:    published.Add(time.Hour),
+		}},
+	}
+}
+
+func TestRenderRSSIsWellFormed(t *testing.T) {
+	body, err := RenderRSS(sampleFeed())
+	if err != nil {
+		t.Fatalf("render rss: %v", err)
+	}
+	var doc struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title   string `xml:"title"`
+				PubDate string `xml:"pubDate"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err = xml.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("rss is not valid xml: %v\n%s", err, body)
+	}
+	if len(doc.Channel.Items) != 1 || doc.Channel.Items[0].Title != "Redis <ZSet> 实践" {
+		t.Fatalf("unexpected rss items: %+v", doc.Channel.Items)
+	}
+	if doc.Channel.Items[0].PubDate != "Sat, 01 Mar 2025 10:30:00 +0800" {
+		t.Fatalf("unexpected pubDate: %s", doc.Channel.Items[0].PubDate)
+	}
+}
+
+func TestRenderAtomAndJSON(t *testing.T) {
+	atom, err := RenderAtom(sampleFeed())
+	if err != nil {
+		t.Fatalf("render atom: %v", err)
+	}
+	if !strings.Contains(string(atom), `<feed xmlns="http://www.w3.org/2005/Atom"`) ||
+		!strings.Contains(string(atom), `<category term="Redis"></category>`) ||
+		!strings.Contains(string(atom), `<category term="数据库"></category>`) {
+		t.Fatalf("unexpected atom output:\n%s", atom)
+	}
+
+	body, err := RenderJSON(sampleFeed())
+	if err != nil {
+		t.Fatalf("render json: %v", err)
+	}
+	var doc map[string]any
+	if err = json.Unmarshal(body, &doc); err != nil {
+		t.Fatalf("json feed invalid: %v", err)
+	}
+	if doc["version"] != "https://jsonfeed.org/version/1.1" || len(doc["items"].([]any)) != 1 {
+		t.Fatalf("unexpected json feed: %s", body)
+	}
+	if !strings.Contains(string(body), "Redis <ZSet>") {
+		t.Fatalf("expected html characters to stay unescaped: %s", body)
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2025, 3, 1, 2, 30, 15, 500, time.UTC)
+	etag := `"abc"`
+
+	if !NotModified(`"x", W/"abc"`, "", etag, lastModified) {
+		t.Fatalf("expected weak etag match")
+	}
+	if NotModified(`"x"`, lastModified.Format(http.TimeFormat), etag, lastModified) {
+		t.Fatalf("If-None-Match must take precedence over If-Modified-Since")
+	}
+	if !NotModified("", lastModified.Format(http.TimeFormat), etag, lastModified) {
+		t.Fatalf("expected If-Modified-Since match at second precision")
+	}
+	if NotModified("", lastModified.Add(-time.Minute).Format(http.TimeFormat), etag, lastModified) {
+		t.Fatalf("expected modified feed")
+	}
+}
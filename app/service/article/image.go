@@ -17,7 +17,6 @@ import (
 	"meta-api/common/constants"
 	"meta-api/common/idutil"
 	"meta-api/common/types"
-	"meta-api/pkg/cos"
 )
 
 var errArticleImageInUse = errors.New("article image is still referenced")
@@ -167,9 +166,17 @@ func (a *articleService) AdminGetArticleImageDetail(ctx context.Context,
 		})
 	}
 
+	variants, err := a.articleModel.ListArticleImageVariants(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sources, srcSet := articleImageSources(image, variants)
+
 	return &types.AdminGetArticleImageDetailResponse{
 		Image:      articleImageItemFromModel(image, refCount),
 		References: items,
+		Sources:    sources,
+		SrcSet:     srcSet,
 	}, nil
 }
 
@@ -191,16 +198,11 @@ func (a *articleService) AdminDeleteArticleImage(ctx context.Context,
 		return errArticleImageInUse
 	}
 
-	if err = a.imageStore.Delete(ctx, image.ObjectKey); err != nil {
-		if errors.Is(err, cos.ErrDisabled) {
-			return fmt.Errorf("article image storage is not configured: %w", err)
-		}
-		return err
-	}
-	if err = a.articleModel.DeleteArticleImage(ctx, id); err != nil {
+	// 只移入回收站，COS 对象在彻底删除时清理
+	if err = a.articleModel.TrashArticleImage(ctx, id, articleNow()); err != nil {
 		return err
 	}
-	a.logger.Info("article image deleted",
+	a.logger.Info("article image trashed",
 		zap.String("image_id", request.ID),
 		zap.String("object_key", image.ObjectKey),
 		zap.Bool("force", request.Force))
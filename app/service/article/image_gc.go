@@ -0,0 +1,191 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/types"
+)
+
+const (
+	// imageGCContentBatchSize 复核引用时每批读取的文章数
+	imageGCContentBatchSize = 200
+	// imageGCLockTTL 长于定时任务的 10 分钟超时，正常情况下由任务结束时释放
+	imageGCLockTTL = 15 * time.Minute
+)
+
+const (
+	imageGCActionDelete = "delete"
+	imageGCActionKeep   = "keep"
+)
+
+// AdminGetArticleImageGCReport 预览下一次回收会删除哪些图片，不做任何修改
+func (a *articleService) AdminGetArticleImageGCReport(ctx context.Context,
+	request *types.AdminGetArticleImageGCReportRequest) (*types.AdminArticleImageGCReportResponse, error) {
+	return a.collectOrphanedArticleImages(ctx, request.Limit, true)
+}
+
+// CollectOrphanedArticleImages 删除超过保留期仍未被引用的图片及其变体，由定时任务调用
+func (a *articleService) CollectOrphanedArticleImages(ctx context.Context) error {
+	if a.config.ArticleImageGCSnapshot().Disabled {
+		return nil
+	}
+	lockKey := cachekey.ArticleImageGCLock().String()
+	token, err := a.acquireJobLock(ctx, lockKey, imageGCLockTTL)
+	if err != nil {
+		return fmt.Errorf("failed to acquire article image gc lock: %w", err)
+	}
+	if token == "" {
+		return nil
+	}
+	defer a.releaseJobLock(ctx, lockKey, token)
+
+	report, err := a.collectOrphanedArticleImages(ctx, 0, false)
+	if err != nil {
+		return err
+	}
+	if report.Deleted > 0 || report.Kept > 0 {
+		a.logger.Info("orphaned article images collected",
+			zap.Int("deleted", report.Deleted),
+			zap.Int("kept", report.Kept),
+			zap.Int64("reclaimed_bytes", report.ReclaimedBytes))
+	}
+	return nil
+}
+
+// collectOrphanedArticleImages 候选图片来自引用表，删除前再扫描全部文章与草稿内容复核，
+// 避免引用表因历史数据或同步失败而漏记时误删仍在使用的图片
+func (a *articleService) collectOrphanedArticleImages(ctx context.Context, limit int,
+	dryRun bool) (*types.AdminArticleImageGCReportResponse, error) {
+	gcConfig := a.config.ArticleImageGCSnapshot()
+	if limit <= 0 {
+		limit = gcConfig.RunLimit()
+	}
+	gracePeriod := gcConfig.GracePeriod()
+	report := &types.AdminArticleImageGCReportResponse{
+		DryRun:    dryRun,
+		GraceDays: int(gracePeriod / (24 * time.Hour)),
+		Limit:     limit,
+		Rows:      make([]types.AdminArticleImageGCItem, 0),
+	}
+
+	candidates, err := a.articleModel.ListOrphanedArticleImages(ctx, articleNow().Add(-gracePeriod), limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return report, nil
+	}
+	referenced, err := a.referencedArticleImageKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range candidates {
+		image := &candidates[i]
+		variants, err := a.articleModel.ListArticleImageVariants(ctx, image.ID)
+		if err != nil {
+			return nil, err
+		}
+		item := types.AdminArticleImageGCItem{
+			ID:          strconv.FormatUint(image.ID, 10),
+			URL:         image.URL,
+			ImageName:   image.ImageName,
+			Size:        image.Size,
+			Variants:    len(variants),
+			UnusedSince: image.UpdateTime.Format(constants.TimeLayoutToMinute),
+			Action:      imageGCActionDelete,
+		}
+		if isArticleImageReferenced(image, variants, referenced) {
+			item.Action = imageGCActionKeep
+			item.Reason = "referenced by article content"
+			if !dryRun {
+				// 引用表漏记：刷新变动时间，否则它会一直占据队首，后面可删除的图片永远轮不到
+				if err = a.articleModel.MarkArticleImageUsed(ctx, image.ID, articleNow()); err != nil {
+					return nil, err
+				}
+			}
+			report.Kept++
+			report.Rows = append(report.Rows, item)
+			continue
+		}
+
+		if !dryRun {
+			// 扫描期间文章可能刚保存并引用了该图片，删除前以引用表为准再确认一次
+			refCount, err := a.articleModel.CountArticleImageReferences(ctx, image.ID)
+			if err != nil {
+				return nil, err
+			}
+			if refCount > 0 {
+				continue
+			}
+			if err = a.purgeArticleImage(ctx, image); err != nil {
+				return nil, err
+			}
+		}
+		report.Deleted++
+		report.ReclaimedBytes += image.Size
+		for _, variant := range variants {
+			report.ReclaimedBytes += variant.Size
+		}
+		report.Rows = append(report.Rows, item)
+	}
+	return report, nil
+}
+
+// referencedArticleImageKeys 扫描全部文章（含草稿与回收站）及历史版本内容，返回被引用的存储对象 Key。
+// 历史版本随时可能被恢复为草稿，只被历史版本引用的图片同样不能回收
+func (a *articleService) referencedArticleImageKeys(ctx context.Context) (map[string]struct{}, error) {
+	referenced := make(map[string]struct{})
+	if err := a.collectArticleImageKeys(ctx, referenced, a.articleModel.ListArticleContentsAfter); err != nil {
+		return nil, err
+	}
+	if err := a.collectArticleImageKeys(ctx, referenced, a.articleModel.ListArticleRevisionContentsAfter); err != nil {
+		return nil, err
+	}
+	return referenced, nil
+}
+
+// collectArticleImageKeys 按 ID 分批读取内容，把引用到的存储对象 Key 写入 referenced
+func (a *articleService) collectArticleImageKeys(ctx context.Context, referenced map[string]struct{},
+	list func(ctx context.Context, afterID uint64, limit int) ([]article.ArticleContentRecord, error)) error {
+	var afterID uint64
+	for {
+		records, err := list(ctx, afterID, imageGCContentBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			for _, ref := range extractArticleImageRefs(record.Content) {
+				if objectKey, ok := a.imageStore.ObjectKeyFromPublicURL(ref.URL); ok {
+					referenced[objectKey] = struct{}{}
+				}
+			}
+		}
+		if len(records) < imageGCContentBatchSize {
+			return nil
+		}
+		afterID = records[len(records)-1].ID
+	}
+}
+
+// isArticleImageReferenced 原图或任一变体被内容引用都视为仍在使用
+func isArticleImageReferenced(image *article.ArticleImage, variants []article.ArticleImageVariant,
+	referenced map[string]struct{}) bool {
+	if _, ok := referenced[image.ObjectKey]; ok {
+		return true
+	}
+	for _, variant := range variants {
+		if _, ok := referenced[variant.ObjectKey]; ok {
+			return true
+		}
+	}
+	return false
+}
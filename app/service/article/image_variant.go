@@ -0,0 +1,146 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/common/types"
+	"meta-api/pkg/imageproc"
+)
+
+// imageVariantMimes 只对位图生成变体，SVG 与 WebP 原样保存
+var imageVariantMimes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+// uploadArticleImageVariants 生成并上传缩略图与压缩版本，返回待写入的变体记录。
+// 变体只是优化，任一步失败都清理已上传的对象并退回只保存原图
+func (a *articleService) uploadArticleImageVariants(ctx context.Context, imageID uint64, storedName string,
+	imageType articleImageType, content []byte, now time.Time) []article.ArticleImageVariant {
+	variantConfig := a.config.ArticleImageVariantSnapshot()
+	if variantConfig.Disabled || !imageVariantMimes[imageType.mime] {
+		return nil
+	}
+
+	result, err := imageproc.Generate(content, variantConfig.VariantWidths(), variantConfig.JPEGQuality())
+	if err != nil {
+		a.logger.Warn("failed to generate article image variants",
+			zap.String("image_name", storedName), zap.Error(err))
+		return nil
+	}
+
+	baseName := strings.TrimSuffix(storedName, imageType.ext)
+	variants := make([]article.ArticleImageVariant, 0, len(result.Variants))
+	for _, generated := range result.Variants {
+		kind := article.ImageVariantKindResized
+		objectName := baseName + "_w" + strconv.Itoa(generated.Width) + generated.Ext
+		if generated.Optimized {
+			kind = article.ImageVariantKindOptimized
+			objectName = baseName + "_opt" + generated.Ext
+		}
+		publicURL, err := a.imageStore.Upload(ctx, objectName, generated.Content, generated.Mime)
+		if err != nil {
+			a.logger.Warn("failed to upload article image variant",
+				zap.String("object_name", objectName), zap.Error(err))
+			a.deleteArticleImageVariantObjects(ctx, variants)
+			return nil
+		}
+		variants = append(variants, article.ArticleImageVariant{
+			ImageID:    imageID,
+			Kind:       kind,
+			ObjectKey:  a.imageStore.ObjectKey(objectName),
+			URL:        publicURL,
+			Mime:       generated.Mime,
+			Width:      generated.Width,
+			Height:     generated.Height,
+			Size:       int64(len(generated.Content)),
+			CreateTime: now,
+		})
+	}
+
+	for i := range variants {
+		if variants[i].ID, err = a.idGenerator.NextID(); err != nil {
+			a.logger.Warn("failed to generate article image variant id", zap.Error(err))
+			a.deleteArticleImageVariantObjects(ctx, variants)
+			return nil
+		}
+	}
+	return variants
+}
+
+// saveArticleImageVariants 写入变体记录，失败时删除变体对象，返回实际保存的变体
+func (a *articleService) saveArticleImageVariants(ctx context.Context,
+	variants []article.ArticleImageVariant) []article.ArticleImageVariant {
+	if err := a.articleModel.CreateArticleImageVariants(ctx, variants); err != nil {
+		a.logger.Warn("failed to save article image variants", zap.Error(err))
+		a.deleteArticleImageVariantObjects(ctx, variants)
+		return nil
+	}
+	return variants
+}
+
+// deleteArticleImageVariantObjects 尽力删除变体对象，失败只记录日志
+func (a *articleService) deleteArticleImageVariantObjects(ctx context.Context, variants []article.ArticleImageVariant) {
+	for _, variant := range variants {
+		if err := a.imageStore.Delete(ctx, variant.ObjectKey); err != nil {
+			a.logger.Warn("failed to delete article image variant",
+				zap.String("object_key", variant.ObjectKey), zap.Error(err))
+		}
+	}
+}
+
+// purgeArticleImageVariants 彻底删除图片前先删除全部变体对象，任一失败都中止以便下次重试
+func (a *articleService) purgeArticleImageVariants(ctx context.Context, imageID uint64) error {
+	variants, err := a.articleModel.ListArticleImageVariants(ctx, imageID)
+	if err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		if err = a.imageStore.Delete(ctx, variant.ObjectKey); err != nil {
+			return fmt.Errorf("delete article image variant %s: %w", variant.ObjectKey, err)
+		}
+	}
+	return nil
+}
+
+// articleImageSources 组装 srcset 候选：缩略图加原尺寸版本（有压缩版本时优先使用），按宽度升序
+func articleImageSources(image *article.ArticleImage,
+	variants []article.ArticleImageVariant) ([]types.AdminArticleImageSourceItem, string) {
+	sources := make([]types.AdminArticleImageSourceItem, 0, len(variants)+1)
+	var full *types.AdminArticleImageSourceItem
+	if image.Width > 0 {
+		full = &types.AdminArticleImageSourceItem{URL: image.URL, Width: image.Width, Height: image.Height,
+			Mime: image.Mime, Size: image.Size}
+	}
+	for _, variant := range variants {
+		item := types.AdminArticleImageSourceItem{
+			URL:    variant.URL,
+			Width:  variant.Width,
+			Height: variant.Height,
+			Mime:   variant.Mime,
+			Size:   variant.Size,
+		}
+		if variant.Kind == article.ImageVariantKindOptimized {
+			full = &item
+			continue
+		}
+		sources = append(sources, item)
+	}
+	if full != nil {
+		sources = append(sources, *full)
+	}
+
+	candidates := make([]string, 0, len(sources))
+	for _, source := range sources {
+		candidates = append(candidates, source.URL+" "+strconv.Itoa(source.Width)+"w")
+	}
+	return sources, strings.Join(candidates, ", ")
+}
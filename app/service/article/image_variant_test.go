@@ -0,0 +1,30 @@
+package article
+
+import (
+	"testing"
+
+	"meta-api/app/model/article"
+)
+
+func TestArticleImageSources(t *testing.T) {
+	image := &article.ArticleImage{URL: "https://cdn/a.png", Width: 1600, Height: 800, Mime: "image/png"}
+	variants := []article.ArticleImageVariant{
+		{Kind: article.ImageVariantKindResized, URL: "https://cdn/a_w320.jpg", Width: 320, Height: 160},
+		{Kind: article.ImageVariantKindResized, URL: "https://cdn/a_w640.jpg", Width: 640, Height: 320},
+		{Kind: article.ImageVariantKindOptimized, URL: "https://cdn/a_opt.jpg", Width: 1600, Height: 800},
+	}
+
+	sources, srcSet := articleImageSources(image, variants)
+	if len(sources) != 3 || sources[2].URL != "https://cdn/a_opt.jpg" {
+		t.Fatalf("optimized version should replace the original, got %+v", sources)
+	}
+	want := "https://cdn/a_w320.jpg 320w, https://cdn/a_w640.jpg 640w, https://cdn/a_opt.jpg 1600w"
+	if srcSet != want {
+		t.Fatalf("srcset = %q, want %q", srcSet, want)
+	}
+
+	// 历史图片没有记录尺寸，也没有变体
+	if sources, srcSet = articleImageSources(&article.ArticleImage{URL: "https://cdn/b.svg"}, nil); len(sources) != 0 || srcSet != "" {
+		t.Fatalf("expected no sources for legacy image, got %+v %q", sources, srcSet)
+	}
+}
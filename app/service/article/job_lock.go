@@ -0,0 +1,49 @@
+package article
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// releaseJobLockScript 只有持有者能释放：任务执行超过锁的 TTL 后，锁可能已被其他实例重新获取
+var releaseJobLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+return redis.call("DEL", KEYS[1])
+`)
+
+// acquireJobLock 多实例定时任务的分布式锁，锁的值为随机 token，返回空串表示锁已被其他实例持有
+func (a *articleService) acquireJobLock(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate job lock token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+	locked, err := a.redis.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return "", err
+	}
+	if !locked {
+		return "", nil
+	}
+	return token, nil
+}
+
+// releaseJobLock 比较 token 后删除锁，失败只记日志，锁最迟在 TTL 后自然过期
+func (a *articleService) releaseJobLock(ctx context.Context, key string, token string) {
+	released, err := releaseJobLockScript.Run(context.WithoutCancel(ctx), a.redis, []string{key}, token).Int()
+	if err != nil {
+		a.logger.Warn("failed to release job lock", zap.String("key", key), zap.Error(err))
+		return
+	}
+	if released == 0 {
+		a.logger.Warn("job lock expired before release", zap.String("key", key))
+	}
+}
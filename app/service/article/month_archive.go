@@ -0,0 +1,191 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/readcache"
+	"meta-api/common/types"
+)
+
+// 按月归档读穿缓存：文章发布、更新、删除时自增版本号整体失效，TTL 只兜底
+const (
+	articleArchiveCacheTTL      = 30 * time.Minute
+	articleArchiveCacheStaleTTL = 5 * time.Minute
+	articleArchiveCacheJitter   = 0.1
+
+	articleArchiveMonthsPeriod = "months"
+	articleArchiveMonthLayout  = "2006-01"
+)
+
+var errArticleArchiveMonthOutOfRange = errors.New("article archive month out of range")
+
+func newArticleArchiveCache[T any](store readcache.Store, logger *zap.Logger) *readcache.Cache[T] {
+	return readcache.New[T](store, readcache.Options{
+		TTL:      articleArchiveCacheTTL,
+		StaleTTL: articleArchiveCacheStaleTTL,
+		Jitter:   articleArchiveCacheJitter,
+		OnError: func(key string, err error) {
+			logger.Warn("article archive cache degraded", zap.String("key", key), zap.Error(err))
+		},
+	})
+}
+
+// UserGetArticleArchive 公开文章按创建年月分组的计数，归档页据此渲染目录，再按月分页加载文章
+func (a *articleService) UserGetArticleArchive(ctx context.Context) (*types.UserGetArticleArchiveResponse, error) {
+	version, err := a.articleArchiveVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := cachekey.ArticleArchiveString(version, articleArchiveMonthsPeriod).String()
+	response, err := a.archiveMonthsCache.Get(ctx, key,
+		func(ctx context.Context) (types.UserGetArticleArchiveResponse, error) {
+			rows, err := a.articleModel.CountArchiveMonths(ctx)
+			if err != nil {
+				a.logger.Error("failed to count archive months", zap.Error(err))
+				return types.UserGetArticleArchiveResponse{}, err
+			}
+			return groupArchiveMonths(rows), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// UserGetArticleArchiveMonth 某月的公开文章分页。整月列表作为一个缓存项，分页在内存中完成
+func (a *articleService) UserGetArticleArchiveMonth(ctx context.Context,
+	request *types.UserGetArticleArchiveMonthRequest) (*types.UserGetArticleArchiveMonthResponse, error) {
+	// 年月来自匿名请求：先按归档目录校验，超出 [首篇文章所在月, 当前月] 的直接拒绝，
+	// 范围内但没有文章的月份直接返回空列表，都不创建缓存项
+	archive, err := a.UserGetArticleArchive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !archiveMonthInRange(archive, request.Year, request.Month, articleNow()) {
+		return nil, errArticleArchiveMonthOutOfRange
+	}
+	if archiveMonthCount(archive, request.Year, request.Month) == 0 {
+		return &types.UserGetArticleArchiveMonthResponse{
+			Year:  request.Year,
+			Month: request.Month,
+			Rows:  make([]types.UserArchiveArticleItem, 0),
+		}, nil
+	}
+
+	version, err := a.articleArchiveVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Date(request.Year, time.Month(request.Month), 1, 0, 0, 0, 0, articleNow().Location())
+	key := cachekey.ArticleArchiveString(version, start.Format(articleArchiveMonthLayout)).String()
+	items, err := a.archiveListCache.Get(ctx, key, func(ctx context.Context) ([]types.UserArchiveArticleItem, error) {
+		rows, err := a.articleModel.ListArchiveArticles(ctx, start, start.AddDate(0, 1, 0))
+		if err != nil {
+			a.logger.Error("failed to list archive articles", zap.Error(err))
+			return nil, err
+		}
+		items := make([]types.UserArchiveArticleItem, 0, len(rows))
+		for _, row := range rows {
+			items = append(items, types.UserArchiveArticleItem{
+				ID:         strconv.FormatUint(row.ID, 10),
+				Title:      row.Title,
+				Slug:       row.Slug,
+				CreateTime: row.CreateTime.Format(constants.TimeLayoutToMinute),
+			})
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	offset := min((request.Page-1)*request.PageSize, len(items))
+	end := min(offset+request.PageSize, len(items))
+	return &types.UserGetArticleArchiveMonthResponse{
+		Year:  request.Year,
+		Month: request.Month,
+		Rows:  items[offset:end],
+		Total: len(items),
+	}, nil
+}
+
+// archiveMonthInRange 年月是否落在 [最早有公开文章的月份, now 所在月份] 内，没有任何文章时一律不在范围内
+func archiveMonthInRange(archive *types.UserGetArticleArchiveResponse, year int, month int, now time.Time) bool {
+	if len(archive.Years) == 0 {
+		return false
+	}
+	oldest := archive.Years[len(archive.Years)-1]
+	if len(oldest.Months) == 0 {
+		return false
+	}
+	period := year*12 + month
+	first := oldest.Year*12 + oldest.Months[len(oldest.Months)-1].Month
+	current := now.Year()*12 + int(now.Month())
+	return period >= first && period <= current
+}
+
+// archiveMonthCount 归档目录中某月的公开文章数
+func archiveMonthCount(archive *types.UserGetArticleArchiveResponse, year int, month int) int64 {
+	for _, yearItem := range archive.Years {
+		if yearItem.Year != year {
+			continue
+		}
+		for _, monthItem := range yearItem.Months {
+			if monthItem.Month == month {
+				return monthItem.Count
+			}
+		}
+	}
+	return 0
+}
+
+// groupArchiveMonths 把按年月倒序的计数整理为年 → 月两级结构
+func groupArchiveMonths(rows []article.ArchiveMonthCount) types.UserGetArticleArchiveResponse {
+	response := types.UserGetArticleArchiveResponse{Years: make([]types.UserArchiveYearItem, 0)}
+	for _, row := range rows {
+		if len(response.Years) == 0 || response.Years[len(response.Years)-1].Year != row.Year {
+			response.Years = append(response.Years, types.UserArchiveYearItem{
+				Year:   row.Year,
+				Months: make([]types.UserArchiveMonthItem, 0, 12),
+			})
+		}
+		year := &response.Years[len(response.Years)-1]
+		year.Months = append(year.Months, types.UserArchiveMonthItem{Month: row.Month, Count: row.Count})
+		year.Count += row.Count
+		response.Total += row.Count
+	}
+	return response
+}
+
+func (a *articleService) articleArchiveVersion(ctx context.Context) (string, error) {
+	version, err := a.redis.Get(ctx, cachekey.ArticleArchiveVersion().String()).Result()
+	if errors.Is(err, redis.Nil) {
+		return "0", nil
+	}
+	if err != nil {
+		a.logger.Error("failed to get article archive version", zap.Error(err))
+		return "", err
+	}
+	return version, nil
+}
+
+// invalidateArticleArchive 与订阅源一起在发布、更新、删除、可见性变更时调用，自增版本号使归档缓存整体失效。
+// 失败只记日志：归档缓存最迟在 articleArchiveCacheTTL 后自然过期
+func (a *articleService) invalidateArticleArchive(ctx context.Context) {
+	if err := a.redis.Incr(ctx, cachekey.ArticleArchiveVersion().String()).Err(); err != nil {
+		a.logger.Error("failed to bump article archive version", zap.Error(err))
+	}
+}
+
+func IsArticleArchiveMonthOutOfRangeError(err error) bool {
+	return errors.Is(err, errArticleArchiveMonthOutOfRange)
+}
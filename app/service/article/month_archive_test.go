@@ -0,0 +1,62 @@
+package article
+
+import (
+	"testing"
+	"time"
+
+	"meta-api/app/model/article"
+	"meta-api/common/types"
+)
+
+func TestGroupArchiveMonths(t *testing.T) {
+	response := groupArchiveMonths([]article.ArchiveMonthCount{
+		{Year: 2024, Month: 5, Count: 2},
+		{Year: 2024, Month: 1, Count: 3},
+		{Year: 2023, Month: 12, Count: 1},
+	})
+	if response.Total != 6 || len(response.Years) != 2 {
+		t.Fatalf("unexpected archive: %+v", response)
+	}
+	if year := response.Years[0]; year.Year != 2024 || year.Count != 5 || len(year.Months) != 2 || year.Months[1].Month != 1 {
+		t.Fatalf("unexpected 2024 group: %+v", year)
+	}
+	if year := response.Years[1]; year.Year != 2023 || year.Count != 1 || year.Months[0].Count != 1 {
+		t.Fatalf("unexpected 2023 group: %+v", year)
+	}
+	if empty := groupArchiveMonths(nil); empty.Years == nil || empty.Total != 0 {
+		t.Fatalf("empty archive should have non-nil years: %+v", empty)
+	}
+}
+
+func TestArchiveMonthInRange(t *testing.T) {
+	archive := groupArchiveMonths([]article.ArchiveMonthCount{
+		{Year: 2024, Month: 5, Count: 2},
+		{Year: 2023, Month: 12, Count: 1},
+	})
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		year, month int
+		want        bool
+	}{
+		{2023, 11, false},
+		{2023, 12, true},
+		{2024, 3, true},
+		{2024, 6, true},
+		{2024, 7, false},
+		{9999, 12, false},
+	}
+	for _, tc := range tests {
+		if got := archiveMonthInRange(&archive, tc.year, tc.month, now); got != tc.want {
+			t.Fatalf("%d-%02d: expected %v, got %v", tc.year, tc.month, tc.want, got)
+		}
+	}
+	if archiveMonthInRange(&types.UserGetArticleArchiveResponse{}, 2024, 6, now) {
+		t.Fatalf("empty archive should reject every month")
+	}
+	if count := archiveMonthCount(&archive, 2024, 3); count != 0 {
+		t.Fatalf("expected empty month, got %d", count)
+	}
+	if count := archiveMonthCount(&archive, 2024, 5); count != 2 {
+		t.Fatalf("expected 2 articles in 2024-05, got %d", count)
+	}
+}
@@ -0,0 +1,199 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/idutil"
+	"meta-api/common/types"
+)
+
+// promotionCacheTTL 推荐位缓存兜底过期时间，正常情况下由写路径主动删除
+const promotionCacheTTL = 30 * time.Minute
+
+// 各推荐位的文章数上限
+var promotionLimits = map[string]int{
+	article.PromotionPinned:   5,
+	article.PromotionFeatured: 10,
+}
+
+var errPromotionLimitExceeded = errors.New("too many promoted articles")
+
+// AdminGetArticlePromotions 管理员获取置顶或精选文章（包含已下线的文章）
+func (a *articleService) AdminGetArticlePromotions(ctx context.Context,
+	request *types.AdminGetArticlePromotionRequest) (*types.AdminGetArticlePromotionResponse, error) {
+	records, err := a.articleModel.ListArticlePromotions(ctx, request.Kind, false)
+	if err != nil {
+		a.logger.Error("failed to list article promotions", zap.String("kind", request.Kind), zap.Error(err))
+		return nil, err
+	}
+	rows := make([]types.AdminArticlePromotionItem, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, types.AdminArticlePromotionItem{
+			ID:         strconv.FormatUint(record.ArticleID, 10),
+			Title:      record.Title,
+			Status:     record.Status,
+			CreateTime: record.CreateTime.Format(constants.TimeLayoutToMinute),
+		})
+	}
+	return &types.AdminGetArticlePromotionResponse{Kind: request.Kind, Rows: rows, Total: len(rows)}, nil
+}
+
+// AdminUpdateArticlePromotions 按给定顺序整体替换置顶或精选文章
+func (a *articleService) AdminUpdateArticlePromotions(ctx context.Context,
+	request *types.AdminUpdateArticlePromotionRequest) error {
+	if len(request.ArticleIDs) > promotionLimits[request.Kind] {
+		return fmt.Errorf("%w: %s at most %d", errPromotionLimitExceeded, request.Kind, promotionLimits[request.Kind])
+	}
+	articleIDs, err := parsePromotionArticleIDs(request.ArticleIDs)
+	if err != nil {
+		return err
+	}
+	if err = a.articleModel.ReplaceArticlePromotions(ctx, request.Kind, articleIDs, articleNow()); err != nil {
+		a.logger.Error("failed to replace article promotions", zap.String("kind", request.Kind), zap.Error(err))
+		return err
+	}
+	key := cachekey.ArticlePromotionList(request.Kind).String()
+	if err = a.redis.Del(ctx, key).Err(); err != nil {
+		a.logger.Error("failed to delete article promotion cache", zap.String("key", key), zap.Error(err))
+		return fmt.Errorf("failed to delete article promotion cache: %w", err)
+	}
+	return nil
+}
+
+// UserGetFeaturedArticle 前台精选文章轮播，按后台设置的顺序返回
+func (a *articleService) UserGetFeaturedArticle(ctx context.Context) (*types.UserGetFeaturedArticleResponse, error) {
+	rows, err := a.promotedArticles(ctx, article.PromotionFeatured)
+	if err != nil {
+		return nil, err
+	}
+	return &types.UserGetFeaturedArticleResponse{Rows: rows, Total: len(rows)}, nil
+}
+
+// promotedArticles 读取推荐位整包缓存，未命中时查库回填。缓存在推荐位变更以及文章标题、Slug 等变化后主动删除，
+// 文章下线、移入回收站或改为不公开后由 filterPublishedPromotions 在读取时过滤
+func (a *articleService) promotedArticles(ctx context.Context, kind string) ([]types.UserFeaturedArticleItem, error) {
+	key := cachekey.ArticlePromotionList(kind).String()
+	value, err := a.redis.Get(ctx, key).Result()
+	if err == nil {
+		rows := make([]types.UserFeaturedArticleItem, 0)
+		if err = sonic.Unmarshal([]byte(value), &rows); err == nil {
+			return a.filterPublishedPromotions(ctx, rows)
+		}
+		a.logger.Warn("failed to unmarshal article promotion cache", zap.String("kind", kind), zap.Error(err))
+	} else if !errors.Is(err, redis.Nil) {
+		a.logger.Warn("failed to get article promotion from redis", zap.String("kind", kind), zap.Error(err))
+	}
+
+	records, err := a.articleModel.ListArticlePromotions(ctx, kind, true)
+	if err != nil {
+		a.logger.Error("failed to list article promotions", zap.String("kind", kind), zap.Error(err))
+		return nil, err
+	}
+	rows := make([]types.UserFeaturedArticleItem, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, types.UserFeaturedArticleItem{
+			ID:         strconv.FormatUint(record.ArticleID, 10),
+			Slug:       record.Slug,
+			Title:      record.Title,
+			Describe:   record.Describe,
+			CreateTime: record.CreateTime.Format(constants.TimeLayoutToDay),
+		})
+	}
+	if data, err := sonic.Marshal(rows); err != nil {
+		a.logger.Warn("failed to marshal article promotion cache", zap.Error(err))
+	} else if err = a.redis.Set(ctx, key, data, promotionCacheTTL).Err(); err != nil {
+		a.logger.Warn("failed to set article promotion cache", zap.Error(err))
+	}
+	return a.filterPublishedPromotions(ctx, rows)
+}
+
+// invalidateArticlePromotions 文章标题、描述或 Slug 可能变化后删除全部推荐位缓存。
+// 失败只记日志：缓存最迟在 promotionCacheTTL 后自然过期
+func (a *articleService) invalidateArticlePromotions(ctx context.Context) {
+	keys := make([]string, 0, len(promotionLimits))
+	for kind := range promotionLimits {
+		keys = append(keys, cachekey.ArticlePromotionList(kind).String())
+	}
+	if err := a.redis.Del(ctx, keys...).Err(); err != nil {
+		a.logger.Error("failed to delete article promotion cache", zap.Error(err))
+	}
+}
+
+// filterPublishedPromotions 去掉已不在时间有序集合中的文章（已下线或已删除）以及不公开的文章
+func (a *articleService) filterPublishedPromotions(ctx context.Context,
+	rows []types.UserFeaturedArticleItem) ([]types.UserFeaturedArticleItem, error) {
+	if len(rows) == 0 {
+		return rows, nil
+	}
+	hidden, err := a.hiddenArticleIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	// 不存在的成员 score 为 0，已发布文章的 score 为毫秒时间戳
+	scores, err := a.redis.ZMScore(ctx, cachekey.ArticleTimeZSet().String(), ids...).Result()
+	if err != nil {
+		a.logger.Error("failed to check published promotions", zap.Error(err))
+		return nil, fmt.Errorf("failed to check published promotions: %w", err)
+	}
+	published := make([]types.UserFeaturedArticleItem, 0, len(rows))
+	for i, row := range rows {
+		if _, ok := hidden[row.ID]; !ok && scores[i] > 0 {
+			published = append(published, row)
+		}
+	}
+	return published, nil
+}
+
+// pinnedArticleIDs 仍在发布中的公开置顶文章 ID，按置顶顺序排列
+func (a *articleService) pinnedArticleIDs(ctx context.Context) ([]string, error) {
+	rows, err := a.promotedArticles(ctx, article.PromotionPinned)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	return ids, nil
+}
+
+// parsePromotionArticleIDs 解析推荐位文章 ID，保持顺序并拒绝重复
+func parsePromotionArticleIDs(rawIDs []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(rawIDs))
+	seen := make(map[uint64]struct{}, len(rawIDs))
+	for _, rawID := range rawIDs {
+		id, err := idutil.ParseID("articleID", rawID)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[id]; ok {
+			return nil, fmt.Errorf("duplicated promotion article id: %s", rawID)
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func IsPromotionLimitExceededError(err error) bool {
+	return errors.Is(err, errPromotionLimitExceeded)
+}
+
+func IsPromotionArticleNotPublishedError(err error) bool {
+	return errors.Is(err, article.ErrPromotionArticleNotPublished)
+}
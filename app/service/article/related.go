@@ -0,0 +1,281 @@
+package article
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/app/service/article/related"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/idutil"
+	"meta-api/common/readcache"
+	"meta-api/common/types"
+)
+
+const (
+	// relatedArticleTopK 每篇文章在 Redis 中保留的相似文章数
+	relatedArticleTopK = 10
+	// relatedArticleDefaultLimit 接口默认返回条数
+	relatedArticleDefaultLimit = 5
+	// relatedArticleMaxLimit 接口允许的最大条数，与 UserGetRelatedArticleRequest.Limit 的校验一致
+	relatedArticleMaxLimit = 10
+	// relatedTagCacheTTL 同标签回退结果的缓存时间，标签调整后最迟在此时间后生效
+	relatedTagCacheTTL      = 10 * time.Minute
+	relatedTagCacheStaleTTL = 5 * time.Minute
+
+	relatedSourceContent = "content"
+	relatedSourceTag     = "tag"
+)
+
+// UserGetRelatedArticle 相关文章：优先取内容相似的 top-K，不足 limit 篇时用共享标签的文章补齐
+func (a *articleService) UserGetRelatedArticle(ctx context.Context,
+	request *types.UserGetRelatedArticleRequest) (*types.UserGetRelatedArticleResponse, error) {
+	id, err := idutil.ParseID("articleID", request.ID)
+	if err != nil {
+		a.logger.Error("invalid article id", zap.Error(err))
+		return nil, err
+	}
+	limit := request.Limit
+	if limit <= 0 {
+		limit = relatedArticleDefaultLimit
+	}
+
+	members, err := a.redis.ZRevRange(ctx, cachekey.ArticleRelatedZSet(request.ID).String(), 0, relatedArticleTopK-1).Result()
+	if err != nil {
+		a.logger.Error("failed to get article related ZSet", zap.Error(err))
+		return nil, fmt.Errorf("failed to get article related ZSet: %w", err)
+	}
+	ids := make([]uint64, 0, len(members))
+	seen := map[uint64]struct{}{id: {}}
+	for _, member := range members {
+		relatedID, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, exists := seen[relatedID]; exists {
+			continue
+		}
+		seen[relatedID] = struct{}{}
+		ids = append(ids, relatedID)
+	}
+	rows := make([]types.UserRelatedArticleItem, 0, limit)
+	// ZSet 中可能残留刚下线的文章，按 MySQL 中仍公开的文章计数
+	if rows, err = a.appendRelatedRows(ctx, rows, ids, relatedSourceContent, limit); err != nil {
+		return nil, err
+	}
+	if len(rows) == limit {
+		return &types.UserGetRelatedArticleResponse{Rows: rows, Total: len(rows)}, nil
+	}
+
+	// 内容相似的文章不足时才回退到同标签文章，回退结果按文章缓存，避免每次请求都执行 GROUP BY 查询
+	sameTag, err := a.relatedTagCache.Get(ctx, cachekey.ArticleRelatedTagCache(request.ID).String(),
+		func(ctx context.Context) ([]uint64, error) {
+			return a.articleModel.ListSameTagArticleIDs(ctx, id, relatedArticleMaxLimit+relatedArticleTopK)
+		})
+	if err != nil {
+		a.logger.Error("failed to list same tag articles", zap.Error(err))
+		return nil, err
+	}
+	tagIDs := make([]uint64, 0, len(sameTag))
+	for _, relatedID := range sameTag {
+		if _, exists := seen[relatedID]; exists {
+			continue
+		}
+		seen[relatedID] = struct{}{}
+		tagIDs = append(tagIDs, relatedID)
+	}
+	if rows, err = a.appendRelatedRows(ctx, rows, tagIDs, relatedSourceTag, limit); err != nil {
+		return nil, err
+	}
+	return &types.UserGetRelatedArticleResponse{Rows: rows, Total: len(rows)}, nil
+}
+
+// appendRelatedRows 按 ids 的顺序追加仍公开的文章，最多补到 limit 篇
+func (a *articleService) appendRelatedRows(ctx context.Context, rows []types.UserRelatedArticleItem,
+	ids []uint64, source string, limit int) ([]types.UserRelatedArticleItem, error) {
+	if len(ids) == 0 || len(rows) >= limit {
+		return rows, nil
+	}
+	records, err := a.articleModel.ListRelatedArticles(ctx, ids)
+	if err != nil {
+		a.logger.Error("failed to list related articles", zap.Error(err))
+		return nil, err
+	}
+	recordMap := make(map[uint64]article.RelatedArticleRecord, len(records))
+	for _, record := range records {
+		recordMap[record.ID] = record
+	}
+	for _, relatedID := range ids {
+		record, ok := recordMap[relatedID]
+		if !ok {
+			continue
+		}
+		rows = append(rows, types.UserRelatedArticleItem{
+			ID:         strconv.FormatUint(record.ID, 10),
+			Title:      record.Title,
+			Describe:   record.Describe,
+			CreateTime: record.CreateTime.Format(constants.TimeLayoutToDay),
+			ViewNum:    int(record.ViewNum),
+			Source:     source,
+		})
+		if len(rows) == limit {
+			break
+		}
+	}
+	return rows, nil
+}
+
+// newRelatedTagCache 同标签回退结果的读穿缓存；回源时已过滤下线文章，读取时仍按 MySQL 复核可见性
+func newRelatedTagCache(store readcache.Store, logger *zap.Logger) *readcache.Cache[[]uint64] {
+	return readcache.New[[]uint64](store, readcache.Options{
+		TTL:      relatedTagCacheTTL,
+		StaleTTL: relatedTagCacheStaleTTL,
+		OnError: func(key string, err error) {
+			logger.Warn("related tag cache degraded", zap.String("key", key), zap.Error(err))
+		},
+	})
+}
+
+// RebuildRelatedArticles 启动时按 MySQL 全量重算指纹与每篇文章的相似列表
+func (a *articleService) RebuildRelatedArticles(ctx context.Context) error {
+	list, err := a.articleModel.ListSearchDocuments(ctx)
+	if err != nil {
+		a.logger.Error("failed to list related documents", zap.Error(err))
+		return err
+	}
+	ids := make([]uint64, 0, len(list))
+	for _, item := range list {
+		ids = append(ids, item.ID)
+	}
+	tagNames, err := a.articleModel.ListArticleTagNames(ctx, ids)
+	if err != nil {
+		a.logger.Error("failed to list article tag names", zap.Error(err))
+		return err
+	}
+
+	fingerprints := make(map[uint64]uint64, len(list))
+	for _, item := range list {
+		plainText := item.PlainText
+		if plainText == "" {
+			plainText = processArticleContent(item.Content).PlainText
+		}
+		fingerprints[item.ID] = related.Fingerprint(item.Title, tagNames[item.ID], plainText)
+	}
+
+	pipe := a.redis.TxPipeline()
+	fingerprintKey := cachekey.ArticleFingerprintHash().String()
+	pipe.Del(ctx, fingerprintKey)
+	for id, fingerprint := range fingerprints {
+		articleID := strconv.FormatUint(id, 10)
+		pipe.HSet(ctx, fingerprintKey, articleID, strconv.FormatUint(fingerprint, 10))
+		relatedKey := cachekey.ArticleRelatedZSet(articleID).String()
+		pipe.Del(ctx, relatedKey)
+		if members := relatedMembers(related.Nearest(id, fingerprint, fingerprints, relatedArticleTopK)); len(members) > 0 {
+			pipe.ZAdd(ctx, relatedKey, members...)
+		}
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		a.logger.Error("failed to rebuild related articles", zap.Error(err))
+		return fmt.Errorf("failed to rebuild related articles: %w", err)
+	}
+
+	a.logger.Info("related articles rebuilt", zap.Int("total", len(fingerprints)))
+	return nil
+}
+
+// updateRelatedArticles 文章发布或更新后重算其指纹：
+// 整体替换自身的相似列表，并把它写入（或移出）其他文章的相似列表，只保留 top-K。
+// 失败只记录日志，不影响发布流程，下次启动时会全量重建。
+func (a *articleService) updateRelatedArticles(ctx context.Context, articleInfo *article.Article, tagNames []string) {
+	articleID := strconv.FormatUint(articleInfo.ID, 10)
+	fingerprint := related.Fingerprint(articleInfo.Title, tagNames, articleInfo.PlainText)
+	fingerprints, err := a.loadArticleFingerprints(ctx)
+	if err != nil {
+		a.logger.Error("failed to load article fingerprints", zap.String("articleID", articleID), zap.Error(err))
+		return
+	}
+	fingerprints[articleInfo.ID] = fingerprint
+
+	pipe := a.redis.TxPipeline()
+	pipe.HSet(ctx, cachekey.ArticleFingerprintHash().String(), articleID, strconv.FormatUint(fingerprint, 10))
+	relatedKey := cachekey.ArticleRelatedZSet(articleID).String()
+	// 标签可能已调整，同标签回退结果一并失效
+	pipe.Del(ctx, relatedKey, cachekey.ArticleRelatedTagCache(articleID).String())
+	if members := relatedMembers(related.Nearest(articleInfo.ID, fingerprint, fingerprints, relatedArticleTopK)); len(members) > 0 {
+		pipe.ZAdd(ctx, relatedKey, members...)
+	}
+	for otherID, other := range fingerprints {
+		if otherID == articleInfo.ID {
+			continue
+		}
+		otherKey := cachekey.ArticleRelatedZSet(strconv.FormatUint(otherID, 10)).String()
+		similarity := related.Similarity(fingerprint, other)
+		if similarity < related.MinSimilarity {
+			pipe.ZRem(ctx, otherKey, articleID)
+			continue
+		}
+		pipe.ZAdd(ctx, otherKey, redis.Z{Score: float64(similarity), Member: articleID})
+		pipe.ZRemRangeByRank(ctx, otherKey, 0, -relatedArticleTopK-1)
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		a.logger.Error("failed to update related articles", zap.String("articleID", articleID), zap.Error(err))
+	}
+}
+
+// removeRelatedArticles 文章删除或下线后移除其指纹，并从其他文章的相似列表中摘除
+func (a *articleService) removeRelatedArticles(ctx context.Context, id uint64) {
+	articleID := strconv.FormatUint(id, 10)
+	fingerprints, err := a.loadArticleFingerprints(ctx)
+	if err != nil {
+		a.logger.Error("failed to load article fingerprints", zap.String("articleID", articleID), zap.Error(err))
+		return
+	}
+	pipe := a.redis.TxPipeline()
+	pipe.HDel(ctx, cachekey.ArticleFingerprintHash().String(), articleID)
+	pipe.Del(ctx, cachekey.ArticleRelatedZSet(articleID).String())
+	for otherID := range fingerprints {
+		if otherID != id {
+			pipe.ZRem(ctx, cachekey.ArticleRelatedZSet(strconv.FormatUint(otherID, 10)).String(), articleID)
+		}
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		a.logger.Error("failed to remove related articles", zap.String("articleID", articleID), zap.Error(err))
+	}
+}
+
+func (a *articleService) loadArticleFingerprints(ctx context.Context) (map[uint64]uint64, error) {
+	values, err := a.redis.HGetAll(ctx, cachekey.ArticleFingerprintHash().String()).Result()
+	if err != nil {
+		return nil, err
+	}
+	fingerprints := make(map[uint64]uint64, len(values))
+	for field, value := range values {
+		id, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			continue
+		}
+		fingerprint, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		fingerprints[id] = fingerprint
+	}
+	return fingerprints, nil
+}
+
+func relatedMembers(neighbours []related.Neighbour) []redis.Z {
+	members := make([]redis.Z, 0, len(neighbours))
+	for _, neighbour := range neighbours {
+		members = append(members, redis.Z{
+			Score:  float64(neighbour.Similarity),
+			Member: strconv.FormatUint(neighbour.ID, 10),
+		})
+	}
+	return members
+}
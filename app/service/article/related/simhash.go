@@ -0,0 +1,105 @@
+package related
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sort"
+	"unicode/utf8"
+
+	"meta-api/app/service/article/search"
+)
+
+const (
+	// MinSimilarity 两篇文章指纹相同位数的下限（共 64 位）。
+	// 无关文本的指纹约有一半位相同（32），低于下限的不视为相关。
+	MinSimilarity = 40
+
+	titleWeight = 3.0
+	tagWeight   = 8.0
+)
+
+// Neighbour 相关文章及其相似度（指纹相同的位数）
+type Neighbour struct {
+	ID         uint64
+	Similarity int
+}
+
+// Fingerprint 对文章计算 64 位 SimHash 指纹：
+//   - 特征取自 search 分词器，中文只用二元组，单字噪声太大；
+//   - 正文词频按 1+log(tf) 衰减，避免长文中高频词主导指纹；
+//   - 标题词与标签额外加权，标签作为整体特征参与，同标签文章更容易相近。
+func Fingerprint(title string, tagNames []string, body string) uint64 {
+	weights := make(map[string]float64)
+	addTermFrequency(weights, body, 1)
+	addTermFrequency(weights, title, titleWeight)
+	for _, name := range tagNames {
+		weights["tag:"+name] += tagWeight
+	}
+	if len(weights) == 0 {
+		return 0
+	}
+
+	vector := [64]float64{}
+	for feature, weight := range weights {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(feature))
+		sum := hash.Sum64()
+		for bit := range 64 {
+			if sum&(uint64(1)<<bit) != 0 {
+				vector[bit] += weight
+			} else {
+				vector[bit] -= weight
+			}
+		}
+	}
+	var result uint64
+	for bit, weight := range vector {
+		if weight >= 0 {
+			result |= uint64(1) << bit
+		}
+	}
+	return result
+}
+
+// Similarity 两个指纹相同的位数，0~64，越大越相似
+func Similarity(left, right uint64) int {
+	return 64 - bits.OnesCount64(left^right)
+}
+
+// Nearest 从 candidates（文章 ID -> 指纹）中找出与 fingerprint 最相似的 k 篇文章，
+// 跳过 id 自身与低于 MinSimilarity 的文章；相似度相同时新文章（ID 更大）优先。
+func Nearest(id uint64, fingerprint uint64, candidates map[uint64]uint64, k int) []Neighbour {
+	neighbours := make([]Neighbour, 0, k)
+	for candidateID, candidate := range candidates {
+		if candidateID == id {
+			continue
+		}
+		if similarity := Similarity(fingerprint, candidate); similarity >= MinSimilarity {
+			neighbours = append(neighbours, Neighbour{ID: candidateID, Similarity: similarity})
+		}
+	}
+	sort.Slice(neighbours, func(i, j int) bool {
+		if neighbours[i].Similarity != neighbours[j].Similarity {
+			return neighbours[i].Similarity > neighbours[j].Similarity
+		}
+		return neighbours[i].ID > neighbours[j].ID
+	})
+	if len(neighbours) > k {
+		neighbours = neighbours[:k]
+	}
+	return neighbours
+}
+
+func addTermFrequency(weights map[string]float64, text string, factor float64) {
+	counts := make(map[string]int)
+	for _, token := range search.Tokenize(text) {
+		if utf8.RuneCountInString(token.Term) < 2 {
+			continue
+		}
+		counts[token.Term]++
+	}
+	for term, count := range counts {
+		weights[term] += factor * (1 + math.Log(float64(count)))
+	}
+}
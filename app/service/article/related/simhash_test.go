@@ -0,0 +1,51 @@
+package related
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFingerprintSimilarArticlesAreCloser(t *testing.T) {
+	redisA := Fingerprint("Redis 缓存穿透与缓存雪崩", []string{"Redis"},
+		strings.Repeat("缓存穿透指查询不存在的数据，请求直接打到数据库。可以使用布隆过滤器或缓存空值。", 5))
+	redisB := Fingerprint("Redis 缓存击穿的解决方案", []string{"Redis"},
+		strings.Repeat("缓存击穿指热点数据过期，大量请求同时打到数据库。可以使用互斥锁或逻辑过期。", 5))
+	golang := Fingerprint("Go 语言并发模型", []string{"Go"},
+		strings.Repeat("goroutine 由运行时调度，channel 用于在协程之间传递消息，select 处理多路复用。", 5))
+
+	if Similarity(redisA, redisB) <= Similarity(redisA, golang) {
+		t.Fatalf("expected redis articles to be closer: redis=%d go=%d",
+			Similarity(redisA, redisB), Similarity(redisA, golang))
+	}
+	if Similarity(redisA, redisA) != 64 {
+		t.Fatal("identical fingerprints should be fully similar")
+	}
+	if Fingerprint("", nil, "") != 0 {
+		t.Fatal("empty article should have zero fingerprint")
+	}
+}
+
+func TestNearestFiltersAndOrders(t *testing.T) {
+	base := uint64(0)
+	candidates := map[uint64]uint64{
+		1: base,          // 自身
+		2: 0b1,           // 63
+		3: 0b11,          // 62
+		4: 0b1,           // 63，ID 更大优先
+		5: ^uint64(0),    // 0，不相关
+		6: (1 << 24) - 1, // 40，刚好达到下限
+	}
+	got := Nearest(1, base, candidates, 3)
+	want := []Neighbour{{ID: 4, Similarity: 63}, {ID: 2, Similarity: 63}, {ID: 3, Similarity: 62}}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected neighbours: %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected neighbours: %+v", got)
+		}
+	}
+	if all := Nearest(1, base, candidates, 10); len(all) != 4 || all[3].ID != 6 {
+		t.Fatalf("unexpected neighbours without limit: %+v", all)
+	}
+}
@@ -0,0 +1,244 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"meta-api/app/model/article"
+	"meta-api/common/constants"
+	"meta-api/common/idutil"
+	"meta-api/common/types"
+)
+
+// recordArticleRevision 在发布/更新成功后记录一份快照。
+// 快照失败只记日志，不回滚已经成功的文章写入。
+func (a *articleService) recordArticleRevision(ctx context.Context, articleID uint64,
+	title, describe, content, tagName, source string) {
+	id, err := a.idGenerator.NextID()
+	if err != nil {
+		a.logger.Error("generate article revision id error", zap.Error(err))
+		return
+	}
+	if err = a.articleModel.CreateArticleRevision(ctx, &article.ArticleRevision{
+		ID:         id,
+		ArticleID:  articleID,
+		Title:      title,
+		Describe:   describe,
+		Content:    content,
+		TagName:    tagName,
+		Source:     source,
+		CreateTime: articleNow(),
+	}); err != nil {
+		a.logger.Error("failed to record article revision",
+			zap.Uint64("articleID", articleID), zap.String("source", source), zap.Error(err))
+	}
+}
+
+// ensureBaselineRevision 覆盖文章前，若该文章还没有任何历史版本（功能上线前发布的文章），
+// 先把当前线上内容补录为 baseline，保证第一次修改也能回滚。
+func (a *articleService) ensureBaselineRevision(ctx context.Context, current *article.Detail) {
+	total, err := a.articleModel.CountArticleRevisions(ctx, current.ID)
+	if err != nil {
+		a.logger.Error("failed to count article revisions", zap.Uint64("articleID", current.ID), zap.Error(err))
+		return
+	}
+	if total > 0 {
+		return
+	}
+	a.recordArticleRevision(ctx, current.ID, current.Title, current.Describe, current.Content,
+		article.JoinTagNames(current.TagNames), article.RevisionSourceBaseline)
+}
+
+func (a *articleService) AdminGetArticleRevisionList(ctx context.Context,
+	request *types.AdminGetArticleRevisionListRequest) (*types.AdminGetArticleRevisionListResponse, error) {
+	articleID, err := idutil.ParseID("articleID", request.ArticleID)
+	if err != nil {
+		return nil, err
+	}
+	offset := (request.Page - 1) * request.PageSize
+	records, total, err := a.articleModel.ListArticleRevisions(ctx, articleID, offset, request.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]types.AdminArticleRevisionItem, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, types.AdminArticleRevisionItem{
+			ID:         strconv.FormatUint(record.ID, 10),
+			ArticleID:  strconv.FormatUint(record.ArticleID, 10),
+			Title:      record.Title,
+			Tags:       article.SplitTagNames(record.TagName),
+			Source:     record.Source,
+			CreateTime: record.CreateTime.Format(constants.TimeLayoutToSecond),
+		})
+	}
+	return &types.AdminGetArticleRevisionListResponse{
+		Rows:  rows,
+		Total: int(total),
+	}, nil
+}
+
+func (a *articleService) AdminGetArticleRevisionDiff(ctx context.Context,
+	request *types.AdminGetArticleRevisionDiffRequest) (*types.AdminGetArticleRevisionDiffResponse, error) {
+	fromID, err := idutil.ParseID("fromRevisionID", request.FromID)
+	if err != nil {
+		return nil, err
+	}
+	toID, err := idutil.ParseID("toRevisionID", request.ToID)
+	if err != nil {
+		return nil, err
+	}
+	from, err := a.articleModel.GetArticleRevisionByID(ctx, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := a.articleModel.GetArticleRevisionByID(ctx, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]types.AdminArticleRevisionFieldChange, 0, 3)
+	for _, field := range []struct {
+		name     string
+		from, to string
+	}{
+		{name: "title", from: from.Title, to: to.Title},
+		{name: "describe", from: from.Describe, to: to.Describe},
+		{name: "tags", from: from.TagName, to: to.TagName},
+	} {
+		if field.from != field.to {
+			fields = append(fields, types.AdminArticleRevisionFieldChange{
+				Field: field.name,
+				From:  field.from,
+				To:    field.to,
+			})
+		}
+	}
+
+	diff := diffTextLines(from.Content, to.Content)
+	hunks := make([]types.AdminArticleRevisionDiffHunk, 0, len(diff.hunks))
+	for _, hunk := range diff.hunks {
+		lines := make([]types.AdminArticleRevisionDiffLine, 0, len(hunk.lines))
+		for _, line := range hunk.lines {
+			lines = append(lines, types.AdminArticleRevisionDiffLine{
+				Type:    line.kind,
+				OldLine: line.oldLine,
+				NewLine: line.newLine,
+				Text:    line.text,
+			})
+		}
+		hunks = append(hunks, types.AdminArticleRevisionDiffHunk{
+			OldStart: hunk.oldStart,
+			OldLines: hunk.oldLines,
+			NewStart: hunk.newStart,
+			NewLines: hunk.newLines,
+			Lines:    lines,
+		})
+	}
+
+	return &types.AdminGetArticleRevisionDiffResponse{
+		From:      revisionItem(from),
+		To:        revisionItem(to),
+		Fields:    fields,
+		Hunks:     hunks,
+		Added:     diff.added,
+		Removed:   diff.removed,
+		Truncated: diff.truncated,
+	}, nil
+}
+
+// AdminRestoreArticleRevision 把历史版本恢复为草稿，由编辑确认后再走正常发布流程。
+// 文章仍存在时写入（或复用）该文章的编辑草稿，复用时按 DraftVersion 校验，避免覆盖其他页面正在编辑的内容；
+// 文章已被删除时恢复为一篇新草稿。
+func (a *articleService) AdminRestoreArticleRevision(ctx context.Context,
+	request *types.AdminRestoreArticleRevisionRequest) (*types.AdminSaveArticleResponse, error) {
+	revisionID, err := idutil.ParseID("articleRevisionID", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := a.articleModel.GetArticleRevisionByID(ctx, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var publishedID *uint64
+	if _, err = a.articleModel.GetArticleDetailByID(ctx, revision.ArticleID); err == nil {
+		publishedID = &revision.ArticleID
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	tagIDs, _, err := a.ensureTags(ctx, article.SplitTagNames(revision.TagName))
+	if err != nil {
+		return nil, err
+	}
+
+	now := articleNow()
+	if publishedID != nil {
+		existing, err := a.articleModel.FindArticleDraftByPublishedID(ctx, *publishedID)
+		if err == nil {
+			if request.DraftVersion != existing.Version {
+				return nil, a.articleVersionConflict(ctx, existing.ID, true)
+			}
+			draft := &article.Article{
+				ID:          existing.ID,
+				Title:       revision.Title,
+				Describe:    revision.Describe,
+				Content:     revision.Content,
+				PublishedID: publishedID,
+				UpdateTime:  now,
+				TagIDs:      tagIDs,
+				Version:     request.DraftVersion,
+			}
+			if err = a.articleModel.UpdateArticleDraft(ctx, draft); err != nil {
+				if errors.Is(err, article.ErrArticleVersionConflict) {
+					return nil, a.articleVersionConflict(ctx, existing.ID, true)
+				}
+				return nil, err
+			}
+			return &types.AdminSaveArticleResponse{ID: strconv.FormatUint(existing.ID, 10), Title: revision.Title,
+				Version: draft.Version}, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	draftID, err := a.idGenerator.NextID()
+	if err != nil {
+		return nil, fmt.Errorf("generate article draft id: %w", err)
+	}
+	if err = a.articleModel.CreateArticleDraft(ctx, &article.Article{
+		ID:          draftID,
+		Title:       revision.Title,
+		Describe:    revision.Describe,
+		Content:     revision.Content,
+		PublishedID: publishedID,
+		CreateTime:  now,
+		UpdateTime:  now,
+		TagIDs:      tagIDs,
+	}); err != nil {
+		return nil, err
+	}
+	return &types.AdminSaveArticleResponse{ID: strconv.FormatUint(draftID, 10), Title: revision.Title}, nil
+}
+
+func revisionItem(revision *article.ArticleRevision) types.AdminArticleRevisionItem {
+	return types.AdminArticleRevisionItem{
+		ID:         strconv.FormatUint(revision.ID, 10),
+		ArticleID:  strconv.FormatUint(revision.ArticleID, 10),
+		Title:      revision.Title,
+		Tags:       article.SplitTagNames(revision.TagName),
+		Source:     revision.Source,
+		CreateTime: revision.CreateTime.Format(constants.TimeLayoutToSecond),
+	}
+}
+
+func IsArticleRevisionNotFoundError(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,244 @@
+package article
+
+import "strings"
+
+const (
+	diffLineEqual  = "equal"
+	diffLineInsert = "insert"
+	diffLineDelete = "delete"
+
+	// diffContextLines 每个 hunk 前后保留的上下文行数
+	diffContextLines = 3
+	// diffMaxEdits 编辑距离上限，超出后退化为整体替换，避免超大改动占用过多内存
+	diffMaxEdits = 2000
+)
+
+type diffLine struct {
+	kind    string
+	oldLine int // 从 1 开始，插入行为 0
+	newLine int // 从 1 开始，删除行为 0
+	text    string
+}
+
+type diffHunk struct {
+	oldStart int
+	oldLines int
+	newStart int
+	newLines int
+	lines    []diffLine
+}
+
+type lineDiff struct {
+	hunks     []diffHunk
+	added     int
+	removed   int
+	truncated bool // 超出编辑距离上限，结果为整体替换
+}
+
+// diffTextLines 按行比较两段文本，输出带上下文的 unified 风格 hunk
+func diffTextLines(oldText, newText string) lineDiff {
+	oldLines := splitDiffLines(oldText)
+	newLines := splitDiffLines(newText)
+
+	ops, ok := myersDiff(oldLines, newLines, diffMaxEdits)
+	result := lineDiff{truncated: !ok}
+	if !ok {
+		ops = replaceAllDiff(oldLines, newLines)
+	}
+	for _, op := range ops {
+		switch op.kind {
+		case diffLineInsert:
+			result.added++
+		case diffLineDelete:
+			result.removed++
+		}
+	}
+	result.hunks = buildDiffHunks(ops, diffContextLines)
+	return result
+}
+
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// myersDiff 经典 Myers O(ND) 算法。先裁掉公共前后缀缩小问题规模，
+// trace 只保存每一轮 [-d-1, d+1] 的对角线窗口，内存为 O(D²)。
+func myersDiff(a, b []string, maxEdits int) ([]diffLine, bool) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffLine, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, diffLine{kind: diffLineEqual, oldLine: i + 1, newLine: i + 1, text: a[i]})
+	}
+
+	middle, ok := myersMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix, maxEdits)
+	if !ok {
+		return nil, false
+	}
+	ops = append(ops, middle...)
+
+	for i := 0; i < suffix; i++ {
+		oldIndex := len(a) - suffix + i
+		newIndex := len(b) - suffix + i
+		ops = append(ops, diffLine{kind: diffLineEqual, oldLine: oldIndex + 1, newLine: newIndex + 1, text: a[oldIndex]})
+	}
+	return ops, true
+}
+
+type diffTrace struct {
+	low int
+	v   []int
+}
+
+func (t diffTrace) at(k int) int {
+	return t.v[k-t.low]
+}
+
+func myersMiddle(a, b []string, base int, maxEdits int) ([]diffLine, bool) {
+	n, m := len(a), len(b)
+	limit := n + m
+	if maxEdits > 0 && limit > maxEdits {
+		limit = maxEdits
+	}
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	trace := make([]diffTrace, 0)
+
+	found := false
+	for d := 0; d <= limit && !found; d++ {
+		trace = append(trace, diffTrace{
+			low: -d - 1,
+			v:   append([]int(nil), v[offset-d-1:offset+d+2]...),
+		})
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	reversed := make([]diffLine, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		snapshot := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && snapshot.at(k-1) < snapshot.at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := snapshot.at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, diffLine{kind: diffLineEqual,
+				oldLine: base + x, newLine: base + y, text: a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, diffLine{kind: diffLineInsert, newLine: base + y, text: b[y-1]})
+			} else {
+				reversed = append(reversed, diffLine{kind: diffLineDelete, oldLine: base + x, text: a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+	return reversed, true
+}
+
+func replaceAllDiff(a, b []string) []diffLine {
+	ops := make([]diffLine, 0, len(a)+len(b))
+	for i, line := range a {
+		ops = append(ops, diffLine{kind: diffLineDelete, oldLine: i + 1, text: line})
+	}
+	for i, line := range b {
+		ops = append(ops, diffLine{kind: diffLineInsert, newLine: i + 1, text: line})
+	}
+	return ops
+}
+
+// buildDiffHunks 把编辑序列切成 hunk，相邻改动间隔不超过 2*context 行时合并
+func buildDiffHunks(ops []diffLine, context int) []diffHunk {
+	hunks := make([]diffHunk, 0)
+	i := 0
+	for i < len(ops) {
+		for i < len(ops) && ops[i].kind == diffLineEqual {
+			i++
+		}
+		if i >= len(ops) {
+			break
+		}
+
+		start := max(i-context, 0)
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != diffLineEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == diffLineEqual {
+				run++
+			}
+			if run >= len(ops) || run-end > 2*context {
+				end = min(end+context, len(ops))
+				break
+			}
+			end = run
+		}
+
+		hunk := diffHunk{lines: append([]diffLine(nil), ops[start:end]...)}
+		for _, line := range hunk.lines {
+			if line.kind != diffLineInsert {
+				if hunk.oldStart == 0 {
+					hunk.oldStart = line.oldLine
+				}
+				hunk.oldLines++
+			}
+			if line.kind != diffLineDelete {
+				if hunk.newStart == 0 {
+					hunk.newStart = line.newLine
+				}
+				hunk.newLines++
+			}
+		}
+		hunks = append(hunks, hunk)
+		i = end
+	}
+	return hunks
+}
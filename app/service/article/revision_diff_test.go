@@ -0,0 +1,64 @@
+package article
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffTextLinesProducesMinimalEdits(t *testing.T) {
+	oldText := "# 标题\n第一段\n第二段\n第三段\n"
+	newText := "# 标题\n第一段\n第二段（修订）\n第三段\n新增结尾\n"
+
+	diff := diffTextLines(oldText, newText)
+	if diff.truncated {
+		t.Fatalf("unexpected truncated diff")
+	}
+	if diff.added != 2 || diff.removed != 1 {
+		t.Fatalf("expected +2 -1, got +%d -%d", diff.added, diff.removed)
+	}
+	if len(diff.hunks) != 1 {
+		t.Fatalf("expected a single merged hunk, got %d", len(diff.hunks))
+	}
+
+	var rendered []string
+	for _, line := range diff.hunks[0].lines {
+		rendered = append(rendered, line.kind[:1]+line.text)
+	}
+	want := []string{"e# 标题", "e第一段", "d第二段", "i第二段（修订）", "e第三段", "i新增结尾"}
+	if strings.Join(rendered, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected diff lines:\n got %v\nwant %v", rendered, want)
+	}
+	hunk := diff.hunks[0]
+	if hunk.oldStart != 1 || hunk.oldLines != 4 || hunk.newStart != 1 || hunk.newLines != 5 {
+		t.Fatalf("unexpected hunk header: %+v", hunk)
+	}
+}
+
+func TestDiffTextLinesSplitsDistantChanges(t *testing.T) {
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", i+1)
+	}
+	oldText := strings.Join(lines, "\n")
+	changed := append([]string(nil), lines...)
+	changed[1] = "first change"
+	changed[18] = "second change"
+
+	diff := diffTextLines(oldText, strings.Join(changed, "\n"))
+	if len(diff.hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d", len(diff.hunks))
+	}
+	if diff.hunks[1].oldStart != 16 || diff.hunks[1].newStart != 16 {
+		t.Fatalf("unexpected second hunk start: %+v", diff.hunks[1])
+	}
+}
+
+func TestDiffTextLinesFallsBackWhenTooManyEdits(t *testing.T) {
+	ops, ok := myersDiff([]string{"a", "b", "c"}, []string{"x", "y", "z"}, 2)
+	if ok || ops != nil {
+		t.Fatalf("expected edit limit to abort diff")
+	}
+	if diffTextLines("", "one\ntwo").added != 2 {
+		t.Fatalf("expected pure insertion diff")
+	}
+}
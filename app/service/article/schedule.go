@@ -0,0 +1,167 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+)
+
+const (
+	// scheduleBatchSize 单次 cron 最多处理的定时任务数，剩余的留给下一轮
+	scheduleBatchSize = 20
+	scheduleLockTTL   = 50 * time.Second
+)
+
+var errInvalidArticleSchedule = errors.New("invalid article schedule")
+
+// RunScheduledPublishing 执行到期的定时发布与定时下线。
+// 多实例部署时通过 Redis 锁保证同一时刻只有一个实例在处理。
+func (a *articleService) RunScheduledPublishing(ctx context.Context) error {
+	lockKey := cachekey.ArticleScheduleLock().String()
+	token, err := a.acquireJobLock(ctx, lockKey, scheduleLockTTL)
+	if err != nil {
+		return fmt.Errorf("failed to acquire article schedule lock: %w", err)
+	}
+	if token == "" {
+		return nil
+	}
+	defer a.releaseJobLock(ctx, lockKey, token)
+
+	now := articleNow()
+	drafts, err := a.articleModel.ListDueScheduledDrafts(ctx, now, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range drafts {
+		a.publishScheduledDraft(ctx, &drafts[i])
+	}
+
+	due, err := a.articleModel.ListDueUnpublishArticles(ctx, now, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, item := range due {
+		if err = a.unpublishArticle(ctx, item.ID); err != nil {
+			a.logger.Error("scheduled unpublish article failed", zap.Uint64("articleID", item.ID), zap.Error(err))
+			continue
+		}
+		a.logger.Info("article unpublished by schedule", zap.Uint64("articleID", item.ID))
+	}
+	return nil
+}
+
+func (a *articleService) publishScheduledDraft(ctx context.Context, draft *article.Article) {
+	detail, err := a.articleModel.GetArticleDraftDetailByID(ctx, draft.ID)
+	if err != nil {
+		a.logger.Error("failed to load scheduled draft", zap.Uint64("draftID", draft.ID), zap.Error(err))
+		return
+	}
+
+	input := articlePublishInput{
+		title:       strings.TrimSpace(detail.Title),
+		tags:        detail.TagNames,
+		describe:    strings.TrimSpace(detail.Describe),
+		content:     detail.Content,
+		unpublishAt: detail.UnpublishAt,
+	}
+	if input.title == "" || len(input.tags) == 0 || input.describe == "" || strings.TrimSpace(input.content) == "" {
+		// 内容不完整的草稿无法发布：清掉定时，避免每分钟重复失败，编辑可在草稿列表中看到已不再排期
+		a.logger.Error("scheduled draft is incomplete, schedule cleared", zap.Uint64("draftID", draft.ID))
+		if err = a.articleModel.ClearArticleDraftSchedule(ctx, draft.ID); err != nil {
+			a.logger.Error("failed to clear draft schedule", zap.Uint64("draftID", draft.ID), zap.Error(err))
+		}
+		return
+	}
+
+	response, err := a.publishArticleDraft(ctx, draft, input)
+	if err != nil {
+		a.logger.Error("scheduled publish draft failed", zap.Uint64("draftID", draft.ID), zap.Error(err))
+		return
+	}
+	a.logger.Info("article draft published by schedule",
+		zap.Uint64("draftID", draft.ID), zap.String("articleID", response.ID))
+}
+
+// unpublishArticle 将已发布文章下线为草稿，副作用与删除文章一致，但保留内容
+func (a *articleService) unpublishArticle(ctx context.Context, id uint64) error {
+	articleID := strconv.FormatUint(id, 10)
+	detail, err := a.articleModel.GetArticleDetailByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get article detail: %w", err)
+	}
+
+	urlKeys := a.articleURLKeys(ctx, id)
+	if err = a.cdn.PurgeArticles(urlKeys...); err != nil {
+		return fmt.Errorf("failed to purge article CDN cache: %w", err)
+	}
+	if err = a.syncPublishedArticleImageReferences(ctx, id, ""); err != nil {
+		return fmt.Errorf("failed to clear article image references: %w", err)
+	}
+	if err = a.articleModel.UnpublishArticle(ctx, id, articleNow()); err != nil {
+		return err
+	}
+	a.searchIndex.Remove(id)
+	a.removeRelatedArticles(ctx, id)
+	if err = a.removePublishedArticleCache(ctx, articleID, detail.TagNames); err != nil {
+		return err
+	}
+	a.removeArticleSlugCache(ctx, detail.Slug)
+	a.sitemap.RefreshArticles(urlKeys...)
+	a.invalidateArticleFeeds(ctx)
+	a.invalidateArticleArchive(ctx)
+	// 下线的文章从系列导航中隐去
+	a.refreshArticleSeries(ctx, id)
+	a.invalidateArticlePromotions(ctx)
+	return nil
+}
+
+// parseArticleScheduleTime 解析 "2006-01-02 15:04" 格式的定时时间，空串表示不定时
+func parseArticleScheduleTime(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(constants.TimeLayoutToMinute, value, articleNow().Location())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidArticleSchedule, err)
+	}
+	return &t, nil
+}
+
+// validateArticleSchedule 定时时间必须晚于当前时间，且下线时间晚于发布时间
+func validateArticleSchedule(publishAt *time.Time, unpublishAt *time.Time) error {
+	now := articleNow()
+	if publishAt != nil && !publishAt.After(now) {
+		return fmt.Errorf("%w: publish time must be in the future", errInvalidArticleSchedule)
+	}
+	if unpublishAt == nil {
+		return nil
+	}
+	if !unpublishAt.After(now) {
+		return fmt.Errorf("%w: unpublish time must be in the future", errInvalidArticleSchedule)
+	}
+	if publishAt != nil && !unpublishAt.After(*publishAt) {
+		return fmt.Errorf("%w: unpublish time must be after publish time", errInvalidArticleSchedule)
+	}
+	return nil
+}
+
+func formatArticleScheduleTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(articleNow().Location()).Format(constants.TimeLayoutToMinute)
+}
+
+func IsArticleScheduleInvalidError(err error) bool {
+	return errors.Is(err, errInvalidArticleSchedule)
+}
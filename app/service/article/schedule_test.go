@@ -0,0 +1,52 @@
+package article
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseArticleScheduleTime(t *testing.T) {
+	if got, err := parseArticleScheduleTime("  "); err != nil || got != nil {
+		t.Fatalf("expected empty schedule, got %v %v", got, err)
+	}
+	got, err := parseArticleScheduleTime("2030-05-01 08:30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Location().String() != "Asia/Shanghai" || got.Hour() != 8 || got.Minute() != 30 {
+		t.Fatalf("unexpected schedule time: %v", got)
+	}
+	if _, err = parseArticleScheduleTime("2030/05/01"); !IsArticleScheduleInvalidError(err) {
+		t.Fatalf("expected invalid schedule error, got %v", err)
+	}
+}
+
+func TestValidateArticleSchedule(t *testing.T) {
+	now := articleNow()
+	past := now.Add(-time.Minute)
+	soon := now.Add(time.Hour)
+	later := now.Add(2 * time.Hour)
+
+	cases := []struct {
+		name        string
+		publishAt   *time.Time
+		unpublishAt *time.Time
+		valid       bool
+	}{
+		{name: "none", valid: true},
+		{name: "publish only", publishAt: &soon, valid: true},
+		{name: "publish in past", publishAt: &past},
+		{name: "unpublish in past", unpublishAt: &past},
+		{name: "unpublish after publish", publishAt: &soon, unpublishAt: &later, valid: true},
+		{name: "unpublish before publish", publishAt: &later, unpublishAt: &soon},
+	}
+	for _, tc := range cases {
+		err := validateArticleSchedule(tc.publishAt, tc.unpublishAt)
+		if tc.valid && err != nil {
+			t.Fatalf("%s: unexpected error %v", tc.name, err)
+		}
+		if !tc.valid && !IsArticleScheduleInvalidError(err) {
+			t.Fatalf("%s: expected invalid schedule error, got %v", tc.name, err)
+		}
+	}
+}
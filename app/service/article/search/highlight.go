@@ -0,0 +1,125 @@
+package search
+
+import (
+	"html"
+	"sort"
+	"strings"
+)
+
+const (
+	markOpen  = "<mark>"
+	markClose = "</mark>"
+	ellipsis  = "…"
+
+	// 片段起点向前保留的上下文长度
+	snippetLeadRunes = 20
+	maxHighlightSpan = 256
+)
+
+type span struct {
+	start int
+	end   int
+}
+
+// Highlight 将 text 中命中 terms 的片段用 <mark> 包裹，其余内容做 HTML 转义。
+// maxRunes > 0 时截取命中最密集的窗口作为摘要片段；text 中没有任何命中时，
+// maxRunes > 0 返回空串，否则返回转义后的原文。
+func Highlight(text string, terms []string, maxRunes int) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ""
+	}
+	runes := []rune(text)
+	spans := matchSpans(runes, terms)
+	if len(spans) == 0 {
+		if maxRunes > 0 {
+			return ""
+		}
+		return html.EscapeString(text)
+	}
+
+	from, to := 0, len(runes)
+	if maxRunes > 0 && len(runes) > maxRunes {
+		from, to = bestWindow(spans, len(runes), maxRunes)
+	}
+
+	var builder strings.Builder
+	if from > 0 {
+		builder.WriteString(ellipsis)
+	}
+	cursor := from
+	for _, s := range spans {
+		if s.end <= from || s.start >= to {
+			continue
+		}
+		start, end := max(s.start, from), min(s.end, to)
+		builder.WriteString(html.EscapeString(string(runes[cursor:start])))
+		builder.WriteString(markOpen)
+		builder.WriteString(html.EscapeString(string(runes[start:end])))
+		builder.WriteString(markClose)
+		cursor = end
+	}
+	builder.WriteString(html.EscapeString(string(runes[cursor:to])))
+	if to < len(runes) {
+		builder.WriteString(ellipsis)
+	}
+	return collapseWhitespace(builder.String())
+}
+
+// matchSpans 找出命中查询词的区间并合并重叠部分（中文 bigram 会相互重叠）
+func matchSpans(runes []rune, terms []string) []span {
+	wanted := make(map[string]struct{}, len(terms))
+	for _, term := range terms {
+		wanted[term] = struct{}{}
+	}
+
+	spans := make([]span, 0)
+	for _, token := range tokenize(runes, true) {
+		if _, ok := wanted[token.Term]; ok {
+			spans = append(spans, span{start: token.Start, end: token.End})
+		}
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+	sort.Slice(spans, func(a, b int) bool { return spans[a].start < spans[b].start })
+
+	merged := spans[:1]
+	for _, s := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if s.start <= last.end {
+			last.end = max(last.end, s.end)
+			continue
+		}
+		if len(merged) >= maxHighlightSpan {
+			break
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// bestWindow 选取包含命中区间最多的窗口，窗口起点前留少量上下文
+func bestWindow(spans []span, length int, size int) (int, int) {
+	bestStart, bestCount := spans[0].start, 0
+	right := 0
+	for left := range spans {
+		right = max(right, left)
+		for right < len(spans) && spans[right].end-spans[left].start <= size-snippetLeadRunes {
+			right++
+		}
+		if count := right - left; count > bestCount {
+			bestCount = count
+			bestStart = spans[left].start
+		}
+	}
+
+	from := max(bestStart-snippetLeadRunes, 0)
+	to := min(from+size, length)
+	from = max(to-size, 0)
+	return from, to
+}
+
+func collapseWhitespace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
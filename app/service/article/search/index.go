@@ -0,0 +1,312 @@
+// Package search 提供文章全文检索：进程内倒排索引 + BM25 打分 + 命中片段高亮。
+//
+// 文章量级很小（线上 ≤500 篇），索引常驻内存即可；启动时全量构建，
+// 发布/更新/删除时增量维护，并由 cron 周期性全量重建兜底多实例之间的差异。
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// BM25 参数与字段权重：标题是短的高信号字段，摘要次之，正文权重最低
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	titleWeight    = 3.0
+	describeWeight = 1.5
+	bodyWeight     = 1.0
+
+	snippetRunes = 120
+)
+
+const (
+	fieldTitle = iota
+	fieldDescribe
+	fieldBody
+	fieldCount
+)
+
+var fieldWeights = [fieldCount]float64{titleWeight, describeWeight, bodyWeight}
+
+// Document 参与检索的文章，Body 应为去除 Markdown 标记后的纯文本
+type Document struct {
+	ID         uint64
+	Title      string
+	Describe   string
+	Body       string
+	ViewNum    uint64
+	CreateTime time.Time
+}
+
+// Hit 检索命中结果，TitleHighlight/Snippet 为已转义的 HTML，命中词用 <mark> 包裹
+type Hit struct {
+	Document
+	Score          float64
+	TitleHighlight string
+	Snippet        string
+}
+
+type indexedDoc struct {
+	doc     Document
+	lengths [fieldCount]int
+	freqs   map[string]*[fieldCount]int
+}
+
+// Index 进程内倒排索引，并发安全
+type Index struct {
+	mu       sync.RWMutex
+	built    bool
+	docs     map[uint64]*indexedDoc
+	postings map[string]map[uint64]struct{}
+	totalLen [fieldCount]int
+
+	// rebuildMu 串行化全量重建；pending 记录重建期间的增量修改（nil 表示删除），替换后重放
+	rebuildMu sync.Mutex
+	pending   map[uint64]*Document
+}
+
+// NewIndex 创建空索引，需调用 Rebuild 后才视为可用
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[uint64]*indexedDoc),
+		postings: make(map[string]map[uint64]struct{}),
+	}
+}
+
+// Ready 索引是否已完成至少一次全量构建
+func (i *Index) Ready() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.built
+}
+
+// Len 当前索引中的文章数
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.docs)
+}
+
+// Rebuild 用给定文章全量重建索引，构建过程不持锁，完成后整体替换
+func (i *Index) Rebuild(docs []Document) {
+	_ = i.RebuildFrom(func() ([]Document, error) { return docs, nil })
+}
+
+// RebuildFrom 调用 load 读取全部文章并重建索引。从 load 开始到整体替换之间的 Upsert / Remove
+// 会被记录下来，替换后按最终状态重放，避免读库之后的增量修改被旧快照覆盖
+func (i *Index) RebuildFrom(load func() ([]Document, error)) error {
+	i.rebuildMu.Lock()
+	defer i.rebuildMu.Unlock()
+
+	i.mu.Lock()
+	i.pending = make(map[uint64]*Document)
+	i.mu.Unlock()
+
+	docs, err := load()
+	if err != nil {
+		i.mu.Lock()
+		i.pending = nil
+		i.mu.Unlock()
+		return err
+	}
+	fresh := NewIndex()
+	for _, doc := range docs {
+		fresh.add(doc)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.docs = fresh.docs
+	i.postings = fresh.postings
+	i.totalLen = fresh.totalLen
+	for id, doc := range i.pending {
+		i.remove(id)
+		if doc != nil {
+			i.add(*doc)
+		}
+	}
+	i.pending = nil
+	i.built = true
+	return nil
+}
+
+// Upsert 新增或替换一篇文章
+func (i *Index) Upsert(doc Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(doc.ID)
+	i.add(doc)
+	if i.pending != nil {
+		i.pending[doc.ID] = &doc
+	}
+}
+
+// Remove 从索引中移除一篇文章
+func (i *Index) Remove(id uint64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(id)
+	if i.pending != nil {
+		i.pending[id] = nil
+	}
+}
+
+// Search 按 BM25 相关度检索，多个查询词之间为 AND 关系；返回当前页命中与命中总数
+func (i *Index) Search(query string, offset, limit int) ([]Hit, int) {
+	terms := QueryTerms(query)
+	if len(terms) == 0 {
+		return []Hit{}, 0
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	candidates := i.intersect(terms)
+	if len(candidates) == 0 {
+		return []Hit{}, 0
+	}
+
+	docCount := float64(len(i.docs))
+	var avgLen [fieldCount]float64
+	for f := 0; f < fieldCount; f++ {
+		avgLen[f] = math.Max(float64(i.totalLen[f])/docCount, 1)
+	}
+	idf := make(map[string]float64, len(terms))
+	for _, term := range terms {
+		df := float64(len(i.postings[term]))
+		idf[term] = math.Log(1 + (docCount-df+0.5)/(df+0.5))
+	}
+
+	hits := make([]Hit, 0, len(candidates))
+	for _, id := range candidates {
+		entry := i.docs[id]
+		score := 0.0
+		for _, term := range terms {
+			freqs, ok := entry.freqs[term]
+			if !ok {
+				continue
+			}
+			for f := 0; f < fieldCount; f++ {
+				tf := float64(freqs[f])
+				if tf == 0 {
+					continue
+				}
+				norm := tf * (bm25K1 + 1) /
+					(tf + bm25K1*(1-bm25B+bm25B*float64(entry.lengths[f])/avgLen[f]))
+				score += fieldWeights[f] * idf[term] * norm
+			}
+		}
+		hits = append(hits, Hit{Document: entry.doc, Score: score})
+	}
+
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		if hits[a].ViewNum != hits[b].ViewNum {
+			return hits[a].ViewNum > hits[b].ViewNum
+		}
+		return hits[a].CreateTime.After(hits[b].CreateTime)
+	})
+
+	total := len(hits)
+	if offset >= total {
+		return []Hit{}, total
+	}
+	end := offset + limit
+	if limit <= 0 || end > total {
+		end = total
+	}
+	page := hits[offset:end]
+	for idx := range page {
+		page[idx].Score = math.Round(page[idx].Score*1000) / 1000
+		page[idx].TitleHighlight = Highlight(page[idx].Title, terms, 0)
+		page[idx].Snippet = Highlight(page[idx].Body, terms, snippetRunes)
+		if page[idx].Snippet == "" {
+			// 只命中标题时，用摘要兜底展示
+			page[idx].Snippet = Highlight(page[idx].Describe, terms, 0)
+		}
+		// 片段生成后不再需要正文，避免把整篇正文带出索引
+		page[idx].Body = ""
+	}
+	return page, total
+}
+
+func (i *Index) intersect(terms []string) []uint64 {
+	// 从最短的倒排链开始求交集
+	lists := make([]map[uint64]struct{}, 0, len(terms))
+	for _, term := range terms {
+		posting, ok := i.postings[term]
+		if !ok {
+			return nil
+		}
+		lists = append(lists, posting)
+	}
+	sort.Slice(lists, func(a, b int) bool { return len(lists[a]) < len(lists[b]) })
+
+	result := make([]uint64, 0, len(lists[0]))
+	for id := range lists[0] {
+		matched := true
+		for _, other := range lists[1:] {
+			if _, ok := other[id]; !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func (i *Index) add(doc Document) {
+	entry := &indexedDoc{
+		doc:   doc,
+		freqs: make(map[string]*[fieldCount]int),
+	}
+	for f, text := range [fieldCount]string{doc.Title, doc.Describe, doc.Body} {
+		tokens := Tokenize(text)
+		entry.lengths[f] = len(tokens)
+		i.totalLen[f] += len(tokens)
+		for _, token := range tokens {
+			freqs, ok := entry.freqs[token.Term]
+			if !ok {
+				freqs = &[fieldCount]int{}
+				entry.freqs[token.Term] = freqs
+			}
+			freqs[f]++
+		}
+	}
+	for term := range entry.freqs {
+		posting, ok := i.postings[term]
+		if !ok {
+			posting = make(map[uint64]struct{})
+			i.postings[term] = posting
+		}
+		posting[doc.ID] = struct{}{}
+	}
+	i.docs[doc.ID] = entry
+}
+
+func (i *Index) remove(id uint64) {
+	entry, ok := i.docs[id]
+	if !ok {
+		return
+	}
+	for term := range entry.freqs {
+		posting := i.postings[term]
+		delete(posting, id)
+		if len(posting) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	for f := 0; f < fieldCount; f++ {
+		i.totalLen[f] -= entry.lengths[f]
+	}
+	delete(i.docs, id)
+}
@@ -0,0 +1,112 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryTermsUsesBigramsForChinese(t *testing.T) {
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{query: "缓存", want: []string{"缓存"}},
+		{query: "数据库", want: []string{"数据", "据库"}},
+		{query: "锁", want: []string{"锁"}},
+		{query: "Go 语言 GO", want: []string{"go", "语言"}},
+		{query: "Ｒｅｄｉｓ缓存", want: []string{"redis", "缓存"}},
+	}
+	for _, tc := range cases {
+		got := QueryTerms(tc.query)
+		if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+			t.Fatalf("QueryTerms(%q) = %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestIndexRanksTitleHitsFirst(t *testing.T) {
+	index := NewIndex()
+	now := time.Now()
+	index.Rebuild([]Document{
+		{ID: 1, Title: "Go 并发入门", Body: "本文顺带提到 Redis 缓存的使用。", CreateTime: now},
+		{ID: 2, Title: "Redis 缓存击穿与雪崩", Body: "缓存击穿指热点 key 过期瞬间大量请求打到数据库。", CreateTime: now},
+		{ID: 3, Title: "MySQL 索引", Body: "与本次查询无关。", CreateTime: now},
+	})
+
+	hits, total := index.Search("redis 缓存", 0, 10)
+	if total != 2 || len(hits) != 2 {
+		t.Fatalf("expected 2 hits, got total=%d len=%d", total, len(hits))
+	}
+	if hits[0].ID != 2 {
+		t.Fatalf("expected title hit first, got %d", hits[0].ID)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Fatalf("expected descending scores, got %v", []float64{hits[0].Score, hits[1].Score})
+	}
+	if !strings.Contains(hits[0].TitleHighlight, "<mark>Redis</mark>") {
+		t.Fatalf("expected highlighted title, got %q", hits[0].TitleHighlight)
+	}
+	if hits[0].Body != "" {
+		t.Fatalf("expected body stripped from hits")
+	}
+}
+
+func TestIndexUpsertAndRemove(t *testing.T) {
+	index := NewIndex()
+	index.Rebuild(nil)
+	index.Upsert(Document{ID: 1, Title: "旧标题", Body: "旧内容"})
+	index.Upsert(Document{ID: 1, Title: "新标题", Body: "新内容"})
+
+	if _, total := index.Search("旧标题", 0, 10); total != 0 {
+		t.Fatalf("expected stale terms to be removed, got %d hits", total)
+	}
+	if _, total := index.Search("新标题", 0, 10); total != 1 {
+		t.Fatalf("expected upserted document to match, got %d hits", total)
+	}
+
+	index.Remove(1)
+	if index.Len() != 0 || len(index.postings) != 0 {
+		t.Fatalf("expected empty index after remove, docs=%d postings=%d", index.Len(), len(index.postings))
+	}
+}
+
+func TestIndexRebuildReplaysConcurrentChanges(t *testing.T) {
+	index := NewIndex()
+	index.Rebuild([]Document{{ID: 1, Title: "即将删除"}})
+
+	err := index.RebuildFrom(func() ([]Document, error) {
+		// 读库之后、替换之前发生的增量修改
+		snapshot := []Document{{ID: 1, Title: "即将删除"}}
+		index.Upsert(Document{ID: 2, Title: "刚刚发布"})
+		index.Remove(1)
+		return snapshot, nil
+	})
+	if err != nil {
+		t.Fatalf("rebuild failed: %v", err)
+	}
+	if _, total := index.Search("刚刚发布", 0, 10); total != 1 {
+		t.Fatalf("expected article published during rebuild to stay indexed, got %d hits", total)
+	}
+	if _, total := index.Search("即将删除", 0, 10); total != 0 {
+		t.Fatalf("expected article removed during rebuild to stay removed, got %d hits", total)
+	}
+}
+
+func TestHighlightEscapesAndTrimsWindow(t *testing.T) {
+	body := strings.Repeat("无关内容。", 60) + "这里讲 <script> 标签与缓存一致性。" + strings.Repeat("结尾。", 60)
+	snippet := Highlight(body, QueryTerms("缓存"), 40)
+
+	if !strings.Contains(snippet, "<mark>缓存</mark>") {
+		t.Fatalf("expected highlighted term, got %q", snippet)
+	}
+	if strings.Contains(snippet, "<script>") || !strings.Contains(snippet, "&lt;script&gt;") {
+		t.Fatalf("expected escaped html, got %q", snippet)
+	}
+	if !strings.HasPrefix(snippet, ellipsis) || !strings.HasSuffix(snippet, ellipsis) {
+		t.Fatalf("expected ellipsis on both sides, got %q", snippet)
+	}
+	if Highlight("完全不相关", QueryTerms("缓存"), 40) != "" {
+		t.Fatalf("expected empty snippet without matches")
+	}
+}
@@ -0,0 +1,95 @@
+package search
+
+import (
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// Token 分词结果，Start/End 为原文中的 rune 下标（左闭右开），用于高亮定位
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize 对文本做中文友好的分词，用于建立倒排索引：
+//   - 拉丁字母/数字连续片段作为一个词，统一小写、全角转半角；
+//   - 中日韩连续片段同时产出单字与相邻二元组（bigram），
+//     二元组保证多字查询的精度，单字保证单字查询也能命中。
+func Tokenize(text string) []Token {
+	return tokenize([]rune(text), true)
+}
+
+// QueryTerms 对查询词分词并去重：中文片段长度 ≥2 时只取二元组，避免单字带来大量噪声命中
+func QueryTerms(query string) []string {
+	tokens := tokenize([]rune(query), false)
+	seen := make(map[string]struct{}, len(tokens))
+	terms := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if _, ok := seen[token.Term]; ok {
+			continue
+		}
+		seen[token.Term] = struct{}{}
+		terms = append(terms, token.Term)
+	}
+	return terms
+}
+
+func tokenize(runes []rune, withUnigram bool) []Token {
+	tokens := make([]Token, 0, len(runes)/2)
+	normalized := make([]rune, len(runes))
+	for i, r := range runes {
+		normalized[i] = normalizeRune(r)
+	}
+
+	for i := 0; i < len(normalized); {
+		r := normalized[i]
+		switch {
+		case isCJK(r):
+			start := i
+			for i < len(normalized) && isCJK(normalized[i]) {
+				i++
+			}
+			tokens = appendCJKTokens(tokens, normalized[start:i], start, withUnigram)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			start := i
+			for i < len(normalized) && !isCJK(normalized[i]) &&
+				(unicode.IsLetter(normalized[i]) || unicode.IsDigit(normalized[i])) {
+				i++
+			}
+			tokens = append(tokens, Token{Term: string(normalized[start:i]), Start: start, End: i})
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+func appendCJKTokens(tokens []Token, run []rune, offset int, withUnigram bool) []Token {
+	if len(run) == 1 {
+		return append(tokens, Token{Term: string(run), Start: offset, End: offset + 1})
+	}
+	for i := range run {
+		if withUnigram {
+			tokens = append(tokens, Token{Term: string(run[i]), Start: offset + i, End: offset + i + 1})
+		}
+		if i+1 < len(run) {
+			tokens = append(tokens, Token{Term: string(run[i : i+2]), Start: offset + i, End: offset + i + 2})
+		}
+	}
+	return tokens
+}
+
+// normalizeRune 全角转半角并转小写，保持 rune 一一对应，便于用下标回到原文
+func normalizeRune(r rune) rune {
+	if folded := width.LookupRune(r).Folded(); folded != 0 {
+		r = folded
+	}
+	return unicode.ToLower(r)
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
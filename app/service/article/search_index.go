@@ -0,0 +1,74 @@
+package article
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/app/service/article/search"
+	"meta-api/common/cachekey"
+)
+
+// RebuildSearchIndex 从 MySQL 全量重建进程内全文索引，读库期间的增量更新由索引在替换后重放
+func (a *articleService) RebuildSearchIndex(ctx context.Context) error {
+	total := 0
+	err := a.searchIndex.RebuildFrom(func() ([]search.Document, error) {
+		list, err := a.articleModel.ListSearchDocuments(ctx)
+		if err != nil {
+			return nil, err
+		}
+		docs := make([]search.Document, 0, len(list))
+		for _, item := range list {
+			// 不公开的文章不进入搜索结果
+			if item.Visibility != article.ArticleVisibilityPublic {
+				continue
+			}
+			plainText := item.PlainText
+			if plainText == "" {
+				plainText = processArticleContent(item.Content).PlainText
+			}
+			docs = append(docs, buildSearchDocument(item.ID, item.Title, item.Describe, plainText,
+				item.ViewNum, item.CreateTime))
+		}
+		total = len(docs)
+		return docs, nil
+	})
+	if err != nil {
+		a.logger.Error("failed to list search documents", zap.Error(err))
+		return err
+	}
+
+	a.logger.Info("article search index rebuilt", zap.Int("total", total))
+	return nil
+}
+
+// indexPublishedArticle 发布或更新后增量刷新索引，createTime 取文章的原始创建时间；不公开的文章从索引中移除
+func (a *articleService) indexPublishedArticle(ctx context.Context, articleInfo *article.Article, createTime time.Time) {
+	articleID := strconv.FormatUint(articleInfo.ID, 10)
+	hidden, err := a.redis.SIsMember(ctx, cachekey.ArticleHiddenSet().String(), articleID).Result()
+	if err != nil {
+		a.logger.Warn("failed to check hidden article", zap.String("articleID", articleID), zap.Error(err))
+	}
+	if hidden {
+		a.searchIndex.Remove(articleInfo.ID)
+		return
+	}
+	a.searchIndex.Upsert(buildSearchDocument(articleInfo.ID, articleInfo.Title, articleInfo.Describe,
+		articleInfo.PlainText, articleInfo.ViewNum, createTime))
+}
+
+// buildSearchDocument plainText 为内容处理流程产出的纯文本，避免链接地址、强调符号进入索引和摘要片段
+func buildSearchDocument(id uint64, title, describe, plainText string, viewNum uint64,
+	createTime time.Time) search.Document {
+	return search.Document{
+		ID:         id,
+		Title:      title,
+		Describe:   describe,
+		Body:       plainText,
+		ViewNum:    viewNum,
+		CreateTime: createTime,
+	}
+}
@@ -0,0 +1,416 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"meta-api/app/model/article"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/idutil"
+	"meta-api/common/types"
+)
+
+// seriesCacheTTL 系列缓存兜底过期时间，正常情况下由写路径主动删除
+const seriesCacheTTL = 30 * time.Minute
+
+var errSeriesTitleRequired = errors.New("series title is required")
+
+// AdminGetSeriesList 管理员获取系列列表（文章数包含已下线的成员）
+func (a *articleService) AdminGetSeriesList(ctx context.Context) (*types.AdminGetSeriesListResponse, error) {
+	records, err := a.articleModel.ListSeries(ctx, false)
+	if err != nil {
+		a.logger.Error("failed to list series", zap.Error(err))
+		return nil, err
+	}
+	rows := make([]types.AdminSeriesItem, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, types.AdminSeriesItem{
+			ID:         strconv.FormatUint(record.ID, 10),
+			Title:      record.Title,
+			Describe:   record.Describe,
+			ArticleNum: record.ArticleNum,
+			CreateTime: record.CreateTime.Format(constants.TimeLayoutToMinute),
+			UpdateTime: record.UpdateTime.Format(constants.TimeLayoutToMinute),
+		})
+	}
+	return &types.AdminGetSeriesListResponse{Rows: rows, Total: len(rows)}, nil
+}
+
+// AdminGetSeriesDetail 管理员获取系列详情及全部成员
+func (a *articleService) AdminGetSeriesDetail(ctx context.Context,
+	request *types.AdminGetSeriesDetailRequest) (*types.AdminGetSeriesDetailResponse, error) {
+	id, err := idutil.ParseID("seriesID", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	series, err := a.articleModel.GetSeriesByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	records, err := a.articleModel.ListSeriesArticles(ctx, id, false)
+	if err != nil {
+		a.logger.Error("failed to list series articles", zap.Error(err))
+		return nil, err
+	}
+	articles := make([]types.AdminSeriesArticleItem, 0, len(records))
+	for _, record := range records {
+		articles = append(articles, types.AdminSeriesArticleItem{
+			ID:     strconv.FormatUint(record.ArticleID, 10),
+			Title:  record.Title,
+			Status: record.Status,
+		})
+	}
+	return &types.AdminGetSeriesDetailResponse{
+		ID:       request.ID,
+		Title:    series.Title,
+		Describe: series.Describe,
+		Articles: articles,
+	}, nil
+}
+
+// AdminAddSeries 创建系列，可同时指定有序的成员文章
+func (a *articleService) AdminAddSeries(ctx context.Context,
+	request *types.AdminAddSeriesRequest) (*types.AdminAddSeriesResponse, error) {
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return nil, errSeriesTitleRequired
+	}
+	articleIDs, err := parseSeriesArticleIDs(request.ArticleIDs)
+	if err != nil {
+		return nil, err
+	}
+	id, err := a.idGenerator.NextID()
+	if err != nil {
+		a.logger.Error("generate series id error", zap.Error(err))
+		return nil, fmt.Errorf("generate series id error: %w", err)
+	}
+	now := articleNow()
+	series := &article.Series{
+		ID:         id,
+		Title:      title,
+		Describe:   strings.TrimSpace(request.Describe),
+		CreateTime: now,
+		UpdateTime: now,
+	}
+	if err = a.articleModel.CreateSeries(ctx, series, articleIDs); err != nil {
+		a.logger.Error("failed to create series", zap.Error(err))
+		return nil, err
+	}
+	if err = a.invalidateSeriesCache(ctx, id, articleIDs); err != nil {
+		return nil, err
+	}
+	return &types.AdminAddSeriesResponse{ID: strconv.FormatUint(id, 10)}, nil
+}
+
+// AdminUpdateSeries 修改系列标题与简介
+func (a *articleService) AdminUpdateSeries(ctx context.Context, request *types.AdminUpdateSeriesRequest) error {
+	id, err := idutil.ParseID("seriesID", request.ID)
+	if err != nil {
+		return err
+	}
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		return errSeriesTitleRequired
+	}
+	if err = a.articleModel.UpdateSeries(ctx, &article.Series{
+		ID:         id,
+		Title:      title,
+		Describe:   strings.TrimSpace(request.Describe),
+		UpdateTime: articleNow(),
+	}); err != nil {
+		a.logger.Error("failed to update series", zap.Error(err))
+		return err
+	}
+	// 成员不变，文章 -> 系列的映射无需清理
+	return a.invalidateSeriesCache(ctx, id, nil)
+}
+
+// AdminUpdateSeriesArticles 按给定顺序整体替换系列成员
+func (a *articleService) AdminUpdateSeriesArticles(ctx context.Context,
+	request *types.AdminUpdateSeriesArticlesRequest) error {
+	id, err := idutil.ParseID("seriesID", request.ID)
+	if err != nil {
+		return err
+	}
+	articleIDs, err := parseSeriesArticleIDs(request.ArticleIDs)
+	if err != nil {
+		return err
+	}
+	previous, err := a.articleModel.ReplaceSeriesArticles(ctx, id, articleIDs, articleNow())
+	if err != nil {
+		a.logger.Error("failed to replace series articles", zap.Error(err))
+		return err
+	}
+	return a.invalidateSeriesCache(ctx, id, append(previous, articleIDs...))
+}
+
+// AdminDeleteSeries 删除系列，成员文章本身不受影响
+func (a *articleService) AdminDeleteSeries(ctx context.Context, request *types.AdminDeleteSeriesRequest) error {
+	id, err := idutil.ParseID("seriesID", request.ID)
+	if err != nil {
+		return err
+	}
+	articleIDs, err := a.articleModel.DeleteSeries(ctx, id)
+	if err != nil {
+		a.logger.Error("failed to delete series", zap.Error(err))
+		return err
+	}
+	return a.invalidateSeriesCache(ctx, id, articleIDs)
+}
+
+// UserGetSeriesList 前台系列列表，只统计已发布文章，空系列不展示
+func (a *articleService) UserGetSeriesList(ctx context.Context) (*types.UserGetSeriesListResponse, error) {
+	key := cachekey.SeriesList().String()
+	value, err := a.redis.Get(ctx, key).Result()
+	if err == nil {
+		rows := make([]types.UserSeriesItem, 0)
+		if err = sonic.Unmarshal([]byte(value), &rows); err == nil {
+			return &types.UserGetSeriesListResponse{Rows: rows, Total: len(rows)}, nil
+		}
+		a.logger.Warn("failed to unmarshal series list cache", zap.Error(err))
+	} else if !errors.Is(err, redis.Nil) {
+		a.logger.Warn("failed to get series list from redis", zap.Error(err))
+	}
+
+	records, err := a.articleModel.ListSeries(ctx, true)
+	if err != nil {
+		a.logger.Error("failed to list series", zap.Error(err))
+		return nil, err
+	}
+	rows := make([]types.UserSeriesItem, 0, len(records))
+	for _, record := range records {
+		if record.ArticleNum == 0 {
+			continue
+		}
+		rows = append(rows, types.UserSeriesItem{
+			ID:         strconv.FormatUint(record.ID, 10),
+			Title:      record.Title,
+			Describe:   record.Describe,
+			ArticleNum: record.ArticleNum,
+			UpdateTime: record.UpdateTime.Format(constants.TimeLayoutToDay),
+		})
+	}
+	if data, err := sonic.Marshal(rows); err != nil {
+		a.logger.Warn("failed to marshal series list cache", zap.Error(err))
+	} else if err = a.redis.Set(ctx, key, data, seriesCacheTTL).Err(); err != nil {
+		a.logger.Warn("failed to set series list cache", zap.Error(err))
+	}
+	return &types.UserGetSeriesListResponse{Rows: rows, Total: len(rows)}, nil
+}
+
+// UserGetSeriesDetail 前台系列详情，文章按系列顺序排列
+func (a *articleService) UserGetSeriesDetail(ctx context.Context,
+	request *types.UserGetSeriesDetailRequest) (*types.UserGetSeriesDetailResponse, error) {
+	id, err := idutil.ParseID("seriesID", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	return a.loadSeriesDetail(ctx, id)
+}
+
+// loadSeriesDetail 读取 series:{id}:Hash，缺失时回源 MySQL 并写回
+func (a *articleService) loadSeriesDetail(ctx context.Context, id uint64) (*types.UserGetSeriesDetailResponse, error) {
+	seriesID := strconv.FormatUint(id, 10)
+	key := cachekey.SeriesHash(seriesID).String()
+	cached, err := a.redis.HGetAll(ctx, key).Result()
+	if err != nil {
+		a.logger.Warn("failed to get series cache", zap.Error(err))
+	}
+	if articles, ok := cached["articles"]; ok {
+		response := &types.UserGetSeriesDetailResponse{
+			ID:         seriesID,
+			Title:      cached["title"],
+			Describe:   cached["describe"],
+			UpdateTime: cached["updateTime"],
+			Articles:   make([]types.SeriesArticleItem, 0),
+		}
+		if err = sonic.Unmarshal([]byte(articles), &response.Articles); err == nil {
+			return response, nil
+		}
+		a.logger.Warn("failed to unmarshal series cache", zap.Error(err))
+	}
+
+	series, err := a.articleModel.GetSeriesByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	records, err := a.articleModel.ListSeriesArticles(ctx, id, true)
+	if err != nil {
+		a.logger.Error("failed to list series articles", zap.Error(err))
+		return nil, err
+	}
+	response := &types.UserGetSeriesDetailResponse{
+		ID:         seriesID,
+		Title:      series.Title,
+		Describe:   series.Describe,
+		UpdateTime: series.UpdateTime.Format(constants.TimeLayoutToDay),
+		Articles:   make([]types.SeriesArticleItem, 0, len(records)),
+	}
+	for _, record := range records {
+		response.Articles = append(response.Articles, types.SeriesArticleItem{
+			ID:         strconv.FormatUint(record.ArticleID, 10),
+			Title:      record.Title,
+			CreateTime: record.CreateTime.Format(constants.TimeLayoutToDay),
+		})
+	}
+
+	articles, err := sonic.Marshal(response.Articles)
+	if err != nil {
+		a.logger.Warn("failed to marshal series cache", zap.Error(err))
+		return response, nil
+	}
+	pipe := a.redis.TxPipeline()
+	pipe.HSet(ctx, key, map[string]any{
+		"id":         seriesID,
+		"title":      response.Title,
+		"describe":   response.Describe,
+		"updateTime": response.UpdateTime,
+		"articles":   articles,
+	})
+	pipe.Expire(ctx, key, seriesCacheTTL)
+	if _, err = pipe.Exec(ctx); err != nil {
+		a.logger.Warn("failed to set series cache", zap.Error(err))
+	}
+	return response, nil
+}
+
+// articleSeriesContext 文章详情中的系列导航；文章不属于任何系列时返回 nil。
+// 系列导航只是附加信息，出错时记录日志并返回 nil，不影响文章详情本身。
+func (a *articleService) articleSeriesContext(ctx context.Context, articleID string) *types.UserArticleSeriesContext {
+	seriesID, err := a.articleSeriesID(ctx, articleID)
+	if err != nil {
+		a.logger.Warn("failed to get article series", zap.String("articleID", articleID), zap.Error(err))
+		return nil
+	}
+	if seriesID == 0 {
+		return nil
+	}
+	detail, err := a.loadSeriesDetail(ctx, seriesID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			a.logger.Warn("failed to load article series", zap.String("articleID", articleID), zap.Error(err))
+		}
+		return nil
+	}
+	return buildArticleSeriesContext(detail, articleID)
+}
+
+// articleSeriesID 读取 article:{id}:series:String，缺失时回源 MySQL
+func (a *articleService) articleSeriesID(ctx context.Context, articleID string) (uint64, error) {
+	key := cachekey.ArticleSeries(articleID).String()
+	value, err := a.redis.Get(ctx, key).Result()
+	if err == nil {
+		return strconv.ParseUint(value, 10, 64)
+	}
+	if !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+	id, err := idutil.ParseID("articleID", articleID)
+	if err != nil {
+		return 0, err
+	}
+	seriesID, err := a.articleModel.FindSeriesIDByArticleID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if err = a.redis.Set(ctx, key, strconv.FormatUint(seriesID, 10), seriesCacheTTL).Err(); err != nil {
+		a.logger.Warn("failed to set article series cache", zap.Error(err))
+	}
+	return seriesID, nil
+}
+
+func buildArticleSeriesContext(detail *types.UserGetSeriesDetailResponse,
+	articleID string) *types.UserArticleSeriesContext {
+	for i, item := range detail.Articles {
+		if item.ID != articleID {
+			continue
+		}
+		seriesContext := &types.UserArticleSeriesContext{
+			ID:       detail.ID,
+			Title:    detail.Title,
+			Position: i + 1,
+			Total:    len(detail.Articles),
+		}
+		if i > 0 {
+			prev := detail.Articles[i-1]
+			seriesContext.Prev = &prev
+		}
+		if i+1 < len(detail.Articles) {
+			next := detail.Articles[i+1]
+			seriesContext.Next = &next
+		}
+		return seriesContext
+	}
+	// 文章已下线或缓存尚未刷新，不展示导航
+	return nil
+}
+
+// invalidateSeriesCache 删除系列列表、系列详情以及 articleIDs 的文章 -> 系列映射
+func (a *articleService) invalidateSeriesCache(ctx context.Context, seriesID uint64, articleIDs []uint64) error {
+	keys := []string{
+		cachekey.SeriesList().String(),
+		cachekey.SeriesHash(strconv.FormatUint(seriesID, 10)).String(),
+	}
+	for _, articleID := range articleIDs {
+		keys = append(keys, cachekey.ArticleSeries(strconv.FormatUint(articleID, 10)).String())
+	}
+	if err := a.redis.Del(ctx, keys...).Err(); err != nil {
+		a.logger.Error("failed to delete series cache", zap.Uint64("seriesID", seriesID), zap.Error(err))
+		return fmt.Errorf("failed to delete series cache: %w", err)
+	}
+	return nil
+}
+
+// refreshArticleSeries 文章标题、发布状态或可见性变化后，清理其所在系列的缓存。
+// 失败只记日志：系列缓存最迟在 seriesCacheTTL 后自然过期。
+func (a *articleService) refreshArticleSeries(ctx context.Context, articleID uint64) {
+	seriesID, err := a.articleModel.FindSeriesIDByArticleID(ctx, articleID)
+	if err != nil {
+		a.logger.Error("failed to find article series", zap.Uint64("articleID", articleID), zap.Error(err))
+		return
+	}
+	if seriesID == 0 {
+		return
+	}
+	_ = a.invalidateSeriesCache(ctx, seriesID, nil)
+}
+
+// parseSeriesArticleIDs 解析成员文章 ID，保持顺序并拒绝重复
+func parseSeriesArticleIDs(rawIDs []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(rawIDs))
+	seen := make(map[uint64]struct{}, len(rawIDs))
+	for _, rawID := range rawIDs {
+		id, err := idutil.ParseID("articleID", rawID)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[id]; ok {
+			return nil, fmt.Errorf("duplicated series article id: %s", rawID)
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func IsSeriesNotFoundError(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
+
+func IsSeriesArticleConflictError(err error) bool {
+	return errors.Is(err, article.ErrSeriesArticleConflict)
+}
+
+func IsSeriesArticleNotPublishedError(err error) bool {
+	return errors.Is(err, article.ErrSeriesArticleNotPublished)
+}
@@ -0,0 +1,51 @@
+package article
+
+import (
+	"testing"
+
+	"meta-api/common/types"
+)
+
+func TestBuildArticleSeriesContext(t *testing.T) {
+	detail := &types.UserGetSeriesDetailResponse{
+		ID:    "1",
+		Title: "Redis 实战",
+		Articles: []types.SeriesArticleItem{
+			{ID: "10", Title: "第一篇"},
+			{ID: "11", Title: "第二篇"},
+			{ID: "12", Title: "第三篇"},
+		},
+	}
+
+	first := buildArticleSeriesContext(detail, "10")
+	if first == nil || first.Position != 1 || first.Total != 3 || first.Prev != nil || first.Next.ID != "11" {
+		t.Fatalf("unexpected first context: %+v", first)
+	}
+	middle := buildArticleSeriesContext(detail, "11")
+	if middle == nil || middle.Position != 2 || middle.Prev.ID != "10" || middle.Next.ID != "12" {
+		t.Fatalf("unexpected middle context: %+v", middle)
+	}
+	last := buildArticleSeriesContext(detail, "12")
+	if last == nil || last.Position != 3 || last.Prev.ID != "11" || last.Next != nil {
+		t.Fatalf("unexpected last context: %+v", last)
+	}
+	if got := buildArticleSeriesContext(detail, "99"); got != nil {
+		t.Fatalf("article outside series should have no context, got %+v", got)
+	}
+}
+
+func TestParseSeriesArticleIDs(t *testing.T) {
+	ids, err := parseSeriesArticleIDs([]string{"3", "1", "2"})
+	if err != nil {
+		t.Fatalf("parse ids: %v", err)
+	}
+	if len(ids) != 3 || ids[0] != 3 || ids[1] != 1 || ids[2] != 2 {
+		t.Fatalf("order not kept: %v", ids)
+	}
+	if _, err = parseSeriesArticleIDs([]string{"1", "1"}); err == nil {
+		t.Fatal("expected duplicated id error")
+	}
+	if _, err = parseSeriesArticleIDs([]string{"abc"}); err == nil {
+		t.Fatal("expected invalid id error")
+	}
+}
@@ -10,11 +10,14 @@ import (
 
 	"meta-api/app/model/article"
 	"meta-api/app/model/tag"
+	"meta-api/app/service/article/search"
+	"meta-api/common/ratelimit"
+	"meta-api/common/readcache"
 	"meta-api/common/types"
 	"meta-api/config"
 	"meta-api/pkg/cdn"
-	"meta-api/pkg/cos"
 	"meta-api/pkg/sitemap"
+	"meta-api/pkg/storage"
 )
 
 // Service 文章服务接口
@@ -24,24 +27,67 @@ type Service interface {
 	AdminAddArticle(ctx context.Context, request *types.AdminAddArticleRequest) (*types.AdminSaveArticleResponse, error)
 	AdminUpdateArticle(ctx context.Context, request *types.AdminUpdateArticleRequest) (*types.AdminSaveArticleResponse, error)
 	AdminDeleteArticle(ctx context.Context, request *types.AdminDeleteArticleRequest) error
+	AdminGetArticleTrashList(ctx context.Context, request *types.AdminGetArticleTrashListRequest) (*types.AdminGetArticleTrashListResponse, error)
+	AdminRestoreArticle(ctx context.Context, request *types.AdminRestoreArticleRequest) error
+	AdminPurgeArticle(ctx context.Context, request *types.AdminPurgeArticleRequest) error
+	AdminExportArticles(ctx context.Context, request *types.AdminExportArticleRequest) (*types.AdminExportArticleResponse, error)
+	AdminImportArticles(ctx context.Context, content []byte) (*types.AdminImportArticleResponse, error)
+	AdminGetArticleViewStats(ctx context.Context, request *types.AdminGetArticleViewStatsRequest) (*types.AdminGetArticleViewStatsResponse, error)
+	AdminGetSiteViewStats(ctx context.Context, request *types.AdminGetSiteViewStatsRequest) (*types.AdminGetSiteViewStatsResponse, error)
 	AdminGetArticleDraftList(ctx context.Context, request *types.AdminGetArticleDraftListRequest) (*types.AdminGetArticleDraftListResponse, error)
 	AdminGetArticleDraftDetail(ctx context.Context, request *types.AdminGetArticleDraftDetailRequest) (*types.AdminGetArticleDraftDetailResponse, error)
 	AdminSaveArticleDraft(ctx context.Context, request *types.AdminSaveArticleDraftRequest) (*types.AdminSaveArticleResponse, error)
 	AdminPublishArticleDraft(ctx context.Context, request *types.AdminPublishArticleDraftRequest) (*types.AdminSaveArticleResponse, error)
 	AdminDeleteArticleDraft(ctx context.Context, request *types.AdminDeleteArticleDraftRequest) error
+	AdminAcquireArticleEditLease(ctx context.Context, userID string, request *types.AdminArticleEditLeaseRequest) (*types.AdminArticleEditLeaseResponse, error)
+	AdminRenewArticleEditLease(ctx context.Context, request *types.AdminArticleEditLeaseRequest) (*types.AdminArticleEditLeaseResponse, error)
+	AdminReleaseArticleEditLease(ctx context.Context, request *types.AdminArticleEditLeaseRequest) error
+	AdminGetArticleRevisionList(ctx context.Context, request *types.AdminGetArticleRevisionListRequest) (*types.AdminGetArticleRevisionListResponse, error)
+	AdminGetArticleRevisionDiff(ctx context.Context, request *types.AdminGetArticleRevisionDiffRequest) (*types.AdminGetArticleRevisionDiffResponse, error)
+	AdminRestoreArticleRevision(ctx context.Context, request *types.AdminRestoreArticleRevisionRequest) (*types.AdminSaveArticleResponse, error)
 	AdminUploadArticleImage(ctx context.Context, fileName string, contentType string, content []byte) (*types.AdminUploadArticleImageResponse, error)
 	AdminGetArticleImageList(ctx context.Context, request *types.AdminGetArticleImageListRequest) (*types.AdminGetArticleImageListResponse, error)
 	AdminGetArticleImageDetail(ctx context.Context, request *types.AdminGetArticleImageDetailRequest) (*types.AdminGetArticleImageDetailResponse, error)
 	AdminDeleteArticleImage(ctx context.Context, request *types.AdminDeleteArticleImageRequest) error
+	AdminGetArticleImageTrashList(ctx context.Context, request *types.AdminGetArticleImageTrashListRequest) (*types.AdminGetArticleImageTrashListResponse, error)
+	AdminRestoreArticleImage(ctx context.Context, request *types.AdminRestoreArticleImageRequest) error
+	AdminPurgeArticleImage(ctx context.Context, request *types.AdminPurgeArticleImageRequest) error
+	AdminGetArticleImageGCReport(ctx context.Context, request *types.AdminGetArticleImageGCReportRequest) (*types.AdminArticleImageGCReportResponse, error)
+	AdminGetSeriesList(ctx context.Context) (*types.AdminGetSeriesListResponse, error)
+	AdminGetSeriesDetail(ctx context.Context, request *types.AdminGetSeriesDetailRequest) (*types.AdminGetSeriesDetailResponse, error)
+	AdminAddSeries(ctx context.Context, request *types.AdminAddSeriesRequest) (*types.AdminAddSeriesResponse, error)
+	AdminUpdateSeries(ctx context.Context, request *types.AdminUpdateSeriesRequest) error
+	AdminUpdateSeriesArticles(ctx context.Context, request *types.AdminUpdateSeriesArticlesRequest) error
+	AdminDeleteSeries(ctx context.Context, request *types.AdminDeleteSeriesRequest) error
+	AdminGetArticlePromotions(ctx context.Context, request *types.AdminGetArticlePromotionRequest) (*types.AdminGetArticlePromotionResponse, error)
+	AdminUpdateArticlePromotions(ctx context.Context, request *types.AdminUpdateArticlePromotionRequest) error
+	AdminUpdateArticleVisibility(ctx context.Context, request *types.AdminUpdateArticleVisibilityRequest) error
 
 	UserGetArticleList(ctx context.Context, request *types.UserGetArticleListRequest) (*types.UserGetArticleListResponse, error)
 	UserGetArticleDetail(ctx context.Context, request *types.UserGetArticleDetailRequest) (*types.UserGetArticleDetailResponse, error)
+	UserUnlockArticle(ctx context.Context, request *types.UserUnlockArticleRequest) (*types.UserUnlockArticleResponse, error)
 	UserSearchArticle(ctx context.Context, request *types.UserSearchArticleRequest) (*types.UserSearchArticleResponse, error)
 	UserGetHotArticle(ctx context.Context) (*types.UserGetHotArticleResponse, error)
+	UserGetTrendingArticle(ctx context.Context, request *types.UserGetTrendingArticleRequest) (*types.UserGetTrendingArticleResponse, error)
+	UserGetFeaturedArticle(ctx context.Context) (*types.UserGetFeaturedArticleResponse, error)
+	UserGetRelatedArticle(ctx context.Context, request *types.UserGetRelatedArticleRequest) (*types.UserGetRelatedArticleResponse, error)
 	UserGetTimeline(ctx context.Context) (*types.GetTimelineResponse, error)
+	UserGetArticleArchive(ctx context.Context) (*types.UserGetArticleArchiveResponse, error)
+	UserGetArticleArchiveMonth(ctx context.Context, request *types.UserGetArticleArchiveMonthRequest) (*types.UserGetArticleArchiveMonthResponse, error)
+	UserGetArticleFeed(ctx context.Context, request *types.UserGetArticleFeedRequest) (*types.UserGetArticleFeedResponse, error)
+	UserGetSeriesList(ctx context.Context) (*types.UserGetSeriesListResponse, error)
+	UserGetSeriesDetail(ctx context.Context, request *types.UserGetSeriesDetailRequest) (*types.UserGetSeriesDetailResponse, error)
 
 	WarmUpCache(ctx context.Context) error
+	BackfillArticleContent(ctx context.Context) error
+	RebuildSearchIndex(ctx context.Context) error
+	RebuildRelatedArticles(ctx context.Context) error
+	RunScheduledPublishing(ctx context.Context) error
 	PersistViewCount(ctx context.Context) error
+	FlushViewStats(ctx context.Context) error
+	RescoreTrending(ctx context.Context) error
+	PurgeExpiredTrash(ctx context.Context) error
+	CollectOrphanedArticleImages(ctx context.Context) error
 	RegisterCronJobs(c *cron.Cron) ([]cron.EntryID, error)
 }
 
@@ -54,15 +100,22 @@ type articleService struct {
 	articleModel article.Model
 	tagModel     tag.Model
 	cdn          *cdn.Client
-	imageStore   *cos.Client
+	imageStore   storage.ObjectStore
 	sitemap      *sitemap.Client
+	searchIndex  *search.Index
+	limiter      *ratelimit.Limiter
+	detailCache  *readcache.Cache[articleDetailCacheEntry]
+
+	archiveMonthsCache *readcache.Cache[types.UserGetArticleArchiveResponse]
+	archiveListCache   *readcache.Cache[[]types.UserArchiveArticleItem]
+	relatedTagCache    *readcache.Cache[[]uint64]
 }
 
 // NewService 创建服务实例
 func NewService(config *config.Config, logger *zap.Logger,
 	idGenerator *sonyflake.Sonyflake, redis *redis.Client,
 	articleModel article.Model, tagModel tag.Model,
-	cdnClient *cdn.Client, imageStore *cos.Client, sm *sitemap.Client) Service {
+	cdnClient *cdn.Client, imageStore storage.ObjectStore, sm *sitemap.Client) Service {
 
 	return &articleService{
 		config:       config,
@@ -74,5 +127,12 @@ func NewService(config *config.Config, logger *zap.Logger,
 		cdn:          cdnClient,
 		imageStore:   imageStore,
 		sitemap:      sm,
+		searchIndex:  search.NewIndex(),
+		limiter:      ratelimit.NewRedisLimiter(redis),
+		detailCache:  newArticleDetailCache(readcache.NewRedisStore(redis), logger),
+
+		archiveMonthsCache: newArticleArchiveCache[types.UserGetArticleArchiveResponse](readcache.NewRedisStore(redis), logger),
+		archiveListCache:   newArticleArchiveCache[[]types.UserArchiveArticleItem](readcache.NewRedisStore(redis), logger),
+		relatedTagCache:    newRelatedTagCache(readcache.NewRedisStore(redis), logger),
 	}
 }
@@ -0,0 +1,128 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"meta-api/app/model/article"
+	"meta-api/common/cachekey"
+)
+
+const articleSlugMaxLength = 100
+
+// articleSlugPattern 小写字母、数字与单个连字符，首尾不能是连字符
+var articleSlugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+var errArticleSlugInvalid = errors.New("invalid article slug")
+
+// ArticleSlugMovedError 请求的是文章的历史 slug，调用方应永久重定向到 Slug（为空时使用 ID）
+type ArticleSlugMovedError struct {
+	ID   string
+	Slug string
+}
+
+func (e *ArticleSlugMovedError) Error() string {
+	return fmt.Sprintf("article slug moved to %q (id %s)", e.Slug, e.ID)
+}
+
+// normalizeArticleSlug 统一转小写后校验格式；slug 必须包含字母，避免与数字形式的文章 ID 混淆
+func normalizeArticleSlug(value string) (string, error) {
+	slug := strings.ToLower(strings.TrimSpace(value))
+	if slug == "" {
+		return "", nil
+	}
+	if len(slug) > articleSlugMaxLength || !articleSlugPattern.MatchString(slug) {
+		return "", errArticleSlugInvalid
+	}
+	if strings.Trim(slug, "0123456789-") == "" {
+		return "", errArticleSlugInvalid
+	}
+	return slug, nil
+}
+
+// resolveArticleKey 把详情页地址段（文章 ID 或 slug）解析为文章 ID。
+// 命中历史 slug 时返回 *ArticleSlugMovedError，不存在时返回 gorm.ErrRecordNotFound。
+func (a *articleService) resolveArticleKey(ctx context.Context, key string) (string, error) {
+	if _, err := strconv.ParseUint(key, 10, 64); err == nil {
+		return key, nil
+	}
+	slug := strings.ToLower(key)
+	slugKey := cachekey.ArticleSlugHash().String()
+	articleID, err := a.redis.HGet(ctx, slugKey, slug).Result()
+	if err == nil {
+		return articleID, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		a.logger.Error("failed to get article slug cache", zap.Error(err))
+		return "", err
+	}
+
+	lookup, err := a.articleModel.FindArticleBySlug(ctx, slug)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			a.logger.Error("failed to find article by slug", zap.Error(err))
+		}
+		return "", err
+	}
+	articleID = strconv.FormatUint(lookup.ArticleID, 10)
+	if lookup.Moved {
+		return "", &ArticleSlugMovedError{ID: articleID, Slug: lookup.CurrentSlug}
+	}
+	if err = a.redis.HSet(ctx, slugKey, slug, articleID).Err(); err != nil {
+		a.logger.Error("failed to set article slug cache", zap.Error(err))
+	}
+	return articleID, nil
+}
+
+// removeArticleSlugCache 删除 slug -> ID 映射缓存，失败只记录日志（下次访问会回源校正）
+func (a *articleService) removeArticleSlugCache(ctx context.Context, slugs ...string) {
+	fields := make([]string, 0, len(slugs))
+	for _, slug := range slugs {
+		if slug != "" {
+			fields = append(fields, slug)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	if err := a.redis.HDel(ctx, cachekey.ArticleSlugHash().String(), fields...).Err(); err != nil {
+		a.logger.Error("failed to delete article slug cache", zap.Strings("slugs", fields), zap.Error(err))
+	}
+}
+
+// articleURLKeys 文章详情页的全部地址段（ID、当前 slug 与历史 slug），用于 CDN 清理与 sitemap 刷新。
+// 查询失败时退回只使用文章 ID。
+func (a *articleService) articleURLKeys(ctx context.Context, articleIDs ...uint64) []string {
+	keys, err := a.articleModel.ListArticleURLKeys(ctx, articleIDs)
+	if err != nil {
+		a.logger.Error("failed to list article url keys", zap.Error(err))
+		keys = make([]string, 0, len(articleIDs))
+		for _, id := range articleIDs {
+			keys = append(keys, strconv.FormatUint(id, 10))
+		}
+	}
+	return keys
+}
+
+func IsArticleSlugInvalidError(err error) bool {
+	return errors.Is(err, errArticleSlugInvalid)
+}
+
+func IsArticleSlugConflictError(err error) bool {
+	return errors.Is(err, article.ErrArticleSlugConflict)
+}
+
+// AsArticleSlugMovedError 判断是否为历史 slug 重定向
+func AsArticleSlugMovedError(err error) (*ArticleSlugMovedError, bool) {
+	var moved *ArticleSlugMovedError
+	ok := errors.As(err, &moved)
+	return moved, ok
+}
@@ -0,0 +1,26 @@
+package article
+
+import "testing"
+
+func TestNormalizeArticleSlug(t *testing.T) {
+	valid := map[string]string{
+		"":                      "",
+		"  Redis-Cache-Guide  ": "redis-cache-guide",
+		"go-1-25-release":       "go-1-25-release",
+		"k8s":                   "k8s",
+	}
+	for input, want := range valid {
+		got, err := normalizeArticleSlug(input)
+		if err != nil || got != want {
+			t.Fatalf("normalizeArticleSlug(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	// 纯数字会与文章 ID 混淆
+	invalid := []string{"123456", "2024-01", "-redis", "redis-", "redis--cache", "redis_cache", "缓存", "a b"}
+	for _, input := range invalid {
+		if _, err := normalizeArticleSlug(input); !IsArticleSlugInvalidError(err) {
+			t.Fatalf("normalizeArticleSlug(%q) should be invalid, got %v", input, err)
+		}
+	}
+}
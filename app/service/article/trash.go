@@ -0,0 +1,264 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"meta-api/app/model/article"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/idutil"
+	"meta-api/common/types"
+	"meta-api/pkg/storage"
+)
+
+// trashPurgeBatchSize 每次定时清理处理的过期条目上限，剩余的留给下一次
+const trashPurgeBatchSize = 100
+
+// AdminGetArticleTrashList 回收站文章列表
+func (a *articleService) AdminGetArticleTrashList(ctx context.Context,
+	request *types.AdminGetArticleTrashListRequest) (*types.AdminGetArticleTrashListResponse, error) {
+	offset := (request.Page - 1) * request.PageSize
+	records, total, err := a.articleModel.ListTrashedArticles(ctx, offset, request.PageSize)
+	if err != nil {
+		a.logger.Error("failed to list trashed articles", zap.Error(err))
+		return nil, err
+	}
+
+	retention := a.config.TrashSnapshot().Retention()
+	rows := make([]types.AdminArticleTrashItem, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, types.AdminArticleTrashItem{
+			ID:         strconv.FormatUint(record.ID, 10),
+			Title:      record.Title,
+			CreateTime: record.CreateTime.Format(constants.TimeLayoutToMinute),
+			DeleteTime: record.DeletedAt.Format(constants.TimeLayoutToMinute),
+			PurgeTime:  record.DeletedAt.Add(retention).Format(constants.TimeLayoutToMinute),
+		})
+	}
+	return &types.AdminGetArticleTrashListResponse{Rows: rows, Total: int(total)}, nil
+}
+
+// AdminRestoreArticle 从回收站恢复文章，重建 Redis 排序集合、标签计数、图片引用与全文索引
+func (a *articleService) AdminRestoreArticle(ctx context.Context, request *types.AdminRestoreArticleRequest) error {
+	id, err := idutil.ParseID("articleID", request.ID)
+	if err != nil {
+		a.logger.Error("invalid article id", zap.Error(err))
+		return err
+	}
+	if err = a.articleModel.RestoreArticleByID(ctx, id); err != nil {
+		a.logger.Error("failed to restore article", zap.Error(err))
+		return err
+	}
+
+	detail, err := a.articleModel.GetArticleDetailByID(ctx, id)
+	if err != nil {
+		a.logger.Error("failed to get restored article", zap.Error(err))
+		return fmt.Errorf("failed to get restored article: %w", err)
+	}
+	articleInfo := &article.Article{
+		ID:         detail.ID,
+		Title:      detail.Title,
+		Describe:   detail.Describe,
+		Content:    detail.Content,
+		ViewNum:    detail.ViewNum,
+		PlainText:  detail.PlainText,
+		CreateTime: detail.CreateTime,
+	}
+
+	if err = a.syncPublishedArticleImageReferences(ctx, id, detail.Content); err != nil {
+		a.logger.Error("failed to sync article image references", zap.Error(err))
+		return fmt.Errorf("failed to sync article image references: %w", err)
+	}
+	if err = a.restorePublishedArticleCache(ctx, articleInfo, detail.TagNames); err != nil {
+		return err
+	}
+	if err = a.syncArticleVisibility(ctx, request.ID, detail.Visibility); err != nil {
+		return err
+	}
+	a.indexPublishedArticle(ctx, articleInfo, detail.CreateTime)
+	a.updateRelatedArticles(ctx, articleInfo, detail.TagNames)
+
+	urlKeys := a.articleURLKeys(ctx, id)
+	if err = a.cdn.PurgeArticles(urlKeys...); err != nil {
+		a.logger.Error("failed to purge article CDN cache", zap.String("article_id", request.ID), zap.Error(err))
+	}
+	a.sitemap.RefreshArticles(urlKeys...)
+	a.invalidateArticleFeeds(ctx)
+	a.invalidateArticleArchive(ctx)
+
+	seriesID, err := a.articleModel.FindSeriesIDByArticleID(ctx, id)
+	if err != nil {
+		a.logger.Error("failed to find article series", zap.Error(err))
+		return fmt.Errorf("failed to find article series: %w", err)
+	}
+	if seriesID != 0 {
+		return a.invalidateSeriesCache(ctx, seriesID, []uint64{id})
+	}
+	return nil
+}
+
+// AdminPurgeArticle 彻底删除回收站中的文章
+func (a *articleService) AdminPurgeArticle(ctx context.Context, request *types.AdminPurgeArticleRequest) error {
+	id, err := idutil.ParseID("articleID", request.ID)
+	if err != nil {
+		a.logger.Error("invalid article id", zap.Error(err))
+		return err
+	}
+	if _, err = a.articleModel.GetTrashedArticleByID(ctx, id); err != nil {
+		return err
+	}
+	return a.purgeArticle(ctx, id)
+}
+
+// AdminGetArticleImageTrashList 回收站图片列表
+func (a *articleService) AdminGetArticleImageTrashList(ctx context.Context,
+	request *types.AdminGetArticleImageTrashListRequest) (*types.AdminGetArticleImageTrashListResponse, error) {
+	offset := (request.Page - 1) * request.PageSize
+	records, total, err := a.articleModel.ListTrashedArticleImages(ctx, offset, request.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := a.config.TrashSnapshot().Retention()
+	rows := make([]types.AdminArticleImageTrashItem, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, types.AdminArticleImageTrashItem{
+			ID:         strconv.FormatUint(record.ID, 10),
+			URL:        record.URL,
+			ImageName:  record.ImageName,
+			Mime:       record.Mime,
+			Size:       record.Size,
+			RefCount:   record.RefCount,
+			CreateTime: record.CreateTime.Format(constants.TimeLayoutToMinute),
+			DeleteTime: record.DeletedAt.Format(constants.TimeLayoutToMinute),
+			PurgeTime:  record.DeletedAt.Add(retention).Format(constants.TimeLayoutToMinute),
+		})
+	}
+	return &types.AdminGetArticleImageTrashListResponse{Rows: rows, Total: int(total)}, nil
+}
+
+// AdminRestoreArticleImage 从回收站恢复图片，引用关系在移入回收站时保留，无需重新同步
+func (a *articleService) AdminRestoreArticleImage(ctx context.Context,
+	request *types.AdminRestoreArticleImageRequest) error {
+	id, err := idutil.ParseID("articleImageID", request.ID)
+	if err != nil {
+		return err
+	}
+	return a.articleModel.RestoreArticleImage(ctx, id)
+}
+
+// AdminPurgeArticleImage 彻底删除回收站中的图片及其 COS 对象
+func (a *articleService) AdminPurgeArticleImage(ctx context.Context, request *types.AdminPurgeArticleImageRequest) error {
+	id, err := idutil.ParseID("articleImageID", request.ID)
+	if err != nil {
+		return err
+	}
+	image, err := a.articleModel.GetTrashedArticleImageByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return a.purgeArticleImage(ctx, image)
+}
+
+// PurgeExpiredTrash 彻底删除超过保留期的回收站文章与图片
+func (a *articleService) PurgeExpiredTrash(ctx context.Context) error {
+	before := articleNow().Add(-a.config.TrashSnapshot().Retention())
+
+	articleIDs, err := a.articleModel.ListExpiredTrashedArticleIDs(ctx, before, trashPurgeBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, id := range articleIDs {
+		if err = a.purgeArticle(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	images, err := a.articleModel.ListExpiredTrashedArticleImages(ctx, before, trashPurgeBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range images {
+		if err = a.purgeArticleImage(ctx, &images[i]); err != nil {
+			return err
+		}
+	}
+
+	if len(articleIDs) > 0 || len(images) > 0 {
+		a.logger.Info("expired trash purged",
+			zap.Int("articles", len(articleIDs)),
+			zap.Int("images", len(images)))
+	}
+	return nil
+}
+
+func (a *articleService) purgeArticle(ctx context.Context, id uint64) error {
+	seriesID, err := a.articleModel.FindSeriesIDByArticleID(ctx, id)
+	if err != nil {
+		a.logger.Error("failed to find article series", zap.Error(err))
+		return fmt.Errorf("failed to find article series: %w", err)
+	}
+	if err = a.articleModel.PurgeArticleByID(ctx, id); err != nil {
+		a.logger.Error("failed to purge article", zap.Uint64("article_id", id), zap.Error(err))
+		return fmt.Errorf("failed to purge article: %w", err)
+	}
+	if seriesID != 0 {
+		return a.invalidateSeriesCache(ctx, seriesID, []uint64{id})
+	}
+	return nil
+}
+
+func (a *articleService) purgeArticleImage(ctx context.Context, image *article.ArticleImage) error {
+	if err := a.purgeArticleImageVariants(ctx, image.ID); err != nil {
+		if errors.Is(err, storage.ErrDisabled) {
+			return fmt.Errorf("article image storage is not configured: %w", err)
+		}
+		return err
+	}
+	if err := a.imageStore.Delete(ctx, image.ObjectKey); err != nil {
+		if errors.Is(err, storage.ErrDisabled) {
+			return fmt.Errorf("article image storage is not configured: %w", err)
+		}
+		return err
+	}
+	if err := a.articleModel.PurgeArticleImage(ctx, image.ID); err != nil {
+		return err
+	}
+	a.logger.Info("article image purged",
+		zap.Uint64("image_id", image.ID),
+		zap.String("object_key", image.ObjectKey))
+	return nil
+}
+
+// restorePublishedArticleCache 恢复文章的排序集合成员；标签计数与标签文章列表直接删除，由读取方从数据库重建，
+// 避免在移入回收站时已被整体删除的 tag:articleNum:ZSet 上做增量
+func (a *articleService) restorePublishedArticleCache(ctx context.Context, articleInfo *article.Article,
+	tagNames []string) error {
+	articleID := strconv.FormatUint(articleInfo.ID, 10)
+	if err := a.redis.ZAdd(ctx, cachekey.ArticleTimeZSet().String(), redis.Z{
+		Score:  cachekey.ArticleTimeScore(articleInfo.CreateTime),
+		Member: articleInfo.ID,
+	}).Err(); err != nil {
+		a.logger.Error("failed to restore article time zset member", zap.Error(err))
+		return fmt.Errorf("failed to restore article time zset member: %w", err)
+	}
+	if err := a.redis.ZAdd(ctx, cachekey.ArticleViewZSet().String(), redis.Z{
+		Score:  cachekey.ArticleViewScore(articleInfo.ViewNum),
+		Member: articleInfo.ID,
+	}).Err(); err != nil {
+		a.logger.Error("failed to restore article view zset member", zap.Error(err))
+		return fmt.Errorf("failed to restore article view zset member: %w", err)
+	}
+	return a.invalidateUpdatedArticleCache(ctx, articleID, nil, tagNames)
+}
+
+func IsArticleTrashNotFoundError(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,143 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"meta-api/app/service/article/viewstats"
+	"meta-api/common/cachekey"
+	"meta-api/common/types"
+)
+
+// trendingKeepSize 趋势榜保留的文章数上限，接口 size 不会超过该值
+const trendingKeepSize = 100
+
+// RescoreTrending 读取窗口内按小时分桶的浏览、评论事件，按指数衰减重新计算趋势分数并整体替换趋势榜。
+// 只保留仍在已发布集合中的文章；先写临时 Key 再 RENAME，读取方不会看到半成品
+func (a *articleService) RescoreTrending(ctx context.Context) error {
+	ranking := a.config.RankingSnapshot()
+	now := time.Now()
+	hours := int(ranking.TrendingWindow() / time.Hour)
+	points := viewstats.HourSeries(now, hours, nil)
+
+	pipe := a.redis.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, 0, len(points))
+	for _, point := range points {
+		cmds = append(cmds, pipe.HGetAll(ctx, cachekey.ArticleTrendingHourHash(point.Key).String()))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		a.logger.Error("failed to get trending buckets", zap.Error(err))
+		return fmt.Errorf("failed to get trending buckets: %w", err)
+	}
+
+	commentWeight := ranking.CommentWeight()
+	buckets := make(map[string]map[string]float64)
+	for i, cmd := range cmds {
+		for field, value := range cmd.Val() {
+			articleID, event, ok := viewstats.ParseTrendingField(field)
+			if !ok {
+				continue
+			}
+			count, err := strconv.ParseFloat(value, 64)
+			if err != nil || count <= 0 {
+				continue
+			}
+			if event == viewstats.TrendingEventComment {
+				count *= commentWeight
+			}
+			if buckets[articleID] == nil {
+				buckets[articleID] = make(map[string]float64)
+			}
+			buckets[articleID][points[i].Key] += count
+		}
+	}
+
+	// 过滤已下线、已删除以及不公开的文章
+	hidden, err := a.hiddenArticleIDs(ctx)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(buckets))
+	for articleID := range buckets {
+		if _, ok := hidden[articleID]; !ok {
+			ids = append(ids, articleID)
+		}
+	}
+	timeKey := cachekey.ArticleTimeZSet().String()
+	pipe = a.redis.Pipeline()
+	scoreCmds := make([]*redis.FloatCmd, 0, len(ids))
+	for _, articleID := range ids {
+		scoreCmds = append(scoreCmds, pipe.ZScore(ctx, timeKey, articleID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		a.logger.Error("failed to check published articles", zap.Error(err))
+		return fmt.Errorf("failed to check published articles: %w", err)
+	}
+
+	halfLife := ranking.TrendingHalfLife()
+	members := make([]redis.Z, 0, len(ids))
+	for i, articleID := range ids {
+		if scoreCmds[i].Err() != nil {
+			continue
+		}
+		score := viewstats.TrendingScore(buckets[articleID], now, halfLife)
+		if score <= 0 {
+			continue
+		}
+		members = append(members, redis.Z{Score: score, Member: articleID})
+	}
+
+	trendingKey := cachekey.ArticleTrendingZSet().String()
+	if len(members) == 0 {
+		if err := a.redis.Del(ctx, trendingKey).Err(); err != nil {
+			a.logger.Error("failed to clear trending articles", zap.Error(err))
+			return fmt.Errorf("failed to clear trending articles: %w", err)
+		}
+		return nil
+	}
+
+	tmpKey := trendingKey + ":tmp"
+	pipe = a.redis.TxPipeline()
+	pipe.Del(ctx, tmpKey)
+	pipe.ZAdd(ctx, tmpKey, members...)
+	pipe.ZRemRangeByRank(ctx, tmpKey, 0, -trendingKeepSize-1)
+	pipe.Rename(ctx, tmpKey, trendingKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		a.logger.Error("failed to replace trending articles", zap.Error(err))
+		return fmt.Errorf("failed to replace trending articles: %w", err)
+	}
+	a.logger.Debug("trending articles rescored", zap.Int("articles", min(len(members), trendingKeepSize)))
+	return nil
+}
+
+// UserGetTrendingArticle 获取近期趋势文章，按时间衰减后的浏览与评论热度排序
+func (a *articleService) UserGetTrendingArticle(ctx context.Context,
+	request *types.UserGetTrendingArticleRequest) (*types.UserGetTrendingArticleResponse, error) {
+	size := request.Size
+	if size <= 0 {
+		size = a.config.RankingSnapshot().TrendingLimit()
+	}
+	size = min(size, trendingKeepSize)
+
+	articleIDs, err := a.redis.ZRevRange(ctx, cachekey.ArticleTrendingZSet().String(), 0, int64(size-1)).Result()
+	if err != nil {
+		a.logger.Error("failed to get article:trending:ZSet", zap.Error(err))
+		return nil, fmt.Errorf("failed to get article:trending:ZSet, err: %w", err)
+	}
+
+	articleList, err := a.getRankedArticleItems(ctx, articleIDs)
+	if err != nil {
+		return nil, err
+	}
+	response := &types.UserGetTrendingArticleResponse{}
+	response.Rows = articleList
+	response.Total = len(articleList)
+
+	return response, nil
+}
@@ -2,7 +2,6 @@ package article
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"sort"
@@ -11,243 +10,317 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 
+	"meta-api/app/model/article"
+	"meta-api/app/service/article/search"
 	"meta-api/common/cachekey"
 	"meta-api/common/constants"
 	"meta-api/common/idutil"
+	"meta-api/common/readcache"
 	"meta-api/common/types"
 )
 
-// UserGetArticleList 获取文章列表
+// UserGetArticleList 获取文章列表，置顶文章按后台顺序排在最前面，其余按发布时间倒序
 func (a *articleService) UserGetArticleList(ctx context.Context,
 	request *types.UserGetArticleListRequest) (*types.UserGetArticleListResponse, error) {
 
-	start := (request.Page - 1) * request.PageSize
-	stop := start + request.PageSize - 1
+	start := int64((request.Page - 1) * request.PageSize)
+	stop := start + int64(request.PageSize) - 1
 
-	// 获取文章 ID 有序集合
-	articleIDZSet, err := a.redis.ZRevRangeWithScores(ctx, cachekey.ArticleTimeZSet().String(), int64(start), int64(stop)).Result()
+	// 不公开的文章不出现在列表中；置顶文章只出现在列表头部，常规部分同样跳过
+	hidden, err := a.hiddenArticleIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pinnedIDs, err := a.pinnedArticleIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pinnedSet := make(map[string]struct{}, len(pinnedIDs))
+	excluded := make(map[string]struct{}, len(hidden)+len(pinnedIDs))
+	for id := range hidden {
+		excluded[id] = struct{}{}
+	}
+	for _, id := range pinnedIDs {
+		pinnedSet[id] = struct{}{}
+		excluded[id] = struct{}{}
+	}
+	timeKey := cachekey.ArticleTimeZSet().String()
+	excludedRanks, err := a.excludedRanks(ctx, timeKey, excluded)
 	if err != nil {
-		a.logger.Error("failed to get article:time:ZSet", zap.Error(err))
 		return nil, err
 	}
 
-	articleList := make([]types.UserGetArticleItem, 0)
-	for _, z := range articleIDZSet {
-		articleItem := types.UserGetArticleItem{}
-
-		articleItem.ID = z.Member.(string)
-		hashKey := cachekey.ArticleHash(articleItem.ID).String()
-		if exist := a.redis.Exists(ctx, hashKey); exist.Val() == 1 {
-			// 获取缓存数据
-			fields := []string{"title", "tagName", "describe", "createTime", "updateTime", "viewNum"}
-			result, err := a.redis.HMGet(ctx, hashKey, fields...).Result()
-			if err != nil {
-				a.logger.Error("get article info HMGet error", zap.Error(err))
-				return nil, fmt.Errorf("get article info HMGet error, err: %w", err)
-			}
-			articleItem.Title = result[0].(string)
-			articleItem.TagName = result[1].(string)
-			articleItem.Describe = result[2].(string)
-			articleItem.CreateTime = result[3].(string)[:10]
-			articleItem.UpdateTime = result[4].(string)[:10]
-			viewNumStr := result[5].(string)
-			articleItem.ViewNum, err = strconv.Atoi(viewNumStr)
-			if err != nil {
-				a.logger.Error("parse string to int error", zap.Error(err))
-				return nil, fmt.Errorf("parse string to int error, err: %w", err)
-			}
-		} else {
-			// 查询数据库
-			id, err := idutil.ParseID("articleID", articleItem.ID)
-			if err != nil {
-				a.logger.Error("invalid article id", zap.Error(err))
-				return nil, err
-			}
-			articleInfo, err := a.articleModel.GetArticleDetailByID(ctx, id)
-			if err != nil {
-				a.logger.Error("get article detail by id error", zap.Error(err))
-				return nil, fmt.Errorf("get article detail by id error, err: %w", err)
+	pinnedCount := int64(len(pinnedIDs))
+	articleIDs := make([]string, 0, request.PageSize)
+	if start < pinnedCount {
+		articleIDs = append(articleIDs, pinnedIDs[start:min(stop+1, pinnedCount)]...)
+	}
+	if need := stop - start + 1 - int64(len(articleIDs)); need > 0 {
+		windowStart, windowStop, skip := excludedPageWindow(excludedRanks, pinnedCount, start, stop)
+		// 获取文章 ID 有序集合
+		window, err := a.redis.ZRevRange(ctx, timeKey, windowStart, windowStop).Result()
+		if err != nil {
+			a.logger.Error("failed to get article:time:ZSet", zap.Error(err))
+			return nil, err
+		}
+		for _, id := range window {
+			if _, ok := excluded[id]; ok {
+				continue
 			}
-
-			// 设置缓存
-			mapData := map[string]any{
-				"id":         articleInfo.ID,
-				"title":      articleInfo.Title,
-				"describe":   articleInfo.Describe,
-				"content":    articleInfo.Content,
-				"viewNum":    articleInfo.ViewNum,
-				"createTime": articleInfo.CreateTime.Format(constants.TimeLayoutToSecond),
-				"updateTime": articleInfo.UpdateTime.Format(constants.TimeLayoutToSecond),
-				"tagID":      articleTagIDValue(articleInfo.TagID),
-				"tagName":    articleInfo.TagName,
+			if skip > 0 {
+				skip--
+				continue
 			}
-			if err = a.redis.HMSet(ctx, cachekey.ArticleHash(articleItem.ID).String(), mapData).Err(); err != nil {
-				a.logger.Error("redis set article hash error", zap.Error(err))
-				return nil, fmt.Errorf("redis set article hash error: %w", err)
+			if need == 0 {
+				break
 			}
+			articleIDs = append(articleIDs, id)
+			need--
+		}
+	}
 
-			// 返回数据
-			articleItem.Title = articleInfo.Title
-			articleItem.TagName = articleInfo.TagName
-			articleItem.Describe = articleInfo.Describe
-			articleItem.CreateTime = articleInfo.CreateTime.Format(constants.TimeLayoutToDay)
-			articleItem.UpdateTime = articleInfo.UpdateTime.Format(constants.TimeLayoutToDay)
-			articleItem.ViewNum = int(articleInfo.ViewNum)
+	articleList := make([]types.UserGetArticleItem, 0, len(articleIDs))
+	for _, articleID := range articleIDs {
+		articleItem, err := a.getArticleListItem(ctx, articleID)
+		if err != nil {
+			return nil, err
 		}
+		_, articleItem.Pinned = pinnedSet[articleID]
 		articleList = append(articleList, articleItem)
 	}
 	response := &types.UserGetArticleListResponse{}
 	response.Rows = articleList
-	response.Total = int(a.redis.ZCard(ctx, cachekey.ArticleTimeZSet().String()).Val())
+	response.Total = int(a.redis.ZCard(ctx, timeKey).Val()) - len(excludedRanks) + len(pinnedIDs)
 	return response, nil
 }
 
-// UserGetArticleDetail 获取文章详情
-func (a *articleService) UserGetArticleDetail(ctx context.Context,
-	request *types.UserGetArticleDetailRequest) (*types.UserGetArticleDetailResponse, error) {
+// getArticleListItem 读取列表展示所需的文章字段，优先读 Redis Hash，未命中时查库并回填缓存
+func (a *articleService) getArticleListItem(ctx context.Context, articleID string) (types.UserGetArticleItem, error) {
+	articleItem := types.UserGetArticleItem{}
 
-	response := &types.UserGetArticleDetailResponse{}
-	hashKey := cachekey.ArticleHash(request.ID).String()
+	articleItem.ID = articleID
+	hashKey := cachekey.ArticleHash(articleItem.ID).String()
 	if exist := a.redis.Exists(ctx, hashKey); exist.Val() == 1 {
-		// 缓存查询
-		fields := []string{"title", "tagName", "content", "createTime", "updateTime"}
+		// 获取缓存数据
+		fields := []string{"title", "tagName", "describe", "createTime", "updateTime", "viewNum", "slug"}
 		result, err := a.redis.HMGet(ctx, hashKey, fields...).Result()
 		if err != nil {
 			a.logger.Error("get article info HMGet error", zap.Error(err))
-			return nil, err
-		}
-		response.ID = request.ID
-		response.Title = result[0].(string)
-		response.TagName = result[1].(string)
-		response.Content = result[2].(string)
-		response.CreateTime = result[3].(string)[:10]
-		response.UpdateTime = result[4].(string)[:10]
-	} else {
-		// 查询 MySQL
-		id, err := idutil.ParseID("articleID", request.ID)
-		if err != nil {
-			a.logger.Error("invalid article id", zap.Error(err))
-			return nil, err
+			return articleItem, fmt.Errorf("get article info HMGet error, err: %w", err)
 		}
-		articleInfo, err := a.articleModel.GetArticleDetailByID(ctx, id)
+		articleItem.Slug, _ = result[6].(string)
+		articleItem.Title = result[0].(string)
+		articleItem.Tags = article.SplitTagNames(result[1].(string))
+		articleItem.Describe = result[2].(string)
+		articleItem.CreateTime = result[3].(string)[:10]
+		articleItem.UpdateTime = result[4].(string)[:10]
+		viewNumStr := result[5].(string)
+		articleItem.ViewNum, err = strconv.Atoi(viewNumStr)
 		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return nil, fmt.Errorf("record not found: %w", err)
-			}
-			a.logger.Error("get article detail by id error", zap.Error(err))
-			return nil, fmt.Errorf("get article detail by id error, err: %w", err)
+			a.logger.Error("parse string to int error", zap.Error(err))
+			return articleItem, fmt.Errorf("parse string to int error, err: %w", err)
 		}
+		return articleItem, nil
+	}
 
-		// 设置缓存
-		mapData := map[string]any{
-			"id":         articleInfo.ID,
-			"title":      articleInfo.Title,
-			"describe":   articleInfo.Describe,
-			"content":    articleInfo.Content,
-			"viewNum":    articleInfo.ViewNum,
-			"createTime": articleInfo.CreateTime.Format(constants.TimeLayoutToSecond),
-			"updateTime": articleInfo.UpdateTime.Format(constants.TimeLayoutToSecond),
-			"tagID":      articleTagIDValue(articleInfo.TagID),
-			"tagName":    articleInfo.TagName,
-		}
-		if err = a.redis.HMSet(ctx, cachekey.ArticleHash(request.ID).String(), mapData).Err(); err != nil {
-			a.logger.Error("redis set article hash error", zap.Error(err))
-			return nil, fmt.Errorf("redis set article hash error: %w", err)
+	// 查询数据库
+	id, err := idutil.ParseID("articleID", articleItem.ID)
+	if err != nil {
+		a.logger.Error("invalid article id", zap.Error(err))
+		return articleItem, err
+	}
+	articleInfo, err := a.articleModel.GetArticleDetailByID(ctx, id)
+	if err != nil {
+		a.logger.Error("get article detail by id error", zap.Error(err))
+		return articleItem, fmt.Errorf("get article detail by id error, err: %w", err)
+	}
+
+	// 设置缓存
+	mapData := articleInfo.HashData()
+	if err = a.redis.HMSet(ctx, cachekey.ArticleHash(articleItem.ID).String(), mapData).Err(); err != nil {
+		a.logger.Error("redis set article hash error", zap.Error(err))
+		return articleItem, fmt.Errorf("redis set article hash error: %w", err)
+	}
+
+	// 返回数据
+	articleItem.Slug = articleInfo.Slug
+	articleItem.Title = articleInfo.Title
+	articleItem.Tags = nonNilTagNames(articleInfo.TagNames)
+	articleItem.Describe = articleInfo.Describe
+	articleItem.CreateTime = articleInfo.CreateTime.Format(constants.TimeLayoutToDay)
+	articleItem.UpdateTime = articleInfo.UpdateTime.Format(constants.TimeLayoutToDay)
+	articleItem.ViewNum = int(articleInfo.ViewNum)
+	return articleItem, nil
+}
+
+// UserGetArticleDetail 获取文章详情
+func (a *articleService) UserGetArticleDetail(ctx context.Context,
+	request *types.UserGetArticleDetailRequest) (*types.UserGetArticleDetailResponse, error) {
+
+	// 地址段可以是文章 ID 或 slug，旧 slug 由 handler 转为重定向
+	articleID, err := a.resolveArticleKey(ctx, request.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("record not found: %w", err)
 		}
+		return nil, err
+	}
 
-		// 返回数据
-		response.ID = request.ID
-		response.Title = articleInfo.Title
-		response.TagName = articleInfo.TagName
-		response.Content = articleInfo.Content
-		response.CreateTime = articleInfo.CreateTime.Format(constants.TimeLayoutToMinute)
-		response.UpdateTime = articleInfo.UpdateTime.Format(constants.TimeLayoutToMinute)
+	// 读穿缓存：并发未命中合并为一次回源，不存在的文章做负缓存
+	detail, err := a.detailCache.Get(ctx, cachekey.ArticleDetailCache(articleID).String(),
+		func(ctx context.Context) (articleDetailCacheEntry, error) {
+			return a.loadArticleDetail(ctx, articleID)
+		})
+	if err != nil {
+		if readcache.IsNotFound(err) {
+			return nil, fmt.Errorf("record not found: %w", err)
+		}
+		a.logger.Error("get article detail error", zap.Error(err))
+		return nil, fmt.Errorf("get article detail error, err: %w", err)
+	}
+	if err = checkArticleAccess(articleID, detail.Response.Title, detail.Visibility, detail.AccessVersion,
+		request.AccessTokens); err != nil {
+		return nil, err
 	}
 
+	response := &detail.Response
+	response.Protected = detail.Visibility == article.ArticleVisibilityProtected
+	response.Series = a.articleSeriesContext(ctx, articleID)
+
 	return response, nil
 }
 
 // UserSearchArticle 搜索文章
 //
-// 注意：MySQL 中的 article.view_num 由 cron 周期性回写，会落后于 Redis 中的真实浏览量
-// （热路径只 +1 到 article:view:ZSet 与 article:{id}:Hash）。直接返回 MySQL 的 view_num
-// 会与文章详情页 / 热门文章列表的浏览量不一致。
+// 优先走进程内全文索引（BM25 打分 + 高亮片段）；索引尚未构建完成时（例如启动预热失败）
+// 回退到 MySQL LIKE 检索，保证搜索可用。
 //
-// 这里在 MySQL 检索结果之上，用 article:view:ZSet 中的 score 校正每条结果的 view_num，
-// ZSet 不存在该 member 时（极少见，比如缓存预热未覆盖到）才回退到 MySQL 的值兜底。
+// 注意：MySQL 中的 article.view_num 由 cron 周期性回写，会落后于 Redis 中的真实浏览量
+// （热路径只 +1 到 article:view:ZSet 与 article:{id}:Hash），索引里的浏览量同样来自 MySQL。
+// 这里用 article:view:ZSet 中的 score 校正每条结果的 view_num，
+// ZSet 不存在该 member 时（极少见，比如缓存预热未覆盖到）才回退到原值兜底。
 func (a *articleService) UserSearchArticle(ctx context.Context,
 	request *types.UserSearchArticleRequest) (*types.UserSearchArticleResponse, error) {
 
 	limit := request.PageSize
 	offset := (request.Page - 1) * request.PageSize
 	word := strings.TrimSpace(request.Word)
-	articleList, total, err := a.articleModel.SearchArticle(ctx, word, limit, offset)
-	if err != nil {
-		a.logger.Error("failed to search article", zap.Error(err))
-		return nil, fmt.Errorf("failed to search article, err: %w", err)
+
+	var (
+		rows  []types.UserSearchArticleItem
+		total int
+	)
+	if a.searchIndex.Ready() {
+		hits, hitTotal := a.searchIndex.Search(word, offset, limit)
+		rows = make([]types.UserSearchArticleItem, 0, len(hits))
+		for _, hit := range hits {
+			rows = append(rows, types.UserSearchArticleItem{
+				ID:             strconv.FormatUint(hit.ID, 10),
+				Title:          hit.Title,
+				Describe:       hit.Describe,
+				CreateTime:     hit.CreateTime.Format(constants.TimeLayoutToDay),
+				ViewNum:        int(hit.ViewNum),
+				Score:          hit.Score,
+				TitleHighlight: hit.TitleHighlight,
+				Snippet:        hit.Snippet,
+			})
+		}
+		total = hitTotal
+	} else {
+		articleList, count, err := a.articleModel.SearchArticle(ctx, word, limit, offset)
+		if err != nil {
+			a.logger.Error("failed to search article", zap.Error(err))
+			return nil, fmt.Errorf("failed to search article, err: %w", err)
+		}
+		terms := search.QueryTerms(word)
+		rows = make([]types.UserSearchArticleItem, 0, len(articleList))
+		for _, item := range articleList {
+			rows = append(rows, types.UserSearchArticleItem{
+				ID:             strconv.FormatUint(item.ID, 10),
+				Title:          item.Title,
+				Describe:       item.Describe,
+				CreateTime:     item.CreateTime.Format(constants.TimeLayoutToDay),
+				ViewNum:        int(item.ViewNum),
+				TitleHighlight: search.Highlight(item.Title, terms, 0),
+				Snippet:        search.Highlight(item.Describe, terms, 0),
+			})
+		}
+		total = int(count)
 	}
 
 	// 用 Redis ZSet 中的 score 校正浏览量，pipeline 一次拿到所有结果
 	// 用 ZScore 而不是 ZMScore，方便通过 redis.Nil 区分「不存在」与「分数恰好为 0」
 	viewZSetKey := cachekey.ArticleViewZSet().String()
-	scoreCmds := make([]*redis.FloatCmd, len(articleList))
-	if len(articleList) > 0 {
+	scoreCmds := make([]*redis.FloatCmd, len(rows))
+	if len(rows) > 0 {
 		_, pipeErr := a.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
-			for i, item := range articleList {
-				scoreCmds[i] = pipe.ZScore(ctx, viewZSetKey, strconv.FormatUint(item.ID, 10))
+			for i, item := range rows {
+				scoreCmds[i] = pipe.ZScore(ctx, viewZSetKey, item.ID)
 			}
 			return nil
 		})
-		// pipeline 整体失败仅记录日志、降级使用 MySQL 的 view_num，不阻塞搜索
+		// pipeline 整体失败仅记录日志、降级使用原有的 view_num，不阻塞搜索
 		if pipeErr != nil && !errors.Is(pipeErr, redis.Nil) {
 			a.logger.Warn("failed to pipeline ZScore for view num correction",
 				zap.Error(pipeErr))
 		}
 	}
-
-	rows := make([]types.UserGetArticleItem, 0, len(articleList))
-	for i, item := range articleList {
-		viewNum := int(item.ViewNum)
-		if scoreCmds[i] != nil {
-			if score, scoreErr := scoreCmds[i].Result(); scoreErr == nil {
-				viewNum = int(score)
-			} else if !errors.Is(scoreErr, redis.Nil) {
-				// 单条失败（非 not-found）仅打日志，不影响该条返回
-				a.logger.Warn("zscore failed for article",
-					zap.Uint64("articleID", item.ID), zap.Error(scoreErr))
-			}
+	for i := range rows {
+		if scoreCmds[i] == nil {
+			continue
+		}
+		if score, scoreErr := scoreCmds[i].Result(); scoreErr == nil {
+			rows[i].ViewNum = int(score)
+		} else if !errors.Is(scoreErr, redis.Nil) {
+			// 单条失败（非 not-found）仅打日志，不影响该条返回
+			a.logger.Warn("zscore failed for article",
+				zap.String("articleID", rows[i].ID), zap.Error(scoreErr))
 		}
-
-		rows = append(rows, types.UserGetArticleItem{
-			ID:         strconv.Itoa(int(item.ID)),
-			Title:      item.Title,
-			Describe:   item.Describe,
-			ViewNum:    viewNum,
-			CreateTime: item.CreateTime.Format(constants.TimeLayoutToDay),
-		})
 	}
+
 	response := &types.UserSearchArticleResponse{}
 	response.Rows = rows
-	response.Total = int(total)
+	response.Total = total
 
 	return response, nil
 }
 
 // UserGetHotArticle 获取热门文章
 func (a *articleService) UserGetHotArticle(ctx context.Context) (*types.UserGetHotArticleResponse, error) {
-	articleIDZSet, err := a.redis.ZRevRangeWithScores(ctx, cachekey.ArticleViewZSet().String(), 0, 2).Result()
+	limit := a.config.RankingSnapshot().HotLimit()
+	hidden, err := a.hiddenArticleIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 多取不公开文章的篇数，过滤后仍能凑满榜单
+	articleIDZSet, err := a.redis.ZRevRange(ctx, cachekey.ArticleViewZSet().String(), 0,
+		int64(limit+len(hidden)-1)).Result()
 	if err != nil {
 		a.logger.Error("failed to get article:view:ZSet", zap.Error(err))
 		return nil, fmt.Errorf("failed to get article:view:ZSet, err: %w", err)
 	}
 
-	// 获取文章浏览量
-	articleList := make([]types.GetHotArticleItem, 0)
-	for _, z := range articleIDZSet {
+	articleList, err := a.getRankedArticleItems(ctx, filterHiddenArticleIDs(articleIDZSet, hidden, limit))
+	if err != nil {
+		return nil, err
+	}
+	response := &types.UserGetHotArticleResponse{}
+	response.Rows = articleList
+	response.Total = len(articleList)
+
+	return response, nil
+}
+
+// getRankedArticleItems 按榜单顺序读取文章标题与浏览量，优先读 Redis Hash，未命中时查库并回填缓存；
+// 榜单中已不存在的文章直接跳过
+func (a *articleService) getRankedArticleItems(ctx context.Context, articleIDs []string) ([]types.GetHotArticleItem, error) {
+	articleList := make([]types.GetHotArticleItem, 0, len(articleIDs))
+	for _, articleID := range articleIDs {
 		articleItem := types.GetHotArticleItem{}
 
-		articleItem.ID = z.Member.(string)
+		articleItem.ID = articleID
 		hashKey := cachekey.ArticleHash(articleItem.ID).String()
 		if exist := a.redis.Exists(ctx, hashKey); exist.Val() == 1 {
 			fields := []string{"title", "viewNum"}
@@ -269,23 +342,16 @@ func (a *articleService) UserGetHotArticle(ctx context.Context) (*types.UserGetH
 				return nil, fmt.Errorf("invalid article id, err: %w", err)
 			}
 			articleInfo, err := a.articleModel.GetArticleDetailByID(ctx, id)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
 			if err != nil {
 				a.logger.Error("get article detail by id error", zap.Error(err))
 				return nil, fmt.Errorf("get article detail by id error, err: %w", err)
 			}
 
 			// 设置缓存
-			mapData := map[string]any{
-				"id":         articleInfo.ID,
-				"title":      articleInfo.Title,
-				"describe":   articleInfo.Describe,
-				"content":    articleInfo.Content,
-				"viewNum":    articleInfo.ViewNum,
-				"createTime": articleInfo.CreateTime.Format(constants.TimeLayoutToSecond),
-				"updateTime": articleInfo.UpdateTime.Format(constants.TimeLayoutToSecond),
-				"tagID":      articleTagIDValue(articleInfo.TagID),
-				"tagName":    articleInfo.TagName,
-			}
+			mapData := articleInfo.HashData()
 			if err = a.redis.HMSet(ctx, cachekey.ArticleHash(articleItem.ID).String(), mapData).Err(); err != nil {
 				a.logger.Error("redis set article hash error", zap.Error(err))
 				return nil, fmt.Errorf("redis set article hash error: %w", err)
@@ -296,11 +362,7 @@ func (a *articleService) UserGetHotArticle(ctx context.Context) (*types.UserGetH
 		}
 		articleList = append(articleList, articleItem)
 	}
-	response := &types.UserGetHotArticleResponse{}
-	response.Rows = articleList
-	response.Total = len(articleList)
-
-	return response, nil
+	return articleList, nil
 }
 
 // UserGetTimeline 获取文章归档
@@ -312,9 +374,16 @@ func (a *articleService) UserGetTimeline(ctx context.Context) (*types.GetTimelin
 		a.logger.Error("failed to get article:time:ZSet", zap.Error(err))
 		return nil, fmt.Errorf("failed to get article:time:ZSet, err: %w", err)
 	}
+	hidden, err := a.hiddenArticleIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
 	groupedArticles := make(map[string][]types.GetTimelineListItem)
 	for _, z := range articleIDZSet {
 		articleID := z.Member.(string)
+		if _, ok := hidden[articleID]; ok {
+			continue
+		}
 		hashKey := cachekey.ArticleHash(articleID).String()
 		if exist := a.redis.Exists(ctx, hashKey); exist.Val() == 1 {
 			result, err := a.redis.HMGet(ctx, hashKey, []string{"title", "createTime"}...).Result()
@@ -343,17 +412,7 @@ func (a *articleService) UserGetTimeline(ctx context.Context) (*types.GetTimelin
 			}
 
 			// 设置缓存
-			mapData := map[string]any{
-				"id":         articleInfo.ID,
-				"title":      articleInfo.Title,
-				"describe":   articleInfo.Describe,
-				"content":    articleInfo.Content,
-				"viewNum":    articleInfo.ViewNum,
-				"createTime": articleInfo.CreateTime.Format(constants.TimeLayoutToSecond),
-				"updateTime": articleInfo.UpdateTime.Format(constants.TimeLayoutToSecond),
-				"tagID":      articleTagIDValue(articleInfo.TagID),
-				"tagName":    articleInfo.TagName,
-			}
+			mapData := articleInfo.HashData()
 			if err = a.redis.HMSet(ctx, cachekey.ArticleHash(articleID).String(), mapData).Err(); err != nil {
 				a.logger.Error("redis set article hash error", zap.Error(err))
 				return nil, fmt.Errorf("redis set article hash error: %w", err)
@@ -375,14 +434,16 @@ func (a *articleService) UserGetTimeline(ctx context.Context) (*types.GetTimelin
 	sort.Slice(years, func(i, j int) bool {
 		return years[i] > years[j]
 	})
+	total := 0
 	for _, year := range years {
 		rows = append(rows, types.GetTimelineRowsItem{
 			Time: year,
 			List: groupedArticles[year],
 		})
+		total += len(groupedArticles[year])
 	}
 	response.Rows = rows
-	response.Total = len(articleIDZSet)
+	response.Total = total
 
 	return response, nil
 }
@@ -0,0 +1,56 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"meta-api/app/model/article"
+	"meta-api/common/constants"
+	"meta-api/common/types"
+)
+
+// ArticleVersionConflictError 保存时版本号已过期，Current 为服务端当前内容
+type ArticleVersionConflictError struct {
+	Current *types.AdminArticleConflictResponse
+}
+
+func (e *ArticleVersionConflictError) Error() string {
+	return "article version conflict, current version " + strconv.FormatUint(e.Current.Version, 10)
+}
+
+// AsArticleVersionConflictError 判断是否为保存时的版本冲突
+func AsArticleVersionConflictError(err error) (*ArticleVersionConflictError, bool) {
+	var conflict *ArticleVersionConflictError
+	ok := errors.As(err, &conflict)
+	return conflict, ok
+}
+
+func newArticleVersionConflictError(detail *article.Detail) *ArticleVersionConflictError {
+	return &ArticleVersionConflictError{Current: &types.AdminArticleConflictResponse{
+		ID:         strconv.FormatUint(detail.ID, 10),
+		Version:    detail.Version,
+		Title:      detail.Title,
+		Tags:       nonNilTagNames(detail.TagNames),
+		Describe:   detail.Describe,
+		Content:    detail.Content,
+		UpdateTime: detail.UpdateTime.Format(constants.TimeLayoutToSecond),
+	}}
+}
+
+// articleVersionConflict 写入时版本校验失败，重新读取服务端当前内容组装冲突错误
+func (a *articleService) articleVersionConflict(ctx context.Context, id uint64, draft bool) error {
+	var (
+		detail *article.Detail
+		err    error
+	)
+	if draft {
+		detail, err = a.articleModel.GetArticleDraftDetailByID(ctx, id)
+	} else {
+		detail, err = a.articleModel.GetArticleDetailByID(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+	return newArticleVersionConflictError(detail)
+}
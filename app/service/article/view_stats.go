@@ -0,0 +1,412 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"meta-api/app/model/article"
+	"meta-api/app/service/article/viewstats"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/idutil"
+	"meta-api/common/types"
+)
+
+const (
+	viewStatsDefaultDays = 30
+	// visitorRollupTTL 按周、按月合并的访客 HyperLogLog 保留时长，覆盖查询窗口上限（365 天）
+	visitorRollupTTL = 400 * 24 * time.Hour
+)
+
+// FlushViewStats 把 Redis 中按天分桶的浏览量与去重访客数回写到 MySQL，并把按天的访客 HyperLogLog
+// 合并到所在周、所在月的汇总。分桶保存的是当天累计值、PFMERGE 为并集，回写可重复执行；
+// 已结束的日期回写成功后移出待回写集合
+func (a *articleService) FlushViewStats(ctx context.Context) error {
+	pendingKey := cachekey.ArticleViewPendingDaySet().String()
+	days, err := a.redis.SMembers(ctx, pendingKey).Result()
+	if err != nil {
+		a.logger.Error("failed to list pending view stats days", zap.Error(err))
+		return fmt.Errorf("failed to list pending view stats days: %w", err)
+	}
+
+	today := viewstats.DayKey(time.Now())
+	total := 0
+	for _, day := range days {
+		dayTime, err := time.ParseInLocation(viewstats.DayLayout, day, viewstats.Location())
+		if err != nil {
+			a.redis.SRem(ctx, pendingKey, day)
+			continue
+		}
+		counts, err := a.redis.HGetAll(ctx, cachekey.ArticleViewDayHash(day).String()).Result()
+		if err != nil {
+			return fmt.Errorf("failed to get view stats of %s: %w", day, err)
+		}
+
+		rows := make([]article.ArticleViewDaily, 0, len(counts))
+		for field, value := range counts {
+			articleID, err := strconv.ParseUint(field, 10, 64)
+			if err != nil || articleID == 0 {
+				continue
+			}
+			views, err := strconv.ParseUint(value, 10, 64)
+			if err != nil || views == 0 {
+				continue
+			}
+			rows = append(rows, article.ArticleViewDaily{ArticleID: articleID, Day: day, Views: views})
+		}
+		site, err := a.rollUpVisitors(ctx, dayTime, rows)
+		if err != nil {
+			return err
+		}
+		if err = a.articleModel.UpsertArticleViewDaily(ctx, rows, site); err != nil {
+			a.logger.Error("failed to persist view stats", zap.String("day", day), zap.Error(err))
+			return err
+		}
+		total += len(rows)
+		if day < today {
+			if err = a.redis.SRem(ctx, pendingKey, day).Err(); err != nil {
+				a.logger.Warn("failed to remove flushed view stats day", zap.String("day", day), zap.Error(err))
+			}
+		}
+	}
+	if total > 0 {
+		a.logger.Info("article view stats flushed", zap.Int("days", len(days)), zap.Int("rows", total))
+	}
+	return nil
+}
+
+// rollUpVisitors 在同一个 pipeline 中读取当天各文章与全站的去重计数并写回 rows，
+// 同时把当天的 HyperLogLog 合并到周、月汇总
+func (a *articleService) rollUpVisitors(ctx context.Context, day time.Time,
+	rows []article.ArticleViewDaily) (*article.SiteViewDaily, error) {
+	dayKey := viewstats.DayKey(day)
+	rollups := [][2]string{
+		{viewstats.UnitWeek, viewstats.WeekKey(day)},
+		{viewstats.UnitMonth, viewstats.MonthKey(day)},
+	}
+	merge := func(pipe redis.Pipeliner, source string, target func(unit string, period string) string) {
+		for _, rollup := range rollups {
+			key := target(rollup[0], rollup[1])
+			pipe.PFMerge(ctx, key, source)
+			pipe.Expire(ctx, key, visitorRollupTTL)
+		}
+	}
+
+	pipe := a.redis.Pipeline()
+	visitorCmds := make([]*redis.IntCmd, 0, len(rows))
+	for _, row := range rows {
+		articleID := strconv.FormatUint(row.ArticleID, 10)
+		source := cachekey.ArticleVisitorHLL(viewstats.UnitDay, dayKey, articleID).String()
+		visitorCmds = append(visitorCmds, pipe.PFCount(ctx, source))
+		merge(pipe, source, func(unit string, period string) string {
+			return cachekey.ArticleVisitorHLL(unit, period, articleID).String()
+		})
+	}
+	siteSource := cachekey.SiteVisitorHLL(viewstats.UnitDay, dayKey).String()
+	siteVisitors := pipe.PFCount(ctx, siteSource)
+	merge(pipe, siteSource, func(unit string, period string) string {
+		return cachekey.SiteVisitorHLL(unit, period).String()
+	})
+	uniqueSource := cachekey.SiteUniqueViewHLL(viewstats.UnitDay, dayKey).String()
+	uniqueViews := pipe.PFCount(ctx, uniqueSource)
+	merge(pipe, uniqueSource, func(unit string, period string) string {
+		return cachekey.SiteUniqueViewHLL(unit, period).String()
+	})
+	if _, err := pipe.Exec(ctx); err != nil {
+		a.logger.Error("failed to roll up article visitors", zap.String("day", dayKey), zap.Error(err))
+		return nil, fmt.Errorf("failed to roll up article visitors: %w", err)
+	}
+
+	for i := range rows {
+		rows[i].Visitors = uint64(visitorCmds[i].Val())
+	}
+	return &article.SiteViewDaily{
+		Day:         dayKey,
+		Visitors:    uint64(siteVisitors.Val()),
+		UniqueViews: uint64(uniqueViews.Val()),
+	}, nil
+}
+
+// AdminGetArticleViewStats 单篇文章的浏览量与去重访客序列，以及首发 / 长尾流量构成
+func (a *articleService) AdminGetArticleViewStats(ctx context.Context,
+	request *types.AdminGetArticleViewStatsRequest) (*types.AdminGetArticleViewStatsResponse, error) {
+	id, err := idutil.ParseID("articleID", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	detail, err := a.articleModel.GetArticleDetailByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := a.articleModel.ListArticleViewDaily(ctx, id)
+	if err != nil {
+		a.logger.Error("failed to list article view daily", zap.Error(err))
+		return nil, err
+	}
+	live, err := a.liveDayCounts(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	daily := viewstats.Merge(dailyViewCounts(stored), live)
+	summary := viewstats.Summarize(daily, detail.CreateTime)
+
+	response := &types.AdminGetArticleViewStatsResponse{
+		ID:            request.ID,
+		Title:         detail.Title,
+		PublishTime:   detail.CreateTime.Format(constants.TimeLayoutToMinute),
+		TrackedViews:  summary.Total,
+		LaunchViews:   summary.LaunchViews,
+		LongTailViews: summary.LongTailViews,
+		PeakDay:       summary.PeakKey,
+		PeakViews:     summary.PeakViews,
+	}
+	var points []viewstats.Point
+	response.Granularity, points, err = a.viewStatsPoints(ctx, request.Days, request.Granularity, request.ID, daily)
+	if err != nil {
+		return nil, err
+	}
+	response.Points, response.Total = viewStatsResponsePoints(points)
+	return response, nil
+}
+
+// AdminGetSiteViewStats 全站浏览量、去重访客与去重浏览序列
+func (a *articleService) AdminGetSiteViewStats(ctx context.Context,
+	request *types.AdminGetSiteViewStatsRequest) (*types.AdminGetSiteViewStatsResponse, error) {
+	days := request.Days
+	if days <= 0 {
+		days = viewStatsDefaultDays
+	}
+	var daily map[string]viewstats.Counts
+	if request.Granularity != viewstats.UnitHour {
+		now := time.Now()
+		from := viewstats.DayKey(now.AddDate(0, 0, -(days - 1)))
+		// 按周 / 按月汇总时首个周期可能早于窗口起点
+		if periods := viewstats.Periods(now, days, request.Granularity); len(periods) > 0 {
+			from = periods[0].FirstDay
+		}
+		stored, err := a.articleModel.ListSiteViewDaily(ctx, from, viewstats.DayKey(now))
+		if err != nil {
+			a.logger.Error("failed to list site view daily", zap.Error(err))
+			return nil, err
+		}
+		live, err := a.liveDayCounts(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		daily = viewstats.Merge(dailyViewCounts(stored), live)
+	}
+
+	granularity, points, err := a.viewStatsPoints(ctx, days, request.Granularity, "", daily)
+	if err != nil {
+		return nil, err
+	}
+	response := &types.AdminGetSiteViewStatsResponse{Granularity: granularity}
+	response.Points, response.Total = viewStatsResponsePoints(points)
+	return response, nil
+}
+
+// viewStatsPoints 按粒度生成序列：
+//   - hour：只有浏览量，数据只在 Redis 中保留有限天数，超出部分不返回；
+//   - day：来自 MySQL 与尚未回写的 Redis 分桶；
+//   - week / month：浏览量按天相加，去重访客读取按周期合并的 HyperLogLog。
+func (a *articleService) viewStatsPoints(ctx context.Context, days int, granularity string, articleID string,
+	daily map[string]viewstats.Counts) (string, []viewstats.Point, error) {
+	if days <= 0 {
+		days = viewStatsDefaultDays
+	}
+	now := time.Now()
+	switch granularity {
+	case viewstats.UnitHour:
+		hours := days * 24
+		if maxHours := int(a.config.ViewStatsSnapshot().HourlyRetention() / time.Hour); hours > maxHours {
+			hours = maxHours
+		}
+		points := viewstats.HourSeries(now, hours, nil)
+		keys := make([]string, 0, len(points))
+		for _, point := range points {
+			keys = append(keys, cachekey.ArticleViewHourHash(point.Key).String())
+		}
+		counts, err := a.sumViewBuckets(ctx, keys, articleID)
+		if err != nil {
+			return "", nil, err
+		}
+		for i := range points {
+			points[i].Views = counts[i]
+		}
+		return viewstats.UnitHour, points, nil
+	case viewstats.UnitWeek, viewstats.UnitMonth:
+		points, err := a.periodPoints(ctx, now, days, granularity, articleID, daily)
+		if err != nil {
+			return "", nil, err
+		}
+		return granularity, points, nil
+	default:
+		return viewstats.UnitDay, viewstats.DaySeries(now, days, daily), nil
+	}
+}
+
+// periodPoints 按周 / 按月汇总。当前周期的合并结果最多滞后一个回写周期，
+// 这里与当天的 HyperLogLog 一起 PFCOUNT 取并集，保证包含今天的访客
+func (a *articleService) periodPoints(ctx context.Context, now time.Time, days int, unit string, articleID string,
+	daily map[string]viewstats.Counts) ([]viewstats.Point, error) {
+	today := viewstats.DayKey(now)
+	hll := func(unit string, period string) string {
+		if articleID != "" {
+			return cachekey.ArticleVisitorHLL(unit, period, articleID).String()
+		}
+		return cachekey.SiteVisitorHLL(unit, period).String()
+	}
+
+	periods := viewstats.Periods(now, days, unit)
+	pipe := a.redis.Pipeline()
+	visitorCmds := make([]*redis.IntCmd, 0, len(periods))
+	uniqueCmds := make([]*redis.IntCmd, 0, len(periods))
+	for _, period := range periods {
+		visitorKeys := []string{hll(unit, period.Key)}
+		uniqueKeys := []string{cachekey.SiteUniqueViewHLL(unit, period.Key).String()}
+		if today >= period.FirstDay && today <= period.LastDay {
+			visitorKeys = append(visitorKeys, hll(viewstats.UnitDay, today))
+			uniqueKeys = append(uniqueKeys, cachekey.SiteUniqueViewHLL(viewstats.UnitDay, today).String())
+		}
+		visitorCmds = append(visitorCmds, pipe.PFCount(ctx, visitorKeys...))
+		if articleID == "" {
+			uniqueCmds = append(uniqueCmds, pipe.PFCount(ctx, uniqueKeys...))
+		}
+	}
+	if len(periods) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			a.logger.Error("failed to count period visitors", zap.Error(err))
+			return nil, fmt.Errorf("failed to count period visitors: %w", err)
+		}
+	}
+
+	points := make([]viewstats.Point, 0, len(periods))
+	for i, period := range periods {
+		point := viewstats.Point{Key: period.Key}
+		point.Views = viewstats.SumViews(daily, period.FirstDay, period.LastDay)
+		point.Visitors = uint64(visitorCmds[i].Val())
+		if articleID == "" {
+			point.UniqueViews = uint64(uniqueCmds[i].Val())
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// liveDayCounts 读取尚未结束回写的日期分桶中的浏览量与去重计数；articleID 为空时返回全站合计
+func (a *articleService) liveDayCounts(ctx context.Context, articleID string) (map[string]viewstats.Counts, error) {
+	days, err := a.redis.SMembers(ctx, cachekey.ArticleViewPendingDaySet().String()).Result()
+	if err != nil {
+		a.logger.Error("failed to list pending view stats days", zap.Error(err))
+		return nil, fmt.Errorf("failed to list pending view stats days: %w", err)
+	}
+	keys := make([]string, 0, len(days))
+	for _, day := range days {
+		keys = append(keys, cachekey.ArticleViewDayHash(day).String())
+	}
+	views, err := a.sumViewBuckets(ctx, keys, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := a.redis.Pipeline()
+	visitorCmds := make([]*redis.IntCmd, 0, len(days))
+	uniqueCmds := make([]*redis.IntCmd, 0, len(days))
+	for _, day := range days {
+		if articleID != "" {
+			visitorCmds = append(visitorCmds, pipe.PFCount(ctx, cachekey.ArticleVisitorHLL(viewstats.UnitDay, day, articleID).String()))
+			continue
+		}
+		visitorCmds = append(visitorCmds, pipe.PFCount(ctx, cachekey.SiteVisitorHLL(viewstats.UnitDay, day).String()))
+		uniqueCmds = append(uniqueCmds, pipe.PFCount(ctx, cachekey.SiteUniqueViewHLL(viewstats.UnitDay, day).String()))
+	}
+	if len(days) > 0 {
+		if _, err = pipe.Exec(ctx); err != nil {
+			a.logger.Error("failed to count live visitors", zap.Error(err))
+			return nil, fmt.Errorf("failed to count live visitors: %w", err)
+		}
+	}
+
+	live := make(map[string]viewstats.Counts, len(days))
+	for i, day := range days {
+		count := viewstats.Counts{Views: views[i], Visitors: uint64(visitorCmds[i].Val())}
+		if articleID == "" {
+			count.UniqueViews = uint64(uniqueCmds[i].Val())
+		}
+		if count != (viewstats.Counts{}) {
+			live[day] = count
+		}
+	}
+	return live, nil
+}
+
+// sumViewBuckets 通过一次 pipeline 读取多个分桶中某篇文章的计数，articleID 为空时取各分桶所有文章之和
+func (a *articleService) sumViewBuckets(ctx context.Context, keys []string, articleID string) ([]uint64, error) {
+	counts := make([]uint64, len(keys))
+	if len(keys) == 0 {
+		return counts, nil
+	}
+	pipe := a.redis.Pipeline()
+	cmds := make([]redis.Cmder, 0, len(keys))
+	for _, key := range keys {
+		if articleID != "" {
+			cmds = append(cmds, pipe.HGet(ctx, key, articleID))
+		} else {
+			cmds = append(cmds, pipe.HVals(ctx, key))
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		a.logger.Error("failed to read view stats buckets", zap.Error(err))
+		return nil, fmt.Errorf("failed to read view stats buckets: %w", err)
+	}
+	for i, cmd := range cmds {
+		switch c := cmd.(type) {
+		case *redis.StringCmd:
+			if views, err := c.Uint64(); err == nil {
+				counts[i] = views
+			}
+		case *redis.StringSliceCmd:
+			for _, value := range c.Val() {
+				if views, err := strconv.ParseUint(value, 10, 64); err == nil {
+					counts[i] += views
+				}
+			}
+		}
+	}
+	return counts, nil
+}
+
+func dailyViewCounts(rows []article.DailyViewCount) map[string]viewstats.Counts {
+	counts := make(map[string]viewstats.Counts, len(rows))
+	for _, row := range rows {
+		counts[row.Day] = viewstats.Counts{Views: row.Views, Visitors: row.Visitors, UniqueViews: row.UniqueViews}
+	}
+	return counts
+}
+
+func viewStatsResponsePoints(points []viewstats.Point) ([]types.AdminViewStatsPoint, uint64) {
+	result := make([]types.AdminViewStatsPoint, 0, len(points))
+	var total uint64
+	for _, point := range points {
+		result = append(result, types.AdminViewStatsPoint{
+			Time:        point.Key,
+			Views:       point.Views,
+			Visitors:    point.Visitors,
+			UniqueViews: point.UniqueViews,
+		})
+		total += point.Views
+	}
+	return result, total
+}
+
+func IsArticleViewStatsNotFoundError(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,45 @@
+package viewstats
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// 趋势事件类型，对应 Redis 趋势小时分桶中 field 的后缀
+const (
+	TrendingEventView    = "view"
+	TrendingEventComment = "comment"
+)
+
+// TrendingField 返回趋势小时分桶中某篇文章某类事件的 field
+func TrendingField(articleID string, event string) string {
+	return articleID + ":" + event
+}
+
+// ParseTrendingField 解析 TrendingField 生成的 field
+func ParseTrendingField(field string) (string, string, bool) {
+	articleID, event, ok := strings.Cut(field, ":")
+	if !ok || articleID == "" || (event != TrendingEventView && event != TrendingEventComment) {
+		return "", "", false
+	}
+	return articleID, event, true
+}
+
+// TrendingScore 按指数衰减汇总按小时分桶（key 为 HourKey 格式）的事件权重：
+// 每个桶以其中点距 now 的时长计算衰减，权重每经过一个 halfLife 减半
+func TrendingScore(buckets map[string]float64, now time.Time, halfLife time.Duration) float64 {
+	var score float64
+	for hour, points := range buckets {
+		start, err := time.ParseInLocation(HourLayout, hour, Location())
+		if err != nil {
+			continue
+		}
+		age := now.Sub(start.Add(30 * time.Minute))
+		if age < 0 {
+			age = 0
+		}
+		score += points * math.Pow(0.5, float64(age)/float64(halfLife))
+	}
+	return score
+}
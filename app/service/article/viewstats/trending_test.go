@@ -0,0 +1,39 @@
+package viewstats
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTrendingScoreDecaysByHalfLife(t *testing.T) {
+	now := time.Date(2024, 5, 2, 12, 30, 0, 0, Location())
+	buckets := map[string]float64{
+		"2024-05-02T12": 10, // 桶中点即 now，不衰减
+		"2024-05-01T12": 8,  // 一个半衰期前，权重减半
+		"bad":           100,
+	}
+	got := TrendingScore(buckets, now, 24*time.Hour)
+	if math.Abs(got-14) > 1e-9 {
+		t.Fatalf("score = %v, want 14", got)
+	}
+}
+
+func TestTrendingRecentBeatsOldPopular(t *testing.T) {
+	now := time.Date(2024, 5, 4, 0, 0, 0, 0, Location())
+	old := TrendingScore(map[string]float64{"2024-05-01T00": 100}, now, 12*time.Hour)
+	recent := TrendingScore(map[string]float64{"2024-05-03T20": 30}, now, 12*time.Hour)
+	if recent <= old {
+		t.Fatalf("recent %v should outrank old %v", recent, old)
+	}
+}
+
+func TestParseTrendingField(t *testing.T) {
+	id, event, ok := ParseTrendingField(TrendingField("42", TrendingEventComment))
+	if !ok || id != "42" || event != TrendingEventComment {
+		t.Fatalf("got %q %q %v", id, event, ok)
+	}
+	if _, _, ok = ParseTrendingField("42:like"); ok {
+		t.Fatal("unknown event should be rejected")
+	}
+}
@@ -0,0 +1,204 @@
+// Package viewstats 负责文章浏览量时间序列的分桶与图表数据整理。
+//
+// 浏览打点按站点时区（Asia/Shanghai）写入 Redis 的按天 / 按小时 Hash 与按天 HyperLogLog，
+// 按天的数据由定时任务回写到 MySQL，HyperLogLog 同时合并到按周、按月的汇总；
+// 浏览与评论事件另记入按小时的趋势分桶，按指数衰减计算趋势分数。这里只处理纯数据转换。
+package viewstats
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DayLayout Redis 按天分桶 Key 与 MySQL day 列的日期格式
+	DayLayout = "2006-01-02"
+	// HourLayout Redis 按小时分桶 Key 的时间格式
+	HourLayout = "2006-01-02T15"
+	// MonthLayout 按月汇总的周期格式
+	MonthLayout = "2006-01"
+
+	// LaunchDays 发布后计入"首发流量"的天数（含发布当天），之后的浏览计为长尾流量
+	LaunchDays = 7
+)
+
+// 序列粒度
+const (
+	UnitHour  = "hour"
+	UnitDay   = "day"
+	UnitWeek  = "week"
+	UnitMonth = "month"
+)
+
+// Location 站点时区，分桶与展示都按该时区划分日期
+func Location() *time.Location {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// DayKey 返回 t 所在日期（站点时区）
+func DayKey(t time.Time) string {
+	return t.In(Location()).Format(DayLayout)
+}
+
+// HourKey 返回 t 所在小时（站点时区）
+func HourKey(t time.Time) string {
+	return t.In(Location()).Format(HourLayout)
+}
+
+// WeekKey 返回 t 所在的 ISO 周，例如 2024-W18
+func WeekKey(t time.Time) string {
+	year, week := t.In(Location()).ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// MonthKey 返回 t 所在月份，例如 2024-05
+func MonthKey(t time.Time) string {
+	return t.In(Location()).Format(MonthLayout)
+}
+
+// Counts 一个时间桶内的计数。Visitors 为按指纹去重的访客数；
+// UniqueViews 为去重后的（访客, 文章）组合数，只在全站序列中有意义
+type Counts struct {
+	Views       uint64
+	Visitors    uint64
+	UniqueViews uint64
+}
+
+// Point 图表中的一个点
+type Point struct {
+	Key string
+	Counts
+}
+
+// DaySeries 返回截止到 end 所在日期、共 days 天的连续序列，缺失的日期补 0
+func DaySeries(end time.Time, days int, counts map[string]Counts) []Point {
+	start := startOfDay(end).AddDate(0, 0, -(days - 1))
+	points := make([]Point, 0, days)
+	for i := 0; i < days; i++ {
+		key := start.AddDate(0, 0, i).Format(DayLayout)
+		points = append(points, Point{Key: key, Counts: counts[key]})
+	}
+	return points
+}
+
+// HourSeries 返回截止到 end 所在小时、共 hours 小时的连续序列，缺失的小时补 0
+func HourSeries(end time.Time, hours int, counts map[string]Counts) []Point {
+	end = end.In(Location()).Truncate(time.Hour)
+	points := make([]Point, 0, hours)
+	for i := hours - 1; i >= 0; i-- {
+		key := end.Add(-time.Duration(i) * time.Hour).Format(HourLayout)
+		points = append(points, Point{Key: key, Counts: counts[key]})
+	}
+	return points
+}
+
+// Period 按周 / 按月汇总的一个周期，FirstDay / LastDay 为周期内首尾日期（含）
+type Period struct {
+	Key      string
+	FirstDay string
+	LastDay  string
+}
+
+// Periods 返回覆盖截止到 end 所在日期、共 days 天窗口的连续周期；首个周期从窗口起点所在周期的第一天开始
+func Periods(end time.Time, days int, unit string) []Period {
+	last := startOfDay(end)
+	start := last.AddDate(0, 0, -(days - 1))
+	switch unit {
+	case UnitWeek:
+		// ISO 周从周一开始
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	case UnitMonth:
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+	default:
+		return nil
+	}
+
+	periods := make([]Period, 0)
+	for !start.After(last) {
+		var next time.Time
+		var key string
+		if unit == UnitWeek {
+			next = start.AddDate(0, 0, 7)
+			key = WeekKey(start)
+		} else {
+			next = start.AddDate(0, 1, 0)
+			key = MonthKey(start)
+		}
+		periods = append(periods, Period{
+			Key:      key,
+			FirstDay: start.Format(DayLayout),
+			LastDay:  next.AddDate(0, 0, -1).Format(DayLayout),
+		})
+		start = next
+	}
+	return periods
+}
+
+// SumViews 汇总 [from, to] 日期范围内的浏览量；去重访客数不能按天相加，由调用方从按周期合并的 HyperLogLog 读取
+func SumViews(counts map[string]Counts, from string, to string) uint64 {
+	var total uint64
+	for key, count := range counts {
+		if key >= from && key <= to {
+			total += count.Views
+		}
+	}
+	return total
+}
+
+// Merge 合并已落库与 Redis 中尚未落库的计数。Redis 中保存的是当天累计值而非增量，
+// 同一天两边逐项取较大值，与回写时的 GREATEST 语义一致
+func Merge(stored map[string]Counts, live map[string]Counts) map[string]Counts {
+	merged := make(map[string]Counts, len(stored)+len(live))
+	for key, count := range stored {
+		merged[key] = count
+	}
+	for key, count := range live {
+		current := merged[key]
+		current.Views = max(current.Views, count.Views)
+		current.Visitors = max(current.Visitors, count.Visitors)
+		current.UniqueViews = max(current.UniqueViews, count.UniqueViews)
+		merged[key] = current
+	}
+	return merged
+}
+
+// Summary 文章流量构成
+type Summary struct {
+	Total         uint64
+	LaunchViews   uint64
+	LongTailViews uint64
+	PeakKey       string
+	PeakViews     uint64
+}
+
+// Summarize 按发布日期把按天浏览量拆分为首发期（发布后 LaunchDays 天内）与长尾两部分。
+// 发布之前的计数（例如下线后重新发布前的旧流量）计入长尾
+func Summarize(counts map[string]Counts, published time.Time) Summary {
+	launchStart := DayKey(published)
+	launchEnd := published.In(Location()).AddDate(0, 0, LaunchDays).Format(DayLayout)
+
+	var summary Summary
+	for key, count := range counts {
+		views := count.Views
+		summary.Total += views
+		if key >= launchStart && key < launchEnd {
+			summary.LaunchViews += views
+		} else {
+			summary.LongTailViews += views
+		}
+		if views > summary.PeakViews || (views == summary.PeakViews && views > 0 && key < summary.PeakKey) {
+			summary.PeakKey = key
+			summary.PeakViews = views
+		}
+	}
+	return summary
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.In(Location())
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
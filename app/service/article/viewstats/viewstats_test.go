@@ -0,0 +1,94 @@
+package viewstats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDaySeriesFillsMissingDays(t *testing.T) {
+	// 2024-05-02 01:00 CST，UTC 仍在 5 月 1 日，分桶应按站点时区
+	end := time.Date(2024, 5, 1, 17, 0, 0, 0, time.UTC)
+	got := DaySeries(end, 3, map[string]Counts{
+		"2024-04-30": {Views: 5, Visitors: 2},
+		"2024-05-02": {Views: 2, Visitors: 1},
+		"2024-04-01": {Views: 9},
+	})
+	want := []Point{
+		{Key: "2024-04-30", Counts: Counts{Views: 5, Visitors: 2}},
+		{Key: "2024-05-01"},
+		{Key: "2024-05-02", Counts: Counts{Views: 2, Visitors: 1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestHourSeries(t *testing.T) {
+	end := time.Date(2024, 5, 1, 23, 30, 0, 0, Location())
+	got := HourSeries(end, 2, map[string]Counts{"2024-05-01T23": {Views: 4}})
+	want := []Point{{Key: "2024-05-01T22"}, {Key: "2024-05-01T23", Counts: Counts{Views: 4}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestMergeKeepsLargerCount(t *testing.T) {
+	got := Merge(
+		map[string]Counts{"a": {Views: 10, Visitors: 2}, "b": {Views: 3}},
+		map[string]Counts{"a": {Views: 4, Visitors: 3}, "b": {Views: 7}, "c": {Views: 1}},
+	)
+	want := map[string]Counts{"a": {Views: 10, Visitors: 3}, "b": {Views: 7}, "c": {Views: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSummarizeSplitsLaunchAndLongTail(t *testing.T) {
+	published := time.Date(2024, 5, 1, 20, 0, 0, 0, Location())
+	counts := map[string]Counts{
+		"2024-04-20": {Views: 1}, // 发布前
+		"2024-05-01": {Views: 50},
+		"2024-05-07": {Views: 10},
+		"2024-05-08": {Views: 3}, // 首发期之后
+		"2024-06-01": {Views: 50},
+	}
+	got := Summarize(counts, published)
+	want := Summary{Total: 114, LaunchViews: 60, LongTailViews: 54, PeakKey: "2024-05-01", PeakViews: 50}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestPeriods(t *testing.T) {
+	// 2024-05-08 为周三，10 天窗口起点 2024-04-29 为周一
+	end := time.Date(2024, 5, 8, 12, 0, 0, 0, Location())
+	weeks := Periods(end, 10, UnitWeek)
+	wantWeeks := []Period{
+		{Key: "2024-W18", FirstDay: "2024-04-29", LastDay: "2024-05-05"},
+		{Key: "2024-W19", FirstDay: "2024-05-06", LastDay: "2024-05-12"},
+	}
+	if !reflect.DeepEqual(weeks, wantWeeks) {
+		t.Fatalf("weeks = %v", weeks)
+	}
+
+	months := Periods(end, 10, UnitMonth)
+	wantMonths := []Period{
+		{Key: "2024-04", FirstDay: "2024-04-01", LastDay: "2024-04-30"},
+		{Key: "2024-05", FirstDay: "2024-05-01", LastDay: "2024-05-31"},
+	}
+	if !reflect.DeepEqual(months, wantMonths) {
+		t.Fatalf("months = %v", months)
+	}
+
+	counts := map[string]Counts{"2024-04-30": {Views: 3}, "2024-05-01": {Views: 4}, "2024-05-06": {Views: 5}}
+	if got := SumViews(counts, "2024-04-29", "2024-05-05"); got != 7 {
+		t.Fatalf("SumViews = %d", got)
+	}
+}
+
+func TestWeekKeyUsesISOYear(t *testing.T) {
+	if got := WeekKey(time.Date(2024, 12, 30, 10, 0, 0, 0, Location())); got != "2025-W01" {
+		t.Fatalf("WeekKey = %q", got)
+	}
+}
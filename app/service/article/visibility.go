@@ -0,0 +1,347 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"meta-api/app/model/article"
+	"meta-api/common/cachekey"
+	"meta-api/common/idutil"
+	"meta-api/common/ratelimit"
+	"meta-api/common/types"
+	"meta-api/common/utils"
+	appconfig "meta-api/config"
+)
+
+const (
+	defaultArticleUnlockIPLimit         = 20
+	defaultArticleUnlockIPWindow        = 10 * time.Minute
+	defaultArticleUnlockIPArticleLimit  = 5
+	defaultArticleUnlockIPArticleWindow = 10 * time.Minute
+	unknownArticleUnlockClientValue     = "unknown"
+)
+
+var (
+	errArticlePasswordRequired = errors.New("protected article requires a password")
+	errArticlePasswordWrong    = errors.New("wrong article password")
+	errArticleNotProtected     = errors.New("article is not password protected")
+)
+
+// ArticleProtectedError 访问加密文章但没有有效的访问凭证，调用方应提示输入密码
+type ArticleProtectedError struct {
+	ID    string
+	Title string
+}
+
+func (e *ArticleProtectedError) Error() string {
+	return fmt.Sprintf("article %s is password protected", e.ID)
+}
+
+// AdminUpdateArticleVisibility 修改已发布文章的可见性，并同步不公开集合、详情缓存、标签文章数、搜索索引、系列与订阅源
+func (a *articleService) AdminUpdateArticleVisibility(ctx context.Context,
+	request *types.AdminUpdateArticleVisibilityRequest) error {
+	id, err := idutil.ParseID("articleID", request.ID)
+	if err != nil {
+		return err
+	}
+
+	var passwordHash *string
+	if request.Visibility == article.ArticleVisibilityProtected {
+		if request.Password != "" {
+			hash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+			if err != nil {
+				a.logger.Error("failed to hash article password", zap.Error(err))
+				return fmt.Errorf("failed to hash article password: %w", err)
+			}
+			hashString := string(hash)
+			passwordHash = &hashString
+		} else {
+			access, err := a.articleModel.GetArticleAccess(ctx, id)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf("record not found: %w", err)
+				}
+				a.logger.Error("failed to get article access", zap.Error(err))
+				return err
+			}
+			if access.AccessPassword == "" {
+				return errArticlePasswordRequired
+			}
+		}
+	}
+
+	if err = a.articleModel.UpdateArticleVisibility(ctx, id, request.Visibility, passwordHash); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("record not found: %w", err)
+		}
+		a.logger.Error("failed to update article visibility", zap.Error(err))
+		return err
+	}
+
+	if err = a.syncArticleVisibility(ctx, request.ID, request.Visibility); err != nil {
+		return err
+	}
+	// 标签文章数只统计公开文章，删除后由标签列表按新的可见性回源重建
+	if err = a.redis.Del(ctx, cachekey.TagArticleNumZSet().String(), cachekey.TagListCache().String()).Err(); err != nil {
+		a.logger.Error("failed to delete tag article count", zap.Error(err))
+		return fmt.Errorf("failed to delete tag article count: %w", err)
+	}
+	// 详情缓存中的可见性与密码版本随之失效，下次访问回源重建
+	if err = a.redis.Del(ctx, articleCacheKeys(request.ID)...).Err(); err != nil {
+		a.logger.Error("failed to delete article hash", zap.Error(err))
+		return fmt.Errorf("failed to delete article hash: %w", err)
+	}
+	if request.Visibility == article.ArticleVisibilityPublic {
+		detail, err := a.articleModel.GetArticleDetailByID(ctx, id)
+		if err != nil {
+			a.logger.Error("failed to get article detail", zap.Error(err))
+			return fmt.Errorf("failed to get article detail: %w", err)
+		}
+		a.searchIndex.Upsert(buildSearchDocument(detail.ID, detail.Title, detail.Describe,
+			detail.PlainText, detail.ViewNum, detail.CreateTime))
+	} else {
+		a.searchIndex.Remove(id)
+		if err = a.redis.ZRem(ctx, cachekey.ArticleTrendingZSet().String(), request.ID).Err(); err != nil {
+			a.logger.Warn("failed to remove hidden article from trending", zap.Error(err))
+		}
+	}
+
+	urlKeys := a.articleURLKeys(ctx, id)
+	if err = a.cdn.PurgeArticles(urlKeys...); err != nil {
+		a.logger.Error("failed to purge article CDN cache", zap.String("article_id", request.ID), zap.Error(err))
+	}
+	a.sitemap.RefreshArticles(urlKeys...)
+	// 系列列表的文章数与前后篇导航只包含公开文章
+	a.refreshArticleSeries(ctx, id)
+	a.invalidateArticlePromotions(ctx)
+	a.invalidateArticleFeeds(ctx)
+	a.invalidateArticleArchive(ctx)
+	return nil
+}
+
+// UserUnlockArticle 校验加密文章的访问密码，成功后签发与当前密码绑定的短期访问凭证
+func (a *articleService) UserUnlockArticle(ctx context.Context,
+	request *types.UserUnlockArticleRequest) (*types.UserUnlockArticleResponse, error) {
+	id, err := idutil.ParseID("articleID", request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.checkArticleUnlockLimit(ctx, request.ID, request.ClientIP); err != nil {
+		return nil, err
+	}
+
+	access, err := a.articleModel.GetArticleAccess(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("record not found: %w", err)
+		}
+		a.logger.Error("failed to get article access", zap.Error(err))
+		return nil, err
+	}
+	if access.Visibility != article.ArticleVisibilityProtected || access.AccessPassword == "" {
+		return nil, errArticleNotProtected
+	}
+	if !utils.BcryptCheck(request.Password, access.AccessPassword) {
+		return nil, errArticlePasswordWrong
+	}
+
+	token, err := utils.GenerateArticleAccessToken(request.ID, article.AccessVersion(access.AccessPassword))
+	if err != nil {
+		a.logger.Error("failed to generate article access token", zap.Error(err))
+		return nil, err
+	}
+	return &types.UserUnlockArticleResponse{ID: request.ID, Token: token}, nil
+}
+
+// checkArticleAccess 加密文章需要携带与当前密码版本一致的访问凭证
+func checkArticleAccess(articleID, title, visibility, accessVersion string, tokens map[string]string) error {
+	if visibility != article.ArticleVisibilityProtected {
+		return nil
+	}
+	if token := tokens[articleID]; token != "" {
+		claims, err := utils.ParseArticleAccessToken(token)
+		if err == nil && claims.ArticleID == articleID && claims.AccessVersion == accessVersion {
+			return nil
+		}
+	}
+	return &ArticleProtectedError{ID: articleID, Title: title}
+}
+
+// syncArticleVisibility 维护不公开文章集合，前台列表、热门、订阅源等据此过滤
+func (a *articleService) syncArticleVisibility(ctx context.Context, articleID string, visibility string) error {
+	key := cachekey.ArticleHiddenSet().String()
+	var err error
+	if visibility == "" || visibility == article.ArticleVisibilityPublic {
+		err = a.redis.SRem(ctx, key, articleID).Err()
+	} else {
+		err = a.redis.SAdd(ctx, key, articleID).Err()
+	}
+	if err != nil {
+		a.logger.Error("failed to sync hidden article set", zap.String("articleID", articleID), zap.Error(err))
+		return fmt.Errorf("failed to sync hidden article set: %w", err)
+	}
+	return nil
+}
+
+// isPublicArticle 新建文章的可见性为空时按公开处理
+func isPublicArticle(articleInfo *article.Article) bool {
+	return articleInfo.Visibility == "" || articleInfo.Visibility == article.ArticleVisibilityPublic
+}
+
+// hiddenArticleIDs 已发布但不公开的文章 ID
+func (a *articleService) hiddenArticleIDs(ctx context.Context) (map[string]struct{}, error) {
+	members, err := a.redis.SMembers(ctx, cachekey.ArticleHiddenSet().String()).Result()
+	if err != nil {
+		a.logger.Error("failed to get hidden article set", zap.Error(err))
+		return nil, fmt.Errorf("failed to get hidden article set: %w", err)
+	}
+	hidden := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		hidden[member] = struct{}{}
+	}
+	return hidden, nil
+}
+
+// filterHiddenArticleIDs 按原顺序去掉不公开的文章，最多保留 limit 篇（limit <= 0 表示不限）
+func filterHiddenArticleIDs(ids []string, hidden map[string]struct{}, limit int) []string {
+	visible := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if limit > 0 && len(visible) == limit {
+			break
+		}
+		if _, ok := hidden[id]; !ok {
+			visible = append(visible, id)
+		}
+	}
+	return visible
+}
+
+// excludedRanks 被排除的文章在有序集合中的倒序排名（升序），不在集合中的直接跳过
+func (a *articleService) excludedRanks(ctx context.Context, key string,
+	excluded map[string]struct{}) ([]int64, error) {
+	if len(excluded) == 0 {
+		return nil, nil
+	}
+	pipe := a.redis.Pipeline()
+	cmds := make([]*redis.IntCmd, 0, len(excluded))
+	for id := range excluded {
+		cmds = append(cmds, pipe.ZRevRank(ctx, key, id))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		a.logger.Error("failed to get excluded article ranks", zap.Error(err))
+		return nil, fmt.Errorf("failed to get excluded article ranks: %w", err)
+	}
+	ranks := make([]int64, 0, len(cmds))
+	for _, cmd := range cmds {
+		if rank, err := cmd.Result(); err == nil {
+			ranks = append(ranks, rank)
+		}
+	}
+	sort.Slice(ranks, func(i, j int) bool { return ranks[i] < ranks[j] })
+	return ranks, nil
+}
+
+// excludedPageWindow 计算分页区间 [start, stop]（headCount 篇置顶文章在前、其余文章按时间倒序在后）中
+// 常规部分对应的有序集合区间：在 [windowStart, windowStop] 内跳过被排除的文章（置顶或不公开）后，
+// 再跳过 skip 篇即为本页常规部分的第一篇。excludedRanks 为被排除文章在有序集合中的排名（升序）
+func excludedPageWindow(excludedRanks []int64, headCount int64, start int64, stop int64) (int64, int64, int64) {
+	// 常规部分在去掉被排除文章后的序列中的区间
+	regularStart := max(start-headCount, 0)
+	regularStop := stop - headCount
+	// 排在 regularStart 之前的被排除文章使窗口内第一篇常规文章的序号前移
+	var skip int64
+	for _, rank := range excludedRanks {
+		if rank < regularStart {
+			skip++
+		}
+	}
+	return regularStart, regularStop + int64(len(excludedRanks)), skip
+}
+
+// checkArticleUnlockLimit 按 IP 与 IP+文章两个维度限制密码尝试次数
+func (a *articleService) checkArticleUnlockLimit(ctx context.Context, articleID string, clientIP string) error {
+	cfg := a.articleUnlockRateLimitConfig()
+	if cfg.Disabled {
+		return nil
+	}
+	ipHash := ratelimit.HashPart(normalizeArticleUnlockClientValue(clientIP))
+	articleHash := ratelimit.HashPart(articleID)
+	err := a.limiter.Check(ctx,
+		articleRateLimitRule(cachekey.ArticleRateLimit("unlock", "ip", ipHash).String(), cfg.IP),
+		articleRateLimitRule(cachekey.ArticleRateLimit("unlock", "ip-article", ipHash, articleHash).String(), cfg.IPArticle),
+	)
+	if err == nil {
+		return nil
+	}
+	if _, ok := ratelimit.AsLimited(err); ok {
+		return err
+	}
+	a.logger.Warn("article unlock rate-limit unavailable", zap.Error(err))
+	return errors.New("文章解锁暂不可用，请稍后再试")
+}
+
+// articleUnlockRateLimitConfig 获取当前解锁限流配置并填充默认值
+func (a *articleService) articleUnlockRateLimitConfig() appconfig.ArticleUnlockRateLimitConfig {
+	cfg := appconfig.ArticleUnlockRateLimitConfig{}
+	if a.config != nil {
+		cfg = a.config.RateLimitSnapshot().ArticleUnlock
+	}
+	fillArticleWindowConfig(&cfg.IP, defaultArticleUnlockIPLimit, defaultArticleUnlockIPWindow)
+	fillArticleWindowConfig(&cfg.IPArticle, defaultArticleUnlockIPArticleLimit, defaultArticleUnlockIPArticleWindow)
+	return cfg
+}
+
+// fillArticleWindowConfig 填充单条窗口规则默认值
+func fillArticleWindowConfig(cfg *appconfig.RateLimitWindowConfig, defaultLimit int64, defaultWindow time.Duration) {
+	if cfg.Limit <= 0 {
+		cfg.Limit = defaultLimit
+	}
+	if cfg.WindowSeconds <= 0 {
+		cfg.WindowSeconds = int64(defaultWindow / time.Second)
+	}
+}
+
+// articleRateLimitRule 将配置项转换为限流规则
+func articleRateLimitRule(key string, cfg appconfig.RateLimitWindowConfig) ratelimit.Rule {
+	return ratelimit.Rule{
+		Key:    key,
+		Limit:  cfg.Limit,
+		Window: time.Duration(cfg.WindowSeconds) * time.Second,
+	}
+}
+
+func normalizeArticleUnlockClientValue(value string) string {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return unknownArticleUnlockClientValue
+	}
+	return value
+}
+
+// AsArticleProtectedError 判断是否为需要密码访问的加密文章
+func AsArticleProtectedError(err error) (*ArticleProtectedError, bool) {
+	var protected *ArticleProtectedError
+	ok := errors.As(err, &protected)
+	return protected, ok
+}
+
+func IsArticlePasswordRequiredError(err error) bool {
+	return errors.Is(err, errArticlePasswordRequired)
+}
+
+func IsArticlePasswordWrongError(err error) bool {
+	return errors.Is(err, errArticlePasswordWrong)
+}
+
+func IsArticleNotProtectedError(err error) bool {
+	return errors.Is(err, errArticleNotProtected)
+}
@@ -0,0 +1,47 @@
+package article
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExcludedPageWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		ranks       []int64
+		headCount   int64
+		start, stop int64
+		wantStart   int64
+		wantStop    int64
+		wantSkip    int64
+	}{
+		{name: "nothing excluded", ranks: nil, headCount: 0, start: 10, stop: 19, wantStart: 10, wantStop: 19, wantSkip: 0},
+		{name: "first page", ranks: []int64{3, 40}, headCount: 2, start: 0, stop: 9, wantStart: 0, wantStop: 9, wantSkip: 0},
+		// 第二页对应常规序列的 [8, 17]，排名 3 的置顶文章排在其前面
+		{name: "later page", ranks: []int64{3, 40}, headCount: 2, start: 10, stop: 19, wantStart: 8, wantStop: 19, wantSkip: 1},
+		{name: "all pinned before", ranks: []int64{0, 1}, headCount: 2, start: 20, stop: 29, wantStart: 18, wantStop: 29, wantSkip: 2},
+		// 不公开的文章只被跳过，不占用列表头部
+		{name: "hidden only", ranks: []int64{0, 5, 12}, headCount: 0, start: 10, stop: 19, wantStart: 10, wantStop: 22, wantSkip: 2},
+		{name: "pinned and hidden", ranks: []int64{1, 2, 30}, headCount: 1, start: 10, stop: 19, wantStart: 9, wantStop: 21, wantSkip: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStart, gotStop, gotSkip := excludedPageWindow(tt.ranks, tt.headCount, tt.start, tt.stop)
+			if gotStart != tt.wantStart || gotStop != tt.wantStop || gotSkip != tt.wantSkip {
+				t.Fatalf("got (%d, %d, %d), want (%d, %d, %d)",
+					gotStart, gotStop, gotSkip, tt.wantStart, tt.wantStop, tt.wantSkip)
+			}
+		})
+	}
+}
+
+func TestFilterHiddenArticleIDs(t *testing.T) {
+	hidden := map[string]struct{}{"2": {}, "4": {}}
+	ids := []string{"1", "2", "3", "4", "5"}
+	if got := filterHiddenArticleIDs(ids, hidden, 0); !reflect.DeepEqual(got, []string{"1", "3", "5"}) {
+		t.Fatalf("unexpected ids: %v", got)
+	}
+	if got := filterHiddenArticleIDs(ids, hidden, 2); !reflect.DeepEqual(got, []string{"1", "3"}) {
+		t.Fatalf("unexpected limited ids: %v", got)
+	}
+}
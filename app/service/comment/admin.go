@@ -98,16 +98,21 @@ func (s *commentService) AdminUpdateCommentStatus(ctx context.Context,
 		s.logger.Error("failed to load location", zap.Error(err))
 		return fmt.Errorf("failed to load location: %w", err)
 	}
-	if err = s.commentModel.UpdateCommentStatus(ctx, id, status, time.Now().In(loc)); err != nil {
+	now := time.Now().In(loc)
+	if err = s.commentModel.UpdateCommentStatus(ctx, id, status, now); err != nil {
 		s.logger.Error("failed to update comment status", zap.Error(err))
 		return err
 	}
+	// 待审核评论在审核通过时才计入趋势
+	if item.Status == commentModel.StatusPending && status == commentModel.StatusApproved {
+		s.recordTrendingComment(ctx, item.ArticleID, now)
+	}
 
 	return s.invalidateArticleCommentCache(ctx, item.ArticleID)
 }
 
 func (s *commentService) AdminDeleteComment(ctx context.Context, request *types.AdminDeleteCommentRequest) error {
-	ids, err := parseAdminDeleteCommentIDs(request)
+	ids, err := parseAdminCommentIDs(request.ID, request.IDList)
 	if err != nil {
 		s.logger.Error("invalid comment id", zap.Error(err))
 		return ErrInvalidComment
@@ -419,12 +424,13 @@ func normalizeAdminAuthorHandle(value string) string {
 	return strconv.FormatUint(number, 10)
 }
 
-func parseAdminDeleteCommentIDs(request *types.AdminDeleteCommentRequest) ([]uint64, error) {
-	rawIDs := make([]string, 0, len(request.IDList)+1)
-	if strings.TrimSpace(request.ID) != "" {
-		rawIDs = append(rawIDs, request.ID)
+// parseAdminCommentIDs 合并单个 ID 与批量 IDList 并去重，删除、恢复与彻底删除共用
+func parseAdminCommentIDs(id string, idList []string) ([]uint64, error) {
+	rawIDs := make([]string, 0, len(idList)+1)
+	if strings.TrimSpace(id) != "" {
+		rawIDs = append(rawIDs, id)
 	}
-	rawIDs = append(rawIDs, request.IDList...)
+	rawIDs = append(rawIDs, idList...)
 	if len(rawIDs) == 0 {
 		return nil, errors.New("empty comment id list")
 	}
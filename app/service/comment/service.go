@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/robfig/cron/v3"
 	"github.com/sony/sonyflake"
 	"go.uber.org/zap"
 
@@ -36,9 +37,15 @@ type Service interface {
 	AdminGetCommentList(ctx context.Context, request *types.AdminGetCommentListRequest) (*types.AdminGetCommentListResponse, error)
 	AdminUpdateCommentStatus(ctx context.Context, request *types.AdminUpdateCommentStatusRequest) error
 	AdminDeleteComment(ctx context.Context, request *types.AdminDeleteCommentRequest) error
+	AdminGetCommentTrashList(ctx context.Context, request *types.AdminGetCommentTrashListRequest) (*types.AdminGetCommentTrashListResponse, error)
+	AdminRestoreComment(ctx context.Context, request *types.AdminRestoreCommentRequest) error
+	AdminPurgeComment(ctx context.Context, request *types.AdminPurgeCommentRequest) error
 	AdminPreviewCommentModeration(ctx context.Context, request *types.AdminPreviewCommentModerationRequest) (*types.AdminPreviewCommentModerationResponse, error)
 	AdminGetCommentReportList(ctx context.Context, request *types.AdminGetCommentReportListRequest) (*types.AdminGetCommentReportListResponse, error)
 	AdminHandleCommentReport(ctx context.Context, request *types.AdminHandleCommentReportRequest) error
+
+	PurgeExpiredTrash(ctx context.Context) error
+	RegisterCronJobs(c *cron.Cron) ([]cron.EntryID, error)
 }
 
 type commentService struct {
@@ -0,0 +1,139 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	commentModel "meta-api/app/model/comment"
+	"meta-api/common/constants"
+	"meta-api/common/types"
+)
+
+// trashPurgeBatchSize 每次定时清理彻底删除的过期评论上限
+const trashPurgeBatchSize = 500
+
+func (s *commentService) AdminGetCommentTrashList(ctx context.Context,
+	request *types.AdminGetCommentTrashListRequest) (*types.AdminGetCommentTrashListResponse, error) {
+	offset := (request.Page - 1) * request.PageSize
+	rows, total, err := s.commentModel.ListTrashedComments(ctx, offset, request.PageSize)
+	if err != nil {
+		s.logger.Error("failed to list trashed comments", zap.Error(err))
+		return nil, err
+	}
+
+	retention := s.config.TrashSnapshot().Retention()
+	items := make([]types.AdminCommentTrashItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toAdminCommentTrashItem(row, retention))
+	}
+	return &types.AdminGetCommentTrashListResponse{Rows: items, Total: int(total)}, nil
+}
+
+func (s *commentService) AdminRestoreComment(ctx context.Context, request *types.AdminRestoreCommentRequest) error {
+	ids, err := parseAdminCommentIDs(request.ID, request.IDList)
+	if err != nil {
+		s.logger.Error("invalid comment id", zap.Error(err))
+		return ErrInvalidComment
+	}
+
+	items, err := s.commentModel.GetTrashedCommentsByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("failed to get trashed comments", zap.Error(err))
+		return fmt.Errorf("failed to get trashed comments: %w", err)
+	}
+	if len(items) != len(ids) {
+		return ErrCommentNotFound
+	}
+
+	if err = s.commentModel.RestoreComments(ctx, ids); err != nil {
+		s.logger.Error("failed to restore comments", zap.Error(err))
+		return err
+	}
+
+	articleIDSet := make(map[uint64]struct{}, len(items))
+	for _, item := range items {
+		articleIDSet[item.ArticleID] = struct{}{}
+	}
+	for articleID := range articleIDSet {
+		if err = s.invalidateArticleCommentCache(ctx, articleID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *commentService) AdminPurgeComment(ctx context.Context, request *types.AdminPurgeCommentRequest) error {
+	ids, err := parseAdminCommentIDs(request.ID, request.IDList)
+	if err != nil {
+		s.logger.Error("invalid comment id", zap.Error(err))
+		return ErrInvalidComment
+	}
+
+	items, err := s.commentModel.GetTrashedCommentsByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("failed to get trashed comments", zap.Error(err))
+		return fmt.Errorf("failed to get trashed comments: %w", err)
+	}
+	if len(items) != len(ids) {
+		return ErrCommentNotFound
+	}
+
+	if err = s.commentModel.PurgeComments(ctx, ids); err != nil {
+		s.logger.Error("failed to purge comments", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// PurgeExpiredTrash 彻底删除超过保留期的回收站评论
+func (s *commentService) PurgeExpiredTrash(ctx context.Context) error {
+	before := time.Now().Add(-s.config.TrashSnapshot().Retention())
+	ids, err := s.commentModel.ListExpiredTrashedCommentIDs(ctx, before, trashPurgeBatchSize)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if err = s.commentModel.PurgeComments(ctx, ids); err != nil {
+		return err
+	}
+	s.logger.Info("expired comment trash purged", zap.Int("comments", len(ids)))
+	return nil
+}
+
+// RegisterCronJobs 把评论回收站清理任务注册到外部 cron 调度器
+func (s *commentService) RegisterCronJobs(c *cron.Cron) ([]cron.EntryID, error) {
+	entryID, err := c.AddFunc(constants.TrashPurgeSpec, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		if err := s.PurgeExpiredTrash(ctx); err != nil {
+			s.logger.Error("cron purge expired comment trash failed", zap.Error(err))
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register comment trash cron job: %w", err)
+	}
+	s.logger.Info("comment cron jobs registered", zap.String("trashPurgeSpec", constants.TrashPurgeSpec))
+	return []cron.EntryID{entryID}, nil
+}
+
+func toAdminCommentTrashItem(row commentModel.TrashListItem, retention time.Duration) types.AdminCommentTrashItem {
+	return types.AdminCommentTrashItem{
+		ID:           strconv.FormatUint(row.ID, 10),
+		ArticleID:    strconv.FormatUint(row.ArticleID, 10),
+		ArticleTitle: row.ArticleTitle,
+		AuthorName:   row.AuthorName,
+		Content:      row.Content,
+		Status:       row.Status,
+		CreateTime:   row.CreateTime.Format(constants.TimeLayoutToMinute),
+		DeleteTime:   row.DeletedAt.Format(constants.TimeLayoutToMinute),
+		PurgeTime:    row.DeletedAt.Add(retention).Format(constants.TimeLayoutToMinute),
+	}
+}
@@ -0,0 +1,25 @@
+package comment
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"meta-api/app/service/article/viewstats"
+	"meta-api/common/cachekey"
+)
+
+// recordTrendingComment 把通过审核的评论记入文章趋势分桶，失败只记录日志，不影响评论本身
+func (s *commentService) recordTrendingComment(ctx context.Context, articleID uint64, now time.Time) {
+	key := cachekey.ArticleTrendingHourHash(viewstats.HourKey(now)).String()
+	field := viewstats.TrendingField(strconv.FormatUint(articleID, 10), viewstats.TrendingEventComment)
+
+	pipe := s.redis.Pipeline()
+	pipe.HIncrBy(ctx, key, field, 1)
+	pipe.Expire(ctx, key, s.config.RankingSnapshot().TrendingWindow()+time.Hour)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Warn("failed to record trending comment", zap.Uint64("article_id", articleID), zap.Error(err))
+	}
+}
@@ -253,6 +253,7 @@ func (s *commentService) UserAddComment(ctx context.Context,
 		AuthorName:        truncateString(user.DisplayName, 80),
 		Content:           content,
 		Status:            moderation.Status,
+		ModerationStatus:  moderation.Status,
 		ModerationReasons: encodeCommentModerationReasons(moderation.Reasons),
 		IP:                request.ClientIP,
 		CreateTime:        now,
@@ -263,6 +264,9 @@ func (s *commentService) UserAddComment(ctx context.Context,
 		return nil, err
 	}
 	s.recordCommentModerationBehavior(ctx, moderationInput)
+	if moderation.Status == commentModel.StatusApproved {
+		s.recordTrendingComment(ctx, articleID, now)
+	}
 
 	return &types.UserAddCommentResponse{
 		ID:     strconv.FormatUint(commentID, 10),
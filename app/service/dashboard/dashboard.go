@@ -0,0 +1,193 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"meta-api/app/service/article/viewstats"
+	"meta-api/common/cachekey"
+	"meta-api/common/constants"
+	"meta-api/common/readcache"
+	"meta-api/common/types"
+	"meta-api/config"
+)
+
+// 统计结果读穿缓存：后台看板允许几分钟的延迟，过期后 1 分钟内先返回旧数据再后台刷新
+const (
+	dashboardCacheTTL      = 5 * time.Minute
+	dashboardCacheStaleTTL = time.Minute
+	dashboardCacheJitter   = 0.1
+)
+
+// ErrInvalidDashboardRange 开始日期晚于结束日期，或范围超过允许的最大天数
+var ErrInvalidDashboardRange = errors.New("invalid dashboard range")
+
+func newDashboardCache(store readcache.Store, logger *zap.Logger) *readcache.Cache[types.AdminGetDashboardResponse] {
+	return readcache.New[types.AdminGetDashboardResponse](store, readcache.Options{
+		TTL:      dashboardCacheTTL,
+		StaleTTL: dashboardCacheStaleTTL,
+		Jitter:   dashboardCacheJitter,
+		OnError: func(key string, err error) {
+			logger.Warn("dashboard cache degraded", zap.String("key", key), zap.Error(err))
+		},
+	})
+}
+
+// AdminGetDashboard 统计时间范围内的各项指标合计与按天趋势
+func (s *dashboardService) AdminGetDashboard(ctx context.Context,
+	request *types.AdminGetDashboardRequest) (*types.AdminGetDashboardResponse, error) {
+	start, days, err := resolveDashboardRange(request, s.config.DashboardSnapshot(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	from := start.Format(viewstats.DayLayout)
+	to := start.AddDate(0, 0, days-1).Format(viewstats.DayLayout)
+	key := cachekey.AdminDashboard(from, to).String()
+	if request.Refresh {
+		if err = s.cache.Delete(ctx, key); err != nil {
+			s.logger.Warn("failed to delete dashboard cache", zap.String("key", key), zap.Error(err))
+		}
+	}
+
+	response, err := s.cache.Get(ctx, key, func(ctx context.Context) (types.AdminGetDashboardResponse, error) {
+		return s.loadDashboard(ctx, start, days)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// resolveDashboardRange 返回统计范围第一天的零点（站点时区）与天数，结束日期不晚于今天
+func resolveDashboardRange(request *types.AdminGetDashboardRequest, dashboardConfig config.DashboardConfig,
+	now time.Time) (time.Time, int, error) {
+	loc := viewstats.Location()
+	year, month, day := now.In(loc).Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	maxDays := dashboardConfig.MaxRangeDays()
+
+	if request.From == "" && request.To == "" {
+		days := request.Days
+		if days <= 0 {
+			days = dashboardConfig.RangeDays()
+		}
+		if days > maxDays {
+			return time.Time{}, 0, fmt.Errorf("%w: at most %d days", ErrInvalidDashboardRange, maxDays)
+		}
+		return today.AddDate(0, 0, -(days - 1)), days, nil
+	}
+
+	end := today
+	if request.To != "" {
+		parsed, err := time.ParseInLocation(viewstats.DayLayout, request.To, loc)
+		if err != nil {
+			return time.Time{}, 0, fmt.Errorf("%w: %w", ErrInvalidDashboardRange, err)
+		}
+		if parsed.Before(today) {
+			end = parsed
+		}
+	}
+	start := end.AddDate(0, 0, -(dashboardConfig.RangeDays() - 1))
+	if request.From != "" {
+		parsed, err := time.ParseInLocation(viewstats.DayLayout, request.From, loc)
+		if err != nil {
+			return time.Time{}, 0, fmt.Errorf("%w: %w", ErrInvalidDashboardRange, err)
+		}
+		start = parsed
+	}
+	if start.After(end) {
+		return time.Time{}, 0, fmt.Errorf("%w: from is after to", ErrInvalidDashboardRange)
+	}
+	// 站点时区没有夏令时，按整天四舍五入即可
+	days := int((end.Sub(start)+12*time.Hour)/(24*time.Hour)) + 1
+	if days > maxDays {
+		return time.Time{}, 0, fmt.Errorf("%w: at most %d days", ErrInvalidDashboardRange, maxDays)
+	}
+	return start, days, nil
+}
+
+// loadDashboard 每个数据源一次按天分组的查询，在内存中补齐缺失的日期并汇总
+func (s *dashboardService) loadDashboard(ctx context.Context, start time.Time,
+	days int) (types.AdminGetDashboardResponse, error) {
+	end := start.AddDate(0, 0, days)
+	from := start.Format(viewstats.DayLayout)
+	to := end.AddDate(0, 0, -1).Format(viewstats.DayLayout)
+	series := newDashboardSeries(start, days)
+
+	articles, err := s.articleModel.CountPublishedArticlesByDay(ctx, start, end)
+	if err != nil {
+		s.logger.Error("failed to count published articles", zap.Error(err))
+		return types.AdminGetDashboardResponse{}, err
+	}
+	series.addArticles(articles)
+
+	views, err := s.articleModel.ListSiteViewDaily(ctx, from, to)
+	if err != nil {
+		s.logger.Error("failed to list site view daily", zap.Error(err))
+		return types.AdminGetDashboardResponse{}, err
+	}
+	series.addViews(views)
+
+	users, err := s.userModel.CountUsersByDay(ctx, start, end)
+	if err != nil {
+		s.logger.Error("failed to count new users", zap.Error(err))
+		return types.AdminGetDashboardResponse{}, err
+	}
+	series.addUsers(users)
+
+	comments, err := s.commentModel.CountCommentsByDay(ctx, start, end)
+	if err != nil {
+		s.logger.Error("failed to count comments", zap.Error(err))
+		return types.AdminGetDashboardResponse{}, err
+	}
+	series.addComments(comments)
+
+	reports, err := s.commentModel.CountCommentReportsByDay(ctx, start, end)
+	if err != nil {
+		s.logger.Error("failed to count comment reports", zap.Error(err))
+		return types.AdminGetDashboardResponse{}, err
+	}
+	series.addReports(reports)
+
+	pendingReports, err := s.commentModel.CountPendingCommentReports(ctx)
+	if err != nil {
+		s.logger.Error("failed to count pending comment reports", zap.Error(err))
+		return types.AdminGetDashboardResponse{}, err
+	}
+
+	if err = s.loadGuardStats(ctx, series); err != nil {
+		return types.AdminGetDashboardResponse{}, err
+	}
+
+	return types.AdminGetDashboardResponse{
+		From:           from,
+		To:             to,
+		Totals:         series.totals(),
+		PendingReports: pendingReports,
+		Points:         series.points,
+		GuardDecisions: series.guardItems(),
+		GeneratedAt:    time.Now().In(viewstats.Location()).Format(constants.TimeLayoutToSecond),
+	}, nil
+}
+
+// loadGuardStats 在同一个 pipeline 中读取范围内每天的风控决策计数
+func (s *dashboardService) loadGuardStats(ctx context.Context, series *dashboardSeries) error {
+	pipe := s.redis.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(series.points))
+	for i, point := range series.points {
+		cmds[i] = pipe.HGetAll(ctx, cachekey.GuardDecisionStatsHash(point.Day).String())
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Error("failed to get guard decision stats", zap.Error(err))
+		return fmt.Errorf("failed to get guard decision stats: %w", err)
+	}
+	for i, point := range series.points {
+		series.addGuard(point.Day, cmds[i].Val())
+	}
+	return nil
+}
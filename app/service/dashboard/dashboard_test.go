@@ -0,0 +1,85 @@
+package dashboard
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"meta-api/app/model/comment"
+	"meta-api/app/service/article/viewstats"
+	"meta-api/common/types"
+	"meta-api/config"
+)
+
+func TestResolveDashboardRange(t *testing.T) {
+	now := time.Date(2024, 5, 10, 1, 0, 0, 0, viewstats.Location())
+	dashboardConfig := config.DashboardConfig{DefaultDays: 7, MaxDays: 31}
+
+	cases := []struct {
+		name    string
+		request types.AdminGetDashboardRequest
+		from    string
+		days    int
+		invalid bool
+	}{
+		{name: "default", from: "2024-05-04", days: 7},
+		{name: "days", request: types.AdminGetDashboardRequest{Days: 3}, from: "2024-05-08", days: 3},
+		{name: "days over max", request: types.AdminGetDashboardRequest{Days: 32}, invalid: true},
+		{name: "from to", request: types.AdminGetDashboardRequest{From: "2024-04-01", To: "2024-04-30"}, from: "2024-04-01", days: 30},
+		{name: "from only", request: types.AdminGetDashboardRequest{From: "2024-05-09"}, from: "2024-05-09", days: 2},
+		{name: "to only", request: types.AdminGetDashboardRequest{To: "2024-05-01"}, from: "2024-04-25", days: 7},
+		{name: "to in future", request: types.AdminGetDashboardRequest{From: "2024-05-01", To: "2024-06-01"}, from: "2024-05-01", days: 10},
+		{name: "reversed", request: types.AdminGetDashboardRequest{From: "2024-05-03", To: "2024-05-01"}, invalid: true},
+		{name: "range over max", request: types.AdminGetDashboardRequest{From: "2024-01-01", To: "2024-03-01"}, invalid: true},
+	}
+	for _, tc := range cases {
+		start, days, err := resolveDashboardRange(&tc.request, dashboardConfig, now)
+		if tc.invalid {
+			if !errors.Is(err, ErrInvalidDashboardRange) {
+				t.Fatalf("%s: expected invalid range, got %v", tc.name, err)
+			}
+			continue
+		}
+		if err != nil || start.Format(viewstats.DayLayout) != tc.from || days != tc.days {
+			t.Fatalf("%s: got %s %d %v", tc.name, start.Format(viewstats.DayLayout), days, err)
+		}
+	}
+}
+
+func TestDashboardSeriesTotals(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, viewstats.Location())
+	series := newDashboardSeries(start, 2)
+	series.addComments([]comment.DailyStatusCount{
+		{Day: "2024-05-01", Status: comment.StatusApproved, ModerationStatus: comment.StatusApproved, Count: 3},
+		// 自动审核拒绝后被后台改为通过，仍按提交时的审核结果计入拒绝
+		{Day: "2024-05-01", Status: comment.StatusApproved, ModerationStatus: comment.StatusRejected, Count: 1},
+		{Day: "2024-05-02", Status: comment.StatusRejected, ModerationStatus: "", Count: 2},
+		{Day: "2024-04-30", Status: comment.StatusPending, Count: 5},
+	})
+	series.addGuard("2024-05-02", map[string]string{
+		"view-log:accept":       "6",
+		"view-log:rate-limited": "3",
+		"share-create:silent":   "1",
+		"bad":                   "9",
+	})
+
+	totals := series.totals()
+	if totals.Comments.Total != 6 || totals.Comments.Approved != 4 || totals.Comments.Rejected != 2 {
+		t.Fatalf("unexpected comment totals: %+v", totals.Comments)
+	}
+	if totals.Moderation.Checked != 4 || totals.Moderation.Rejected != 1 ||
+		totals.Moderation.RejectRate == nil || *totals.Moderation.RejectRate != 0.25 {
+		t.Fatalf("unexpected moderation totals: %+v", totals.Moderation)
+	}
+	if totals.Guard.Accepted != 6 || totals.Guard.Rejected != 4 ||
+		totals.Guard.RejectRate == nil || *totals.Guard.RejectRate != 0.4 {
+		t.Fatalf("unexpected guard totals: %+v", totals.Guard)
+	}
+	if rate := series.points[1].Moderation.RejectRate; rate != nil {
+		t.Fatalf("historical comments should leave the reject rate unknown, got %v", *rate)
+	}
+	items := series.guardItems()
+	if len(items) != 3 || items[0].Scene != "share-create" || items[1].Decision != "accept" {
+		t.Fatalf("unexpected guard items: %+v", items)
+	}
+}
@@ -0,0 +1,179 @@
+package dashboard
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"meta-api/app/model/article"
+	"meta-api/app/model/comment"
+	"meta-api/app/model/user"
+	"meta-api/app/service/article/viewstats"
+	"meta-api/common/guard"
+	"meta-api/common/types"
+)
+
+// dashboardSeries 按天累加的指标，points 与日期一一对应，没有数据的日期各项为 0
+type dashboardSeries struct {
+	points []types.AdminDashboardPoint
+	index  map[string]int
+	// guardDecisions 范围内按（场景, 决策）累计的风控评估次数
+	guardDecisions map[[2]string]int64
+}
+
+func newDashboardSeries(start time.Time, days int) *dashboardSeries {
+	series := &dashboardSeries{
+		points:         make([]types.AdminDashboardPoint, days),
+		index:          make(map[string]int, days),
+		guardDecisions: make(map[[2]string]int64),
+	}
+	for i := range days {
+		day := start.AddDate(0, 0, i).Format(viewstats.DayLayout)
+		series.points[i].Day = day
+		series.index[day] = i
+	}
+	return series
+}
+
+// at 返回某天的指标，不在范围内的日期返回 nil
+func (s *dashboardSeries) at(day string) *types.AdminDashboardMetrics {
+	i, ok := s.index[day]
+	if !ok {
+		return nil
+	}
+	return &s.points[i].AdminDashboardMetrics
+}
+
+func (s *dashboardSeries) addArticles(rows []article.DailyCount) {
+	for _, row := range rows {
+		if metrics := s.at(row.Day); metrics != nil {
+			metrics.ArticlesPublished += row.Count
+		}
+	}
+}
+
+func (s *dashboardSeries) addViews(rows []article.DailyViewCount) {
+	for _, row := range rows {
+		if metrics := s.at(row.Day); metrics != nil {
+			metrics.Views += row.Views
+			metrics.Visitors += row.Visitors
+		}
+	}
+}
+
+func (s *dashboardSeries) addUsers(rows []user.DailyCount) {
+	for _, row := range rows {
+		if metrics := s.at(row.Day); metrics != nil {
+			metrics.NewUsers += row.Count
+		}
+	}
+}
+
+// addComments 按当前状态计入评论数，按提交时的自动审核结果计入审核数；没有审核结果的历史评论不计入审核数
+func (s *dashboardSeries) addComments(rows []comment.DailyStatusCount) {
+	for _, row := range rows {
+		metrics := s.at(row.Day)
+		if metrics == nil {
+			continue
+		}
+		metrics.Comments.Total += row.Count
+		switch row.Status {
+		case comment.StatusPending:
+			metrics.Comments.Pending += row.Count
+		case comment.StatusApproved:
+			metrics.Comments.Approved += row.Count
+		case comment.StatusRejected:
+			metrics.Comments.Rejected += row.Count
+		}
+		if row.ModerationStatus != "" {
+			metrics.Moderation.Checked += row.Count
+			if row.ModerationStatus == comment.StatusRejected {
+				metrics.Moderation.Rejected += row.Count
+			}
+		}
+	}
+}
+
+func (s *dashboardSeries) addReports(rows []comment.DailyStatusCount) {
+	for _, row := range rows {
+		if metrics := s.at(row.Day); metrics != nil {
+			metrics.Reports += row.Count
+		}
+	}
+}
+
+// addGuard 计入某天的风控决策计数，fields 为 guard 按天决策计数 Hash 的全部字段
+func (s *dashboardSeries) addGuard(day string, fields map[string]string) {
+	metrics := s.at(day)
+	if metrics == nil {
+		return
+	}
+	for field, value := range fields {
+		scene, decision, ok := guard.ParseDecisionStatsField(field)
+		if !ok {
+			continue
+		}
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || count <= 0 {
+			continue
+		}
+		if decision == guard.DecisionAccept.String() {
+			metrics.Guard.Accepted += count
+		} else {
+			metrics.Guard.Rejected += count
+		}
+		s.guardDecisions[[2]string{scene, decision}] += count
+	}
+}
+
+// totals 计算每天与整个范围的比率，返回范围内的合计
+func (s *dashboardSeries) totals() types.AdminDashboardMetrics {
+	var totals types.AdminDashboardMetrics
+	for i := range s.points {
+		metrics := &s.points[i].AdminDashboardMetrics
+		fillRates(metrics)
+		totals.ArticlesPublished += metrics.ArticlesPublished
+		totals.Views += metrics.Views
+		totals.Visitors += metrics.Visitors
+		totals.NewUsers += metrics.NewUsers
+		totals.Comments.Total += metrics.Comments.Total
+		totals.Comments.Pending += metrics.Comments.Pending
+		totals.Comments.Approved += metrics.Comments.Approved
+		totals.Comments.Rejected += metrics.Comments.Rejected
+		totals.Reports += metrics.Reports
+		totals.Moderation.Checked += metrics.Moderation.Checked
+		totals.Moderation.Rejected += metrics.Moderation.Rejected
+		totals.Guard.Accepted += metrics.Guard.Accepted
+		totals.Guard.Rejected += metrics.Guard.Rejected
+	}
+	fillRates(&totals)
+	return totals
+}
+
+// guardItems 按场景、次数倒序列出范围内的风控决策计数
+func (s *dashboardSeries) guardItems() []types.AdminDashboardGuardItem {
+	items := make([]types.AdminDashboardGuardItem, 0, len(s.guardDecisions))
+	for key, count := range s.guardDecisions {
+		items = append(items, types.AdminDashboardGuardItem{Scene: key[0], Decision: key[1], Count: count})
+	}
+	slices.SortFunc(items, func(a, b types.AdminDashboardGuardItem) int {
+		return cmp.Or(cmp.Compare(a.Scene, b.Scene), cmp.Compare(b.Count, a.Count), cmp.Compare(a.Decision, b.Decision))
+	})
+	return items
+}
+
+func fillRates(metrics *types.AdminDashboardMetrics) {
+	metrics.Moderation.RejectRate = ratio(metrics.Moderation.Rejected, metrics.Moderation.Checked)
+	metrics.Guard.RejectRate = ratio(metrics.Guard.Rejected, metrics.Guard.Accepted+metrics.Guard.Rejected)
+}
+
+// ratio 保留四位小数，分母为 0 时没有样本，返回 nil 表示未知
+func ratio(part int64, whole int64) *float64 {
+	if whole <= 0 {
+		return nil
+	}
+	rate := math.Round(float64(part)/float64(whole)*10000) / 10000
+	return &rate
+}
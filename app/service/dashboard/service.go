@@ -0,0 +1,55 @@
+// Package dashboard 汇总后台数据看板的各项指标。
+//
+// 文章、浏览量、用户、评论与举报按天分组直接从 MySQL 统计，风控拦截量来自 guard 写入 Redis 的按天决策计数；
+// 同一时间范围的统计结果整体缓存在 Redis 中，过期后先返回旧数据再后台刷新。
+//
+// 文件分布：
+//
+//	service.go   —— Service 接口 + impl + DI 构造
+//	dashboard.go —— 时间范围解析、读穿缓存与各数据源查询
+//	series.go    —— 按天序列的累加与合计（纯数据转换）
+package dashboard
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"meta-api/app/model/article"
+	"meta-api/app/model/comment"
+	"meta-api/app/model/user"
+	"meta-api/common/readcache"
+	"meta-api/common/types"
+	"meta-api/config"
+)
+
+// Service 后台数据看板服务接口
+type Service interface {
+	AdminGetDashboard(ctx context.Context, request *types.AdminGetDashboardRequest) (*types.AdminGetDashboardResponse, error)
+}
+
+// dashboardService 后台数据看板服务实现
+type dashboardService struct {
+	config       *config.Config
+	logger       *zap.Logger
+	redis        *redis.Client
+	articleModel article.Model
+	commentModel comment.Model
+	userModel    user.Model
+	cache        *readcache.Cache[types.AdminGetDashboardResponse]
+}
+
+// NewService 创建服务实例
+func NewService(config *config.Config, logger *zap.Logger, redis *redis.Client, articleModel article.Model,
+	commentModel comment.Model, userModel user.Model) Service {
+	return &dashboardService{
+		config:       config,
+		logger:       logger,
+		redis:        redis,
+		articleModel: articleModel,
+		commentModel: commentModel,
+		userModel:    userModel,
+		cache:        newDashboardCache(readcache.NewRedisStore(redis), logger),
+	}
+}
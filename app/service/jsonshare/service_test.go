@@ -54,6 +54,10 @@ func (s *fakeGuardStore) TokenConsume(context.Context, guard.Scene, string) (str
 	return value, true, nil
 }
 
+func (s *fakeGuardStore) RecordDecision(context.Context, guard.Scene, guard.Decision, string) error {
+	return nil
+}
+
 func TestPrecheckIssuesScopedTokenClaims(t *testing.T) {
 	store := &fakeGuardStore{}
 	service := NewService(zap.NewNop(), &fakeGuardEngine{
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	siteDynamicModel "meta-api/app/model/sitedynamic"
+	"meta-api/common/readcache"
 	"meta-api/common/types"
 )
 
@@ -26,6 +27,8 @@ type siteDynamicService struct {
 	idGenerator *sonyflake.Sonyflake
 	redis       *redis.Client
 	model       siteDynamicModel.Model
+
+	publishedCache *readcache.Cache[[]types.UserSiteDynamicItem]
 }
 
 func NewService(logger *zap.Logger, idGenerator *sonyflake.Sonyflake, redis *redis.Client,
@@ -35,5 +38,7 @@ func NewService(logger *zap.Logger, idGenerator *sonyflake.Sonyflake, redis *red
 		idGenerator: idGenerator,
 		redis:       redis,
 		model:       model,
+
+		publishedCache: newPublishedCache(readcache.NewRedisStore(redis), logger),
 	}
 }
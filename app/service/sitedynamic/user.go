@@ -2,73 +2,50 @@ package sitedynamic
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"time"
 
-	"github.com/bytedance/sonic"
-	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 
 	"meta-api/common/cachekey"
+	"meta-api/common/readcache"
 	"meta-api/common/types"
 )
 
-const publishedCacheTTL = 10 * time.Minute
+// 已发布动态的读穿缓存：后台增删改时主动删除，过期后 1 分钟内先返回旧数据再后台刷新
+const (
+	publishedCacheTTL      = 10 * time.Minute
+	publishedCacheStaleTTL = time.Minute
+	publishedCacheJitter   = 0.1
+)
+
+func newPublishedCache(store readcache.Store, logger *zap.Logger) *readcache.Cache[[]types.UserSiteDynamicItem] {
+	return readcache.New[[]types.UserSiteDynamicItem](store, readcache.Options{
+		TTL:      publishedCacheTTL,
+		StaleTTL: publishedCacheStaleTTL,
+		Jitter:   publishedCacheJitter,
+		OnError: func(key string, err error) {
+			logger.Warn("site dynamic cache degraded", zap.String("key", key), zap.Error(err))
+		},
+	})
+}
 
 func (s *siteDynamicService) UserGetSiteDynamicList(ctx context.Context) (*types.UserGetSiteDynamicListResponse, error) {
-	key := cachekey.SiteDynamicPublishedList().String()
-	response := &types.UserGetSiteDynamicListResponse{}
-	if s.redis != nil {
-		rows, err := s.getPublishedFromCache(ctx, key)
-		if err == nil && rows != nil {
-			response.Rows = rows
-			response.Total = len(rows)
-			return response, nil
-		}
-		if err != nil {
-			s.logger.Warn("failed to get site dynamics from redis", zap.Error(err))
-		}
+	items, err := s.publishedCache.Get(ctx, cachekey.SiteDynamicPublishedList().String(), s.loadPublished)
+	if err != nil {
+		s.logger.Error("failed to list published site dynamics", zap.Error(err))
+		return nil, err
 	}
+	return &types.UserGetSiteDynamicListResponse{Rows: items, Total: len(items)}, nil
+}
 
+func (s *siteDynamicService) loadPublished(ctx context.Context) ([]types.UserSiteDynamicItem, error) {
 	rows, err := s.model.ListPublishedSiteDynamics(ctx)
 	if err != nil {
-		s.logger.Error("failed to list published site dynamics", zap.Error(err))
 		return nil, err
 	}
 	items := make([]types.UserSiteDynamicItem, 0, len(rows))
 	for _, row := range rows {
 		items = append(items, toUserSiteDynamicItem(row))
 	}
-	if s.redis != nil {
-		if err = s.setPublishedCache(ctx, key, items); err != nil {
-			s.logger.Warn("failed to set site dynamics to redis", zap.Error(err))
-		}
-	}
-	response.Rows = items
-	response.Total = len(items)
-	return response, nil
-}
-
-func (s *siteDynamicService) getPublishedFromCache(ctx context.Context, key string) ([]types.UserSiteDynamicItem, error) {
-	value, err := s.redis.Get(ctx, key).Result()
-	if err != nil && !errors.Is(err, redis.Nil) {
-		return nil, err
-	}
-	if errors.Is(err, redis.Nil) {
-		return nil, nil
-	}
-	items := make([]types.UserSiteDynamicItem, 0)
-	if err = sonic.Unmarshal([]byte(value), &items); err != nil {
-		return nil, fmt.Errorf("unmarshal site dynamic cache: %w", err)
-	}
 	return items, nil
 }
-
-func (s *siteDynamicService) setPublishedCache(ctx context.Context, key string, items []types.UserSiteDynamicItem) error {
-	value, err := sonic.Marshal(items)
-	if err != nil {
-		return fmt.Errorf("marshal site dynamic cache: %w", err)
-	}
-	return s.redis.Set(ctx, key, value, publishedCacheTTL).Err()
-}
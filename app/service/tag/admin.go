@@ -126,17 +126,7 @@ func (t *tagService) AdminGetArticleListByTag(ctx context.Context,
 				t.logger.Error("failed to get article from MySQL", zap.Error(mysqlErr))
 				return nil, mysqlErr
 			}
-			mapData := map[string]any{
-				"id":         articleInfo.ID,
-				"title":      articleInfo.Title,
-				"describe":   articleInfo.Describe,
-				"content":    articleInfo.Content,
-				"viewNum":    articleInfo.ViewNum,
-				"createTime": articleInfo.CreateTime.Format(constants.TimeLayoutToSecond),
-				"updateTime": articleInfo.UpdateTime.Format(constants.TimeLayoutToSecond),
-				"tagID":      articleTagIDValue(articleInfo.TagID),
-				"tagName":    articleInfo.TagName,
-			}
+			mapData := articleInfo.HashData()
 			if err = t.redis.HMSet(ctx, cachekey.ArticleHash(articleID).String(), mapData).Err(); err != nil {
 				t.logger.Error("failed to write article:articleID:ZSet", zap.Error(err))
 				return nil, fmt.Errorf("failed to write article:articleID:ZSet: %w", err)
@@ -200,8 +190,17 @@ func (t *tagService) AdminUpdateTag(ctx context.Context, request *types.AdminUpd
 		}
 	}
 
-	// 更新文章表中的标签 ID
-	if err = t.articleModel.UpdateArticleTagID(ctx, request.ArticleIDList, tagInfo.ID); err != nil {
+	oldTagInfo, err := t.tagModel.FindTagByName(ctx, request.OldTagName)
+	if err != nil {
+		t.logger.Error("FindTagByName error", zap.Error(err))
+		return fmt.Errorf("FindTagByName error: %w", err)
+	}
+	if oldTagInfo.ID == 0 {
+		return fmt.Errorf("not found tagName")
+	}
+
+	// 把文章上的旧标签替换为新标签，文章的其他标签保持不变
+	if err = t.articleModel.ReplaceArticleTag(ctx, request.ArticleIDList, oldTagInfo.ID, tagInfo.ID); err != nil {
 		t.logger.Error("failed to update article list tag", zap.Error(err))
 		return fmt.Errorf("failed to update article list tag: %w", err)
 	}
@@ -221,7 +220,7 @@ func (t *tagService) AdminUpdateTag(ctx context.Context, request *types.AdminUpd
 
 	// 删除缓存脏数据
 	for _, id := range request.ArticleIDList {
-		if err = t.redis.Del(ctx, cachekey.ArticleHash(id).String()).Err(); err != nil {
+		if err = t.redis.Del(ctx, cachekey.ArticleHash(id).String(), cachekey.ArticleDetailCache(id).String()).Err(); err != nil {
 			t.logger.Error("failed to delete article:id:Hash", zap.Error(err))
 			return fmt.Errorf("failed to delete article:id:Hash: %w", err)
 		}
@@ -237,16 +236,21 @@ func (t *tagService) AdminUpdateTag(ctx context.Context, request *types.AdminUpd
 		return fmt.Errorf("failed to delete newTagName:article:ZSet: %w", err)
 	}
 
-	if err = t.redis.Del(ctx, cachekey.TagArticleNumZSet().String()).Err(); err != nil {
+	if err = t.redis.Del(ctx, cachekey.TagArticleNumZSet().String(), cachekey.TagListCache().String()).Err(); err != nil {
 		t.logger.Error("failed to delete tag:articleNum:ZSet", zap.Error(err))
 		return fmt.Errorf("failed to delete tag:articleNum:ZSet: %w", err)
 	}
 
 	// 刷新 sitemap 内部缓存，让标签 URL 和文章归属变更尽快反映到 sitemap.xml。
-	t.sitemap.RefreshArticles(request.ArticleIDList...)
+	urlKeys := t.articleURLKeys(ctx, request.ArticleIDList)
+	t.sitemap.RefreshArticles(urlKeys...)
+	// 文章所属标签变化，订阅源中的分类随之失效
+	if err = t.redis.Incr(ctx, cachekey.ArticleFeedVersion().String()).Err(); err != nil {
+		t.logger.Error("failed to bump article feed version", zap.Error(err))
+	}
 
-	// 清理 CDN 上受影响文章详情 HTML 缓存，避免页面继续展示旧标签。
-	if err = t.cdn.PurgeArticles(request.ArticleIDList...); err != nil {
+	// 清理 CDN 上受影响文章详情 HTML 缓存（ID 与 slug 两种地址），避免页面继续展示旧标签。
+	if err = t.cdn.PurgeArticles(urlKeys...); err != nil {
 		t.logger.Error("failed to purge article CDN cache",
 			zap.Strings("article_ids", request.ArticleIDList), zap.Error(err))
 		return fmt.Errorf("failed to purge article CDN cache: %w", err)
@@ -254,3 +258,19 @@ func (t *tagService) AdminUpdateTag(ctx context.Context, request *types.AdminUpd
 
 	return nil
 }
+
+// articleURLKeys 文章详情页的全部地址段（ID、当前 slug 与历史 slug），查询失败时退回只使用文章 ID
+func (t *tagService) articleURLKeys(ctx context.Context, articleIDList []string) []string {
+	ids := make([]uint64, 0, len(articleIDList))
+	for _, articleID := range articleIDList {
+		if id, err := strconv.ParseUint(articleID, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	keys, err := t.articleModel.ListArticleURLKeys(ctx, ids)
+	if err != nil {
+		t.logger.Error("failed to list article url keys", zap.Error(err))
+		return articleIDList
+	}
+	return keys
+}
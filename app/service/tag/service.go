@@ -2,6 +2,7 @@ package tag
 
 import (
 	"context"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/sony/sonyflake"
@@ -9,12 +10,20 @@ import (
 
 	"meta-api/app/model/article"
 	"meta-api/app/model/tag"
+	"meta-api/common/readcache"
 	"meta-api/common/types"
 	"meta-api/config"
 	"meta-api/pkg/cdn"
 	"meta-api/pkg/sitemap"
 )
 
+// 标签列表读穿缓存：文章增删改时主动删除，TTL 只兜底
+const (
+	tagListCacheTTL      = 10 * time.Minute
+	tagListCacheStaleTTL = 2 * time.Minute
+	tagListCacheJitter   = 0.1
+)
+
 // Service 标签服务接口
 type Service interface {
 	AdminGetTagList(ctx context.Context) (*types.AdminGetTagListResponse, error)
@@ -35,6 +44,7 @@ type tagService struct {
 	articleModel article.Model
 	cdn          *cdn.Client
 	sitemap      *sitemap.Client
+	listCache    *readcache.Cache[types.UserGetTagListResponse]
 }
 
 // NewService 创建服务实例
@@ -49,5 +59,17 @@ func NewService(config *config.Config, logger *zap.Logger, idGenerator *sonyflak
 		articleModel: articleModel,
 		cdn:          cdnClient,
 		sitemap:      sm,
+		listCache:    newTagListCache(readcache.NewRedisStore(redis), logger),
 	}
 }
+
+func newTagListCache(store readcache.Store, logger *zap.Logger) *readcache.Cache[types.UserGetTagListResponse] {
+	return readcache.New[types.UserGetTagListResponse](store, readcache.Options{
+		TTL:      tagListCacheTTL,
+		StaleTTL: tagListCacheStaleTTL,
+		Jitter:   tagListCacheJitter,
+		OnError: func(key string, err error) {
+			logger.Warn("tag list cache degraded", zap.String("key", key), zap.Error(err))
+		},
+	})
+}
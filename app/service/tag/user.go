@@ -14,16 +14,25 @@ import (
 	"meta-api/common/types"
 )
 
-// UserGetTagList 获取标签列表
+// UserGetTagList 获取标签列表，经读穿缓存合并并发回源
 func (t *tagService) UserGetTagList(ctx context.Context) (*types.UserGetTagListResponse, error) {
-	response := &types.UserGetTagListResponse{}
+	response, err := t.listCache.Get(ctx, cachekey.TagListCache().String(), t.loadTagList)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// loadTagList 从标签文章数 ZSet 读取标签列表，ZSet 不存在时从 MySQL 重建
+func (t *tagService) loadTagList(ctx context.Context) (types.UserGetTagListResponse, error) {
+	response := types.UserGetTagListResponse{}
 	key := cachekey.TagArticleNumZSet().String()
 
 	if exist := t.redis.Exists(ctx, key).Val(); exist == 0 {
 		articleCountWithTagNameList, err := t.tagModel.GetArticleCountWithTagName(ctx)
 		if err != nil {
 			t.logger.Error("failed to get ArticleCountWithTag", zap.Error(err))
-			return nil, fmt.Errorf("failed to get ArticleCountWithTag, err: %w", err)
+			return response, fmt.Errorf("failed to get ArticleCountWithTag, err: %w", err)
 		}
 		if len(articleCountWithTagNameList) > 0 {
 			zAddArgs := make([]redis.Z, len(articleCountWithTagNameList))
@@ -41,7 +50,7 @@ func (t *tagService) UserGetTagList(ctx context.Context) (*types.UserGetTagListR
 			// 批量写入 Redis
 			if err = t.redis.ZAdd(ctx, key, zAddArgs...).Err(); err != nil {
 				t.logger.Error("failed to write tag:articleNum:ZSet", zap.Error(err))
-				return nil, fmt.Errorf("failed to write tag:articleNum:ZSet, err: %w", err)
+				return response, fmt.Errorf("failed to write tag:articleNum:ZSet, err: %w", err)
 			}
 		}
 	} else {
@@ -49,7 +58,7 @@ func (t *tagService) UserGetTagList(ctx context.Context) (*types.UserGetTagListR
 		tagZSet, err := t.redis.ZRevRangeWithScores(ctx, key, 0, -1).Result()
 		if err != nil {
 			t.logger.Error("failed to get tag:articleNum:ZSet", zap.Error(err))
-			return nil, fmt.Errorf("failed to get tag:articleNum:ZSet, err: %w", err)
+			return response, fmt.Errorf("failed to get tag:articleNum:ZSet, err: %w", err)
 		}
 
 		for _, label := range tagZSet {
@@ -74,8 +83,8 @@ func (t *tagService) UserGetArticleListByTag(ctx context.Context,
 	key := cachekey.TagArticleListZSet(request.TagName).String()
 	response := &types.UserGetArticleListByTagResponse{}
 
-	// 获取文章ID列表(包含分页条件)
-	articleIDList, err := t.redis.ZRevRange(ctx, key, int64(start), int64(stop)).Result()
+	// 获取文章ID列表，过滤不公开的文章后再分页
+	articleIDList, err := t.redis.ZRevRange(ctx, key, 0, -1).Result()
 	if err != nil {
 		t.logger.Error("failed to get article:ZSet", zap.Error(err))
 		return nil, fmt.Errorf("failed to get article:ZSet: %w", err)
@@ -100,13 +109,21 @@ func (t *tagService) UserGetArticleListByTag(ctx context.Context,
 				return nil, err
 			}
 		}
-		// 再次获取数据(包含分页条件)
-		articleIDList, err = t.redis.ZRevRange(ctx, key, int64(start), int64(stop)).Result()
+		// 再次获取数据
+		articleIDList, err = t.redis.ZRevRange(ctx, key, 0, -1).Result()
 		if err != nil {
 			t.logger.Error("failed to get article:ZSet", zap.Error(err))
 			return nil, err
 		}
 	}
+	hidden, err := t.redis.SMembers(ctx, cachekey.ArticleHiddenSet().String()).Result()
+	if err != nil {
+		t.logger.Error("failed to get hidden article set", zap.Error(err))
+		return nil, fmt.Errorf("failed to get hidden article set: %w", err)
+	}
+	articleIDList = visibleArticleIDs(articleIDList, hidden)
+	total := len(articleIDList)
+	articleIDList = articleIDList[min(start, total):min(stop+1, total)]
 
 	// 获取 Redis 当中的文章Hash数据
 	fields := []string{"title", "describe", "viewNum", "createTime"}
@@ -130,17 +147,7 @@ func (t *tagService) UserGetArticleListByTag(ctx context.Context,
 			articleItem.CreateTime = articleInfo.CreateTime.Format(constants.TimeLayoutToMinute)
 			articleItem.UpdateTime = articleInfo.UpdateTime.Format(constants.TimeLayoutToMinute)
 
-			mapData := map[string]any{
-				"id":         articleInfo.ID,
-				"title":      articleInfo.Title,
-				"describe":   articleInfo.Describe,
-				"content":    articleInfo.Content,
-				"viewNum":    articleInfo.ViewNum,
-				"createTime": articleInfo.CreateTime.Format(constants.TimeLayoutToSecond),
-				"updateTime": articleInfo.UpdateTime.Format(constants.TimeLayoutToSecond),
-				"tagID":      articleTagIDValue(articleInfo.TagID),
-				"tagName":    articleInfo.TagName,
-			}
+			mapData := articleInfo.HashData()
 			if err = t.redis.HMSet(ctx, cachekey.ArticleHash(articleItem.ID).String(), mapData).Err(); err != nil {
 				t.logger.Error("failed to write article:articleID:ZSet", zap.Error(err))
 				return nil, fmt.Errorf("failed to write article:articleID:ZSet: %w", err)
@@ -168,6 +175,24 @@ func (t *tagService) UserGetArticleListByTag(ctx context.Context,
 		response.Rows = append(response.Rows, articleItem)
 	}
 
-	response.Total = int(t.redis.ZCard(ctx, key).Val())
+	response.Total = total
 	return response, nil
 }
+
+// visibleArticleIDs 按原顺序去掉不公开的文章
+func visibleArticleIDs(articleIDs []string, hidden []string) []string {
+	if len(hidden) == 0 {
+		return articleIDs
+	}
+	hiddenSet := make(map[string]struct{}, len(hidden))
+	for _, id := range hidden {
+		hiddenSet[id] = struct{}{}
+	}
+	visible := make([]string, 0, len(articleIDs))
+	for _, id := range articleIDs {
+		if _, ok := hiddenSet[id]; !ok {
+			visible = append(visible, id)
+		}
+	}
+	return visible
+}
@@ -6,19 +6,51 @@ import (
 
 	"go.uber.org/zap"
 
+	"meta-api/app/service/article/viewstats"
 	"meta-api/common/cachekey"
 )
 
-const incrementTimeout = time.Second
+const (
+	incrementTimeout = time.Second
+	// dayBucketTTL 按天分桶与按天访客 HyperLogLog 在 Redis 中的保留时长，远大于回写周期，停机数天后重启仍能补回写
+	dayBucketTTL = 7 * 24 * time.Hour
+)
 
-// Increment 通过 Redis 完成"浏览量 +1"
-func (s *viewLogService) Increment(articleID string) {
+// Increment 通过 Redis 完成"浏览量 +1"，同时累加当天（及可选的当前小时）分桶与趋势分桶；
+// fingerprint 非空时写入当天的去重访客 HyperLogLog
+func (s *viewLogService) Increment(articleID string, fingerprint string) {
 	ctx, cancel := context.WithTimeout(context.Background(), incrementTimeout)
 	defer cancel()
 
+	now := time.Now()
+	day := viewstats.DayKey(now)
+	dayKey := cachekey.ArticleViewDayHash(day).String()
+
 	pipe := s.redis.Pipeline()
 	pipe.HIncrBy(ctx, cachekey.ArticleHash(articleID).String(), "viewNum", 1)
 	pipe.ZIncrBy(ctx, cachekey.ArticleViewZSet().String(), 1, articleID)
+	pipe.HIncrBy(ctx, dayKey, articleID, 1)
+	pipe.Expire(ctx, dayKey, dayBucketTTL)
+	pipe.SAdd(ctx, cachekey.ArticleViewPendingDaySet().String(), day)
+	trendingKey := cachekey.ArticleTrendingHourHash(viewstats.HourKey(now)).String()
+	pipe.HIncrBy(ctx, trendingKey, viewstats.TrendingField(articleID, viewstats.TrendingEventView), 1)
+	pipe.Expire(ctx, trendingKey, s.config.RankingSnapshot().TrendingWindow()+time.Hour)
+	if fingerprint != "" {
+		visitorKey := cachekey.ArticleVisitorHLL(viewstats.UnitDay, day, articleID).String()
+		siteVisitorKey := cachekey.SiteVisitorHLL(viewstats.UnitDay, day).String()
+		uniqueViewKey := cachekey.SiteUniqueViewHLL(viewstats.UnitDay, day).String()
+		pipe.PFAdd(ctx, visitorKey, fingerprint)
+		pipe.Expire(ctx, visitorKey, dayBucketTTL)
+		pipe.PFAdd(ctx, siteVisitorKey, fingerprint)
+		pipe.Expire(ctx, siteVisitorKey, dayBucketTTL)
+		pipe.PFAdd(ctx, uniqueViewKey, articleID+":"+fingerprint)
+		pipe.Expire(ctx, uniqueViewKey, dayBucketTTL)
+	}
+	if stats := s.config.ViewStatsSnapshot(); stats.Hourly {
+		hourKey := cachekey.ArticleViewHourHash(viewstats.HourKey(now)).String()
+		pipe.HIncrBy(ctx, hourKey, articleID, 1)
+		pipe.Expire(ctx, hourKey, stats.HourlyRetention()+time.Hour)
+	}
 	if _, err := pipe.Exec(ctx); err != nil {
 		s.logger.Warn("view-log increment failed", zap.String("article_id", articleID), zap.Error(err))
 	}
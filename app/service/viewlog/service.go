@@ -2,14 +2,15 @@
 //
 // 职责：
 //  1. EnsureArticleExists：MySQL 校验 articleId 是否真实存在
-//  2. Increment：Redis HINCRBY + ZINCRBY 完成 +1
+//  2. Increment：Redis HINCRBY + ZINCRBY 完成 +1，并累加按天 / 按小时分桶与去重访客 HyperLogLog
 //
-// MySQL 由后台 cron PersistViewCount 周期性把 ZSet 里的增量回写。
+// MySQL 由后台 cron PersistViewCount 周期性把 ZSet 里的增量回写；
+// 按天分桶由 article 服务的 FlushViewStats 回写到 article_view_daily。
 //
 // 文件分布：
 //
 //	service.go —— Service 接口 + impl + DI 构造 + 文章存在性校验
-//	counter.go —— 计数（Redis HINCRBY + ZINCRBY）
+//	counter.go —— 计数（Redis HINCRBY + ZINCRBY + 时间分桶 + PFADD）
 //	types.go   —— Outcome 结构与业务码
 package viewlog
 
@@ -23,6 +24,7 @@ import (
 
 	articleModel "meta-api/app/model/article"
 	"meta-api/common/codes"
+	"meta-api/config"
 )
 
 // Service 浏览量打点服务接口。专供新链路（guard.Engine）调用 EnsureArticleExists / Increment。
@@ -32,22 +34,25 @@ type Service interface {
 	// 返回 nil 表示存在，可继续 +1；返回 *Outcome 表示需要按对应 HTTP 状态返回。
 	EnsureArticleExists(ctx context.Context, articleID string) *Outcome
 
-	// Increment 执行计数 +1（HINCRBY + ZINCRBY，与原内部 increment 行为一致）。
+	// Increment 执行计数 +1（HINCRBY + ZINCRBY，与原内部 increment 行为一致），并记录浏览时间分桶。
+	// fingerprint 为 guard.Outcome.Fingerprint，用于按天统计去重访客。
 	//
 	// 对失败仅打日志，调用方无需感知错误（避免响应差异成为攻击信号）。
-	Increment(articleID string)
+	Increment(articleID string, fingerprint string)
 }
 
 // viewLogService 浏览量打点服务实现。
 type viewLogService struct {
+	config       *config.Config
 	logger       *zap.Logger
 	redis        *redis.Client
 	articleModel articleModel.Model
 }
 
 // NewService 构造打点服务实例。
-func NewService(logger *zap.Logger, rdb *redis.Client, am articleModel.Model) Service {
+func NewService(cfg *config.Config, logger *zap.Logger, rdb *redis.Client, am articleModel.Model) Service {
 	return &viewLogService{
+		config:       cfg,
 		logger:       logger,
 		redis:        rdb,
 		articleModel: am,
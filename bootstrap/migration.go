@@ -0,0 +1,35 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// schemaMigration 已执行的一次性数据迁移，Name 唯一
+type schemaMigration struct {
+	Name      string    `gorm:"column:name;type:varchar(100);primaryKey"`
+	AppliedAt time.Time `gorm:"column:applied_at;NOT NULL"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migration"
+}
+
+// runMigrationOnce 在同一事务内先写入迁移标记再执行迁移：标记已存在时直接跳过；
+// 多实例同时启动时，后到的实例在主键冲突处等待先到的事务提交，随后同样跳过
+func runMigrationOnce(db *gorm.DB, name string, migrate func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&schemaMigration{Name: name, AppliedAt: time.Now()})
+		if result.Error != nil {
+			return fmt.Errorf("failed to record migration %s: %w", name, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return migrate(tx)
+	})
+}
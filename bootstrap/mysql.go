@@ -243,19 +243,35 @@ func autoMigrateMySQL(db *gorm.DB) error {
 	if db == nil {
 		return fmt.Errorf("mysql db is nil")
 	}
+	if err := articleModel.MigrateArticleImageContentHash(db); err != nil {
+		return fmt.Errorf("migrate article image content hash: %w", err)
+	}
 	if err := db.AutoMigrate(
 		&adminModel.Admin{},
 		&tagModel.Tag{},
 		&articleModel.Article{},
+		&articleModel.ArticleTag{},
+		&articleModel.ArticleSlugHistory{},
+		&articleModel.Series{},
+		&articleModel.SeriesArticle{},
+		&articleModel.ArticlePromotion{},
 		&articleModel.ArticleImage{},
 		&articleModel.ArticleImageReference{},
+		&articleModel.ArticleImageVariant{},
+		&articleModel.ArticleRevision{},
+		&articleModel.ArticleViewDaily{},
+		&articleModel.SiteViewDaily{},
 		&linkModel.Link{},
 		&siteDynamicModel.SiteDynamic{},
 		&userModel.User{},
 		&commentModel.Comment{},
 		&commentModel.CommentReport{},
+		&schemaMigration{},
 	); err != nil {
 		return fmt.Errorf("auto migrate mysql tables: %w", err)
 	}
+	if err := runMigrationOnce(db, "article_tag_from_legacy_tag_id", articleModel.MigrateLegacyArticleTags); err != nil {
+		return fmt.Errorf("migrate legacy article tags: %w", err)
+	}
 	return nil
 }
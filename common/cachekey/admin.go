@@ -33,3 +33,8 @@ func AboutMeHash() Key { return "aboutMeInfo:Hash" }
 func SMSCode(phone string) Key {
 	return build("sms", "code", phone)
 }
+
+// AdminDashboard 后台数据看板统计结果缓存，按日期范围隔离。
+func AdminDashboard(from string, to string) Key {
+	return build(nsAdmin, "dashboard", from, to)
+}
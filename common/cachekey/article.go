@@ -19,6 +19,70 @@ func ArticleViewZSet() Key { return build(nsArticle, OrderView, "ZSet") }
 // ArticleHash 单篇文章详情缓存（Hash 结构）
 func ArticleHash(id string) Key { return build(nsArticle, id, "Hash") }
 
+// ArticleViewDayHash 某一天（站点时区，2006-01-02）各文章的浏览量累计，field 为文章 ID
+func ArticleViewDayHash(day string) Key { return build(nsArticle, "views", "day", day, "Hash") }
+
+// ArticleViewHourHash 某一小时（站点时区，2006-01-02T15）各文章的浏览量累计，field 为文章 ID
+func ArticleViewHourHash(hour string) Key { return build(nsArticle, "views", "hour", hour, "Hash") }
+
+// ArticleViewPendingDaySet 有尚未回写到 MySQL 的按天浏览量的日期集合
+func ArticleViewPendingDaySet() Key { return build(nsArticle, "views", "day", "pending", "Set") }
+
+// ArticleVisitorHLL 文章在某一周期内按指纹去重的访客（HyperLogLog）。
+// unit 为 day / week / month，period 为对应格式的周期（2006-01-02 / 2024-W18 / 2006-01）
+func ArticleVisitorHLL(unit string, period string, articleID string) Key {
+	return build(nsArticle, "visitors", unit, period, articleID, "HLL")
+}
+
+// SiteVisitorHLL 全站在某一周期内按指纹去重的访客（HyperLogLog）
+func SiteVisitorHLL(unit string, period string) Key {
+	return build(nsArticle, "visitors", unit, period, "site", "HLL")
+}
+
+// SiteUniqueViewHLL 全站在某一周期内去重后的（访客, 文章）组合（HyperLogLog）
+func SiteUniqueViewHLL(unit string, period string) Key {
+	return build(nsArticle, "uniqueViews", unit, period, "HLL")
+}
+
+// ArticleTrendingHourHash 某一小时（站点时区，2006-01-02T15）内的趋势事件计数，
+// field 为 {文章ID}:view / {文章ID}:comment
+func ArticleTrendingHourHash(hour string) Key {
+	return build(nsArticle, "trending", "hour", hour, "Hash")
+}
+
+// ArticleTrendingZSet 按时间衰减后的趋势分数排序的文章集合，由定时任务整体重建
+func ArticleTrendingZSet() Key { return build(nsArticle, "trending", "ZSet") }
+
+// ArticlePromotionList 前台推荐位（pinned / featured）整包缓存（JSON），顺序与后台设置一致
+func ArticlePromotionList(kind string) Key { return build(nsArticle, "promotion", kind, "String") }
+
+// ArticleScheduleLock 定时发布/下线任务的分布式锁，避免多实例重复执行
+func ArticleScheduleLock() Key { return build(nsArticle, "schedule", "Lock") }
+
+// ArticleImageGCLock 未引用图片回收任务的分布式锁，避免多实例同时扫描并删除同一批图片
+func ArticleImageGCLock() Key { return build(nsArticle, "image", "gc", "Lock") }
+
+// ArticleFeedVersion 订阅源版本号，文章发布/更新/删除时自增，使旧版本的订阅缓存整体失效
+func ArticleFeedVersion() Key { return build(nsArticle, "feed", "Version") }
+
+// ArticleFeedHash 某一版本下渲染好的订阅源（body / etag / lastModified）。
+// tagName 为空表示全站订阅源。
+func ArticleFeedHash(version string, format string, tagName string) Key {
+	if tagName == "" {
+		return build(nsArticle, "feed", version, format, "Hash")
+	}
+	return build(nsArticle, "feed", version, format, "tag", tagName, "Hash")
+}
+
+// ArticleArchiveVersion 按月归档版本号，文章发布/更新/删除时自增，使旧版本的归档缓存整体失效
+func ArticleArchiveVersion() Key { return build(nsArticle, "archive", "Version") }
+
+// ArticleArchiveString 某一版本下的归档缓存（JSON）。period 为 months 表示年月汇总，
+// 为 2006-01 表示该月的文章列表
+func ArticleArchiveString(version string, period string) Key {
+	return build(nsArticle, "archive", version, period, "String")
+}
+
 // ArticleOrderZSet 按运行时维度（time / view）选择对应的 ZSet。
 // order 必须是合法枚举之一，否则返回 ok = false（避免用户输入污染 Key 命名空间）。
 func ArticleOrderZSet(order string) (Key, bool) {
@@ -35,3 +99,32 @@ func ArticleTimeScore(t time.Time) float64 { return float64(t.UnixMilli()) }
 
 // ArticleViewScore 文章按浏览量排序的 score
 func ArticleViewScore(viewNum uint64) float64 { return float64(viewNum) }
+
+// ArticleFingerprintHash 已发布文章的 SimHash 指纹，field 为文章 ID，value 为十进制指纹
+func ArticleFingerprintHash() Key { return build(nsArticle, "fingerprint", "Hash") }
+
+// ArticleRelatedZSet 单篇文章内容最相近的 top-K 文章，score 为指纹相同的位数
+func ArticleRelatedZSet(id string) Key { return build(nsArticle, id, "related", "ZSet") }
+
+// ArticleRelatedTagCache 内容相似文章不足时回退的同标签文章 ID 列表（读穿缓存）
+func ArticleRelatedTagCache(id string) Key { return build(nsArticle, id, "related", "tag", "String") }
+
+// ArticleSlugHash 文章当前 slug 到文章 ID 的映射，field 为 slug；历史 slug 不缓存，直接查库
+func ArticleSlugHash() Key { return build(nsArticle, "slug", "Hash") }
+
+// ArticleHiddenSet 已发布但不公开（unlisted / protected）的文章 ID 集合，前台列表类接口据此过滤
+func ArticleHiddenSet() Key { return build(nsArticle, "hidden", "Set") }
+
+// ArticleRateLimit 前台文章相关限流 Key（如加密文章解锁）。
+func ArticleRateLimit(parts ...string) Key {
+	return build(append([]string{nsArticle, "rate-limit"}, parts...)...)
+}
+
+// ArticleEditLeaseHash 文章或草稿的编辑租约（sessionID / userID / acquiredAt），kind 为 article / draft，
+// 带过期时间，编辑器定时续期
+func ArticleEditLeaseHash(kind string, id string) Key {
+	return build(nsArticle, "editLease", kind, id, "Hash")
+}
+
+// ArticleDetailCache 前台文章详情的读穿缓存（JSON），带过期时间，写路径与 ArticleHash 一起删除
+func ArticleDetailCache(id string) Key { return build(nsArticle, id, "detail", "String") }
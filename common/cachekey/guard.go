@@ -21,3 +21,8 @@ func GuardToken(scene string, tokenHex string) Key {
 func GuardRate(scene string, dimension string, subject string, window string) Key {
 	return build(nsGuard, scene, "rate", dimension, subject, window)
 }
+
+// GuardDecisionStatsHash 风控按天（站点时区）的决策计数 Hash，field 为 {scene}:{decision}。
+func GuardDecisionStatsHash(day string) Key {
+	return build(nsGuard, "stats", day, "Hash")
+}
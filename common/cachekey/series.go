@@ -0,0 +1,12 @@
+package cachekey
+
+const nsSeries = "series"
+
+// SeriesList 前台系列列表整包缓存（JSON）
+func SeriesList() Key { return build(nsSeries, "list", "String") }
+
+// SeriesHash 单个系列详情缓存，articles 字段为按系列顺序排列的已发布文章（JSON）
+func SeriesHash(id string) Key { return build(nsSeries, id, "Hash") }
+
+// ArticleSeries 文章所属系列 ID，与 article:{id}:Hash 并列存放；"0" 表示不属于任何系列
+func ArticleSeries(articleID string) Key { return build(nsArticle, articleID, "series", "String") }
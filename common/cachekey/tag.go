@@ -13,3 +13,6 @@ func TagArticleNumZSet() Key { return build(nsTag, "articleNum", "ZSet") }
 func TagArticleListZSet(tagName string) Key {
 	return build(tagName, "article", "ZSet")
 }
+
+// TagListCache 前台标签列表的读穿缓存（JSON），与 TagArticleNumZSet 一起删除
+func TagListCache() Key { return build(nsTag, "list", "String") }
@@ -3,6 +3,8 @@ package codes
 const (
 	Success = 2000 // 成功
 
+	MovedPermanently = 3010 // 资源已永久迁移（访问文章旧 slug 等），Data 中返回新地址
+
 	BadRequest      = 4000 // 参数错误
 	Unauthorized    = 4010 // 未授权（缺少 Token）
 	AuthFailed      = 4011 // 认证失败（错误的 token）
@@ -11,6 +13,7 @@ const (
 	TooManyRequests = 4290 // 请求过于频繁
 	NotFound        = 4040 // 资源不存在（访问被删除的文章等）
 	RequestTimeout  = 4080 // 请求超时（网络连接失败等）
+	Conflict        = 4090 // 资源已被修改（保存文章时版本冲突等），Data 中返回服务端当前内容
 
 	InternalServerError = 5000 // 服务内部错误
 )
@@ -9,9 +9,21 @@ const (
 
 	Spec = "0 3 * * *" // 定时任务表达式，每天 3 点执行
 
+	SearchIndexRebuildSpec = "@every 30m" // 全文索引全量重建周期
+	ArticleScheduleSpec    = "@every 1m"  // 定时发布/下线检查周期
+	TrashPurgeSpec         = "30 3 * * *" // 回收站过期清理，每天 3:30 执行
+	ViewStatsFlushSpec     = "@every 5m"  // 按天浏览量回写 MySQL 周期
+	TrendingRescoreSpec    = "@every 10m" // 趋势文章榜重新计算周期
+	ArticleImageGCSpec     = "0 4 * * *"  // 未引用图片回收，每天 4 点执行
+
 	MaxFileSize         = int64(64 << 10) // MD文件大小限制为64KB
 	MaxArticleImageSize = int64(1 << 20)  // 文章图片上传大小限制为1MB
+	MaxArticleZipSize   = int64(32 << 20) // 文章导入归档大小限制为32MB
 
 	ArticleStatusDraft     = "draft"     // 草稿状态
 	ArticleStatusPublished = "published" // 已发布状态
+
+	ArticleVisibilityPublic    = "public"    // 公开：出现在列表、订阅源、sitemap 与搜索中
+	ArticleVisibilityUnlisted  = "unlisted"  // 不公开：只能通过链接访问
+	ArticleVisibilityProtected = "protected" // 密码保护：不出现在列表中，访问需要输入文章密码
 )
@@ -35,6 +35,9 @@ const (
 	COSSecretID  = "COS_SECRET_ID"
 	COSSecretKey = "COS_SECRET_KEY"
 
+	S3AccessKeyID     = "S3_ACCESS_KEY_ID"
+	S3SecretAccessKey = "S3_SECRET_ACCESS_KEY"
+
 	SitemapRevalidateEndpoint = "SITEMAP_REVALIDATE_ENDPOINT"
 	SitemapRevalidateSecret   = "SITEMAP_REVALIDATE_SECRET"
 
@@ -86,10 +86,20 @@ func NewEngine(cfg EngineConfig) (Engine, error) {
 // Evaluate 主流程。所有阶段都按"先解码/校验 → 后业务规则"的顺序串联。
 //
 // 任一阶段判定为拒：填充 audit + Outcome 后立即返回，不再继续后续阶段。
+// 每次评估的最终决策按天计入 Store，供后台看板统计拦截量。
 func (e *engine) Evaluate(ctx context.Context, req *RiskRequest) (*Outcome, error) {
 	if req == nil {
 		return nil, errors.New("guard: nil request")
 	}
+	out, err := e.evaluate(ctx, req)
+	if err == nil && out != nil {
+		e.recordDecision(ctx, req.Scene, out.Decision)
+	}
+	return out, err
+}
+
+// evaluate Evaluate 的各阶段串联，不含决策计数。
+func (e *engine) evaluate(ctx context.Context, req *RiskRequest) (*Outcome, error) {
 	serverNowMs := e.now().UnixMilli()
 	out := &Outcome{Score: scoreStart}
 	// 在解出 TLV 之前 clientTS 还未知，统一传 0；解出后改写为真实值用于 reject 审计。
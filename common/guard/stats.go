@@ -0,0 +1,53 @@
+package guard
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DecisionStatsTTL 按天决策计数的保留时长，覆盖看板查询窗口上限（365 天）
+	DecisionStatsTTL = 400 * 24 * time.Hour
+
+	// statsDayLayout 决策计数按天分桶的日期格式，与浏览量分桶一致
+	statsDayLayout = "2006-01-02"
+)
+
+// statsLocation 按站点时区（Asia/Shanghai）划分计数日期，与浏览量统计口径一致。
+// 只在首次使用时加载一次时区数据，避免每次 Evaluate 都读取 zoneinfo。
+var statsLocation = sync.OnceValue(func() *time.Location {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		return time.Local
+	}
+	return loc
+})
+
+// DecisionStatsField 决策计数 Hash 的 field。
+func DecisionStatsField(scene Scene, decision Decision) string {
+	return scene.String() + ":" + decision.String()
+}
+
+// ParseDecisionStatsField 解析 DecisionStatsField 生成的 field，返回场景与决策名称。
+func ParseDecisionStatsField(field string) (string, string, bool) {
+	scene, decision, ok := strings.Cut(field, ":")
+	if !ok || scene == "" || decision == "" {
+		return "", "", false
+	}
+	return scene, decision, true
+}
+
+// recordDecision 计数失败只记日志，不影响评估结果。
+func (e *engine) recordDecision(ctx context.Context, scene Scene, decision Decision) {
+	day := e.now().In(statsLocation()).Format(statsDayLayout)
+	if err := e.store.RecordDecision(ctx, scene, decision, day); err != nil {
+		e.logger.Warn("guard decision stats incr failed",
+			zap.String("scene", scene.String()),
+			zap.String("decision", decision.String()),
+			zap.Error(err))
+	}
+}
@@ -36,6 +36,9 @@ type Store interface {
 	// TokenConsume 原子读取并删除一次性 token，命中返回 (tokenValue, true, nil)。
 	// 未命中（不存在/已被消费/已过期）返回 ("", false, nil)。
 	TokenConsume(ctx context.Context, scene Scene, tokenHex string) (string, bool, error)
+
+	// RecordDecision 把一次评估的最终决策计入 day 当天的计数，day 格式 2006-01-02（站点时区）。
+	RecordDecision(ctx context.Context, scene Scene, decision Decision, day string) error
 }
 
 // redisStore 默认 Store 实现。
@@ -122,6 +125,16 @@ func (s *redisStore) TokenConsume(ctx context.Context, scene Scene, tokenHex str
 	return val, true, nil
 }
 
+// RecordDecision HINCRBY 当天的决策计数 Hash，保留 DecisionStatsTTL 供看板回看。
+func (s *redisStore) RecordDecision(ctx context.Context, scene Scene, decision Decision, day string) error {
+	key := cachekey.GuardDecisionStatsHash(day).String()
+	pipe := s.rdb.Pipeline()
+	pipe.HIncrBy(ctx, key, DecisionStatsField(scene, decision), 1)
+	pipe.Expire(ctx, key, DecisionStatsTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 // bytesToHex 内联实现避免 import encoding/hex 时与其它文件命名冲突。
 func bytesToHex(b []byte) string {
 	const digits = "0123456789abcdef"
@@ -67,6 +67,26 @@ const (
 	DecisionInternal
 )
 
+// String 给 Decision 提供可读名称（用于决策计数的 field，不参与协议）。
+func (d Decision) String() string {
+	switch d {
+	case DecisionAccept:
+		return "accept"
+	case DecisionSilent:
+		return "silent"
+	case DecisionBadRequest:
+		return "bad-request"
+	case DecisionRateLimited:
+		return "rate-limited"
+	case DecisionNotFound:
+		return "not-found"
+	case DecisionInternal:
+		return "internal"
+	default:
+		return "unknown"
+	}
+}
+
 // SecFetchHeaders 浏览器侧 Sec-Fetch-* 与相关 header 的快照。
 //
 // 字段为空字符串表示请求未携带（不强制要求；缺失只在 L2 软扣分）。
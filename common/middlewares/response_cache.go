@@ -0,0 +1,226 @@
+package middlewares
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseCacheRule 按路径前缀配置条件请求与压缩，多条规则按顺序匹配第一条
+type ResponseCacheRule struct {
+	Prefix string
+	// ETag 为 true 时按响应体生成强 ETag，并处理 If-None-Match / If-Modified-Since
+	ETag bool
+	// CacheControl 非空且处理函数未设置时写入 Cache-Control
+	CacheControl string
+	// CompressMinSize 响应体达到该字节数时按 Accept-Encoding 做 gzip 压缩，0 表示不压缩
+	CompressMinSize int
+}
+
+// ResponseCacheMiddleware 对 GET / HEAD 请求缓冲响应体，生成 ETag 并在命中条件请求时返回 304，
+// 否则按需压缩后输出。非 200 响应（重定向、处理函数自行返回的 304 等）原样输出
+func ResponseCacheMiddleware(rules ...ResponseCacheRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if method != http.MethodGet && method != http.MethodHead {
+			c.Next()
+			return
+		}
+		rule, ok := responseCacheRuleForPath(c.Request.URL.Path, rules)
+		if !ok || (!rule.ETag && rule.CompressMinSize <= 0) {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &bufferedResponseWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = writer
+		// 处理函数 panic 时恢复原始 Writer，由外层 recovery 中间件直接写错误响应
+		defer func() { c.Writer = original }()
+		c.Next()
+
+		if !writer.written {
+			// 只设置了状态码（如 c.Status）时交给 gin 在请求结束时写出
+			original.WriteHeader(writer.status)
+			return
+		}
+		writer.flush(c.Request, rule)
+	}
+}
+
+// SetPrivateResponse 响应内容依赖 Cookie（如加密文章的访问凭证）时调用：只允许浏览器缓存，并按 Cookie 区分
+func SetPrivateResponse(c *gin.Context) {
+	c.Header("Cache-Control", "private, no-cache")
+	c.Writer.Header().Add("Vary", "Cookie")
+}
+
+func responseCacheRuleForPath(path string, rules []ResponseCacheRule) (ResponseCacheRule, bool) {
+	for _, rule := range rules {
+		if rule.Prefix != "" && strings.HasPrefix(path, rule.Prefix) {
+			return rule, true
+		}
+	}
+	return ResponseCacheRule{}, false
+}
+
+// bufferedResponseWriter 暂存状态码与响应体，处理函数返回后再统一写出
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedResponseWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedResponseWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedResponseWriter) Written() bool {
+	return w.written
+}
+
+// Flush 缓冲期间忽略处理函数的主动 Flush，响应体在 flush 时一次写出
+func (w *bufferedResponseWriter) Flush() {}
+
+func (w *bufferedResponseWriter) flush(request *http.Request, rule ResponseCacheRule) {
+	header := w.ResponseWriter.Header()
+	body := w.body.Bytes()
+	if w.status != http.StatusOK || len(body) == 0 {
+		w.writeOut(w.status, body)
+		return
+	}
+
+	compress := rule.CompressMinSize > 0 && len(body) >= rule.CompressMinSize &&
+		header.Get("Content-Encoding") == ""
+	if compress {
+		header.Add("Vary", "Accept-Encoding")
+		compress = acceptsGzip(request.Header.Get("Accept-Encoding"))
+	}
+
+	if rule.ETag {
+		etag := header.Get("ETag")
+		if etag == "" {
+			// 同一内容的压缩与未压缩表示使用不同的强 ETag
+			etag = strongETag(body, compress)
+			header.Set("ETag", etag)
+		}
+		if rule.CacheControl != "" && header.Get("Cache-Control") == "" {
+			header.Set("Cache-Control", rule.CacheControl)
+		}
+		if requestNotModified(request, etag, header.Get("Last-Modified")) {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			w.writeOut(http.StatusNotModified, nil)
+			return
+		}
+	}
+
+	if compress {
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		if _, err := gz.Write(body); err == nil && gz.Close() == nil {
+			header.Set("Content-Encoding", "gzip")
+			body = compressed.Bytes()
+		}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.writeOut(w.status, body)
+}
+
+func (w *bufferedResponseWriter) writeOut(status int, body []byte) {
+	w.ResponseWriter.WriteHeader(status)
+	if len(body) == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+func strongETag(body []byte, gzipped bool) string {
+	sum := sha256.Sum256(body)
+	tag := hex.EncodeToString(sum[:16])
+	if gzipped {
+		tag += "-gzip"
+	}
+	return `"` + tag + `"`
+}
+
+// requestNotModified 有 If-None-Match 时只比较 ETag（忽略 If-Modified-Since），否则比较 Last-Modified（秒级精度）
+func requestNotModified(request *http.Request, etag string, lastModified string) bool {
+	if ifNoneMatch := strings.TrimSpace(request.Header.Get("If-None-Match")); ifNoneMatch != "" {
+		if ifNoneMatch == "*" {
+			return true
+		}
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			// 弱比较：W/"x" 与 "x" 视为相同
+			if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+	ifModifiedSince := request.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// acceptsGzip 解析 Accept-Encoding，q=0 表示明确拒绝
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			if value, err := strconv.ParseFloat(q, 64); err == nil && value == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
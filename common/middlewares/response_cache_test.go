@@ -0,0 +1,127 @@
+package middlewares
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newResponseCacheEngine(body string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ResponseCacheMiddleware(ResponseCacheRule{Prefix: "/user/", ETag: true, CompressMinSize: 16}))
+	r.GET("/user/detail", func(c *gin.Context) {
+		c.String(http.StatusOK, body)
+	})
+	r.GET("/user/moved", func(c *gin.Context) {
+		c.Redirect(http.StatusFound, "/user/detail")
+	})
+	r.GET("/user/feed", func(c *gin.Context) {
+		c.Status(http.StatusNotModified)
+	})
+	return r
+}
+
+func TestResponseCacheMiddlewareNotModified(t *testing.T) {
+	r := newResponseCacheEngine("hello")
+
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/user/detail", nil))
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Body.String() != "hello" {
+		t.Fatalf("unexpected first response: %d %q %q", first.Code, etag, first.Body.String())
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/user/detail", nil)
+	request.Header.Set("If-None-Match", "W/"+etag)
+	second := httptest.NewRecorder()
+	r.ServeHTTP(second, request)
+	if second.Code != http.StatusNotModified || second.Body.Len() != 0 {
+		t.Fatalf("expected 304 with empty body, got %d %q", second.Code, second.Body.String())
+	}
+}
+
+func TestResponseCacheMiddlewareGzip(t *testing.T) {
+	body := strings.Repeat("article content ", 8)
+	r := newResponseCacheEngine(body)
+
+	request := httptest.NewRequest(http.MethodGet, "/user/detail", nil)
+	request.Header.Set("Accept-Encoding", "br;q=1, gzip;q=0.8")
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, request)
+	if recorder.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip response, headers: %v", recorder.Header())
+	}
+	if !strings.HasSuffix(recorder.Header().Get("ETag"), `-gzip"`) {
+		t.Fatalf("expected gzip etag, got %q", recorder.Header().Get("ETag"))
+	}
+	reader, err := gzip.NewReader(recorder.Body)
+	if err != nil {
+		t.Fatalf("invalid gzip body: %v", err)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil || string(decoded) != body {
+		t.Fatalf("unexpected decoded body %q, err %v", decoded, err)
+	}
+}
+
+func TestResponseCacheMiddlewarePassThrough(t *testing.T) {
+	r := newResponseCacheEngine("hello")
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/user/moved", nil))
+	if recorder.Code != http.StatusFound || recorder.Header().Get("Location") != "/user/detail" {
+		t.Fatalf("redirect not passed through: %d %v", recorder.Code, recorder.Header())
+	}
+	if recorder.Header().Get("ETag") != "" {
+		t.Fatalf("redirect should not carry an etag")
+	}
+
+	// 处理函数自行返回 304 且不写响应体
+	recorder = httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/user/feed", nil))
+	if recorder.Code != http.StatusNotModified {
+		t.Fatalf("expected handler status 304, got %d", recorder.Code)
+	}
+}
+
+func TestResponseCacheMiddlewarePrivateResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ResponseCacheMiddleware(ResponseCacheRule{Prefix: "/user/", ETag: true, CacheControl: "no-cache"}))
+	r.GET("/user/protected", func(c *gin.Context) {
+		SetPrivateResponse(c)
+		c.String(http.StatusOK, "secret")
+	})
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/user/protected", nil))
+	if recorder.Header().Get("Cache-Control") != "private, no-cache" {
+		t.Fatalf("rule should not override private cache control, got %q", recorder.Header().Get("Cache-Control"))
+	}
+	if recorder.Header().Get("Vary") != "Cookie" {
+		t.Fatalf("expected Vary: Cookie, got %v", recorder.Header().Values("Vary"))
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := map[string]bool{
+		"":                   false,
+		"gzip":               true,
+		"deflate, GZIP":      true,
+		"gzip;q=0":           false,
+		"*":                  true,
+		"br, gzip; q=0.5":    true,
+		"identity, gzip;q=0": false,
+	}
+	for header, want := range tests {
+		if got := acceptsGzip(header); got != want {
+			t.Errorf("acceptsGzip(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
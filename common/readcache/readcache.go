@@ -0,0 +1,283 @@
+package readcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/bytedance/sonic"
+)
+
+// ErrNotFound 数据源中不存在该记录；命中负缓存时同样返回该错误
+var ErrNotFound = errors.New("readcache: record not found")
+
+// loadTimeout 回源加载的超时时间。加载由同一 Key 的所有等待方共享，与发起请求的生命周期无关
+const loadTimeout = 5 * time.Second
+
+// Store 抽象缓存底层存储，生产环境使用 Redis，单测可注入内存实现。
+// Get 在 Key 不存在时返回 (nil, nil)；SetNX 只在 Key 不存在时写入；
+// CompareAndSet 只在 Key 的当前值等于 expected 时写入，Key 不存在视为不相等
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	CompareAndSet(ctx context.Context, key string, expected []byte, value []byte, ttl time.Duration) (bool, error)
+	Del(ctx context.Context, keys ...string) error
+}
+
+// Options 读穿缓存策略
+type Options struct {
+	// TTL 数据的新鲜期，超过后进入过期可用期
+	TTL time.Duration
+	// StaleTTL 过期可用期：期间直接返回旧数据并在后台刷新，为 0 表示不返回过期数据
+	StaleTTL time.Duration
+	// NegativeTTL 数据不存在时的负缓存时长，为 0 表示不做负缓存
+	NegativeTTL time.Duration
+	// Jitter TTL 的随机浮动比例（0~1），避免同一批 Key 同时过期
+	Jitter float64
+	// IsNotFound 判断加载函数返回的错误是否表示记录不存在，为空时只识别 ErrNotFound
+	IsNotFound func(err error) bool
+	// OnError 记录缓存读写与后台刷新中被降级处理的错误，可为空
+	OnError func(key string, err error)
+}
+
+// LoadFunc 从数据源加载 Key 对应的数据。ctx 与调用方请求分离，只带有 loadTimeout 超时
+type LoadFunc[T any] func(ctx context.Context) (T, error)
+
+// entry 存储中的缓存条目。FreshUntil 之后为过期可用期，Key 的 TTL 覆盖过期可用期
+type entry[T any] struct {
+	Value      T     `json:"v"`
+	NotFound   bool  `json:"n,omitempty"`
+	FreshUntil int64 `json:"f"`
+}
+
+// Cache 带单飞合并、TTL 随机浮动、负缓存与过期可用的读穿缓存。
+// 同一进程内同一 Key 的并发未命中只会调用一次加载函数
+type Cache[T any] struct {
+	store   Store
+	options Options
+	now     func() time.Time
+
+	mu       sync.Mutex
+	inflight map[string]*call[T]
+}
+
+type call[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// New 创建读穿缓存实例
+func New[T any](store Store, options Options) *Cache[T] {
+	if options.Jitter < 0 {
+		options.Jitter = 0
+	}
+	if options.Jitter > 1 {
+		options.Jitter = 1
+	}
+	return &Cache[T]{
+		store:    store,
+		options:  options,
+		now:      time.Now,
+		inflight: make(map[string]*call[T]),
+	}
+}
+
+// SetNow 注入时间函数，主要用于单元测试
+func (c *Cache[T]) SetNow(now func() time.Time) {
+	if now != nil {
+		c.now = now
+	}
+}
+
+// Get 优先读缓存；新鲜数据直接返回，过期可用数据返回的同时后台刷新，未命中时合并并发请求回源加载。
+// 记录不存在时返回的错误满足 errors.Is(err, ErrNotFound)
+func (c *Cache[T]) Get(ctx context.Context, key string, load LoadFunc[T]) (T, error) {
+	cached, raw, ok := c.read(ctx, key)
+	if ok {
+		if c.now().UnixMilli() < cached.FreshUntil {
+			return c.result(cached)
+		}
+		c.refresh(key, raw, load)
+		return c.result(cached)
+	}
+	return c.do(ctx, key, func(loadCtx context.Context) (T, error) { return c.load(loadCtx, key, raw, load) })
+}
+
+// Delete 写路径变更数据后删除缓存。直接删除 Key 效果相同：正在进行的加载写回前会发现 Key 已变化而放弃写入
+func (c *Cache[T]) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.store.Del(ctx, keys...)
+}
+
+// read 读取缓存条目及其原始值；存储不可用或数据无法解析时按未命中处理
+func (c *Cache[T]) read(ctx context.Context, key string) (*entry[T], []byte, bool) {
+	raw, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.reportError(key, fmt.Errorf("readcache: get: %w", err))
+		return nil, nil, false
+	}
+	if raw == nil {
+		return nil, nil, false
+	}
+	cached := &entry[T]{}
+	if err = sonic.Unmarshal(raw, cached); err != nil || cached.FreshUntil == 0 {
+		// 旧格式、损坏的数据或其他实例的加载占位值，回源后覆盖
+		return nil, raw, false
+	}
+	return cached, raw, true
+}
+
+func (c *Cache[T]) result(cached *entry[T]) (T, error) {
+	if cached.NotFound {
+		var zero T
+		return zero, ErrNotFound
+	}
+	return cached.Value, nil
+}
+
+// load 回源加载并写入缓存；写缓存失败不影响本次结果。
+// raw 为回源前读到的原始值，只有 Key 在加载期间未被删除或覆盖时才写回，
+// 避免与写路径的删除交错时把变更前的数据重新写入缓存
+func (c *Cache[T]) load(ctx context.Context, key string, raw []byte, load LoadFunc[T]) (T, error) {
+	expected := raw
+	if expected == nil {
+		expected = c.lease(ctx, key)
+	}
+	value, err := load(ctx)
+	if err != nil {
+		if !c.isNotFound(err) {
+			return value, err
+		}
+		if c.options.NegativeTTL > 0 {
+			c.write(ctx, key, expected, &entry[T]{NotFound: true}, c.options.NegativeTTL, 0)
+		}
+		if errors.Is(err, ErrNotFound) {
+			return value, err
+		}
+		// 与命中负缓存时一致，同时保留原始错误
+		return value, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	c.write(ctx, key, expected, &entry[T]{Value: value}, c.options.TTL, c.options.StaleTTL)
+	return value, nil
+}
+
+// lease Key 不存在时先写入随机占位值再回源，写路径删除 Key 会连同占位值一起删掉。
+// 占位值已被其他实例抢先写入或存储不可用时返回 nil，本次只回源不写缓存
+func (c *Cache[T]) lease(ctx context.Context, key string) []byte {
+	token := fmt.Appendf(nil, "readcache:lease:%016x%016x", rand.Uint64(), rand.Uint64())
+	ok, err := c.store.SetNX(ctx, key, token, loadTimeout)
+	if err != nil {
+		c.reportError(key, fmt.Errorf("readcache: lease: %w", err))
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	return token
+}
+
+// refresh 后台刷新过期可用的数据，同一 Key 同时只有一个刷新在进行
+func (c *Cache[T]) refresh(key string, raw []byte, load LoadFunc[T]) {
+	c.mu.Lock()
+	if _, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+	go func() {
+		if _, err := c.do(context.Background(), key, func(loadCtx context.Context) (T, error) {
+			return c.load(loadCtx, key, raw, load)
+		}); err != nil && !c.isNotFound(err) {
+			c.reportError(key, fmt.Errorf("readcache: refresh: %w", err))
+		}
+	}()
+}
+
+// do 合并同一 Key 的并发加载，只有第一个调用方会发起 fn。
+// fn 在独立的 goroutine 中以脱离请求的 ctx 执行，任一调用方取消只结束它自己的等待，不影响其他调用方拿到结果
+func (c *Cache[T]) do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	c.mu.Lock()
+	current, ok := c.inflight[key]
+	if !ok {
+		current = &call[T]{done: make(chan struct{})}
+		c.inflight[key] = current
+		go c.run(ctx, key, current, fn)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-current.done:
+		return current.value, current.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// run 执行共享加载，保留 ctx 中的值但不继承取消信号
+func (c *Cache[T]) run(ctx context.Context, key string, current *call[T], fn func(ctx context.Context) (T, error)) {
+	loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+	defer cancel()
+	defer func() {
+		// 加载在独立 goroutine 中执行，panic 无法再由请求的 Recovery 中间件兜底
+		if r := recover(); r != nil {
+			current.err = fmt.Errorf("readcache: load panic: %v", r)
+		}
+		c.mu.Lock()
+		delete(c.inflight, key)
+		c.mu.Unlock()
+		close(current.done)
+	}()
+	current.value, current.err = fn(loadCtx)
+}
+
+// write 比较后写入缓存，expected 为 nil 表示本次不写
+func (c *Cache[T]) write(ctx context.Context, key string, expected []byte, cached *entry[T], ttl time.Duration, staleTTL time.Duration) {
+	if ttl <= 0 || expected == nil {
+		return
+	}
+	ttl = c.jitter(ttl)
+	cached.FreshUntil = c.now().Add(ttl).UnixMilli()
+	raw, err := sonic.Marshal(cached)
+	if err != nil {
+		c.reportError(key, fmt.Errorf("readcache: marshal: %w", err))
+		return
+	}
+	if _, err = c.store.CompareAndSet(ctx, key, expected, raw, ttl+staleTTL); err != nil {
+		c.reportError(key, fmt.Errorf("readcache: set: %w", err))
+	}
+}
+
+// jitter 在 [ttl*(1-Jitter), ttl*(1+Jitter)] 内随机取值
+func (c *Cache[T]) jitter(ttl time.Duration) time.Duration {
+	if c.options.Jitter <= 0 {
+		return ttl
+	}
+	delta := float64(ttl) * c.options.Jitter
+	return max(ttl+time.Duration(delta*(2*rand.Float64()-1)), time.Second)
+}
+
+func (c *Cache[T]) isNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+	return c.options.IsNotFound != nil && c.options.IsNotFound(err)
+}
+
+func (c *Cache[T]) reportError(key string, err error) {
+	if c.options.OnError != nil {
+		c.options.OnError(key, err)
+	}
+}
+
+// IsNotFound 判断是否为记录不存在（包括命中负缓存）
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
@@ -0,0 +1,262 @@
+package readcache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type memoryStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	ttls   map[string]time.Duration
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{values: make(map[string][]byte), ttls: make(map[string]time.Duration)}
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key], nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *memoryStore) SetNX(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.values[key]; ok {
+		return false, nil
+	}
+	s.values[key] = value
+	s.ttls[key] = ttl
+	return true, nil
+}
+
+func (s *memoryStore) CompareAndSet(_ context.Context, key string, expected []byte, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.values[key]
+	if !ok || !bytes.Equal(current, expected) {
+		return false, nil
+	}
+	s.values[key] = value
+	s.ttls[key] = ttl
+	return true, nil
+}
+
+func (s *memoryStore) Del(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.values, key)
+		delete(s.ttls, key)
+	}
+	return nil
+}
+
+func TestCacheCoalescesConcurrentMisses(t *testing.T) {
+	cache := New[string](newMemoryStore(), Options{TTL: time.Minute})
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (string, error) {
+		loads.Add(1)
+		<-release
+		return "detail", nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, err := cache.Get(context.Background(), "article:1", load); err != nil || value != "detail" {
+				t.Errorf("unexpected result %q, %v", value, err)
+			}
+		}()
+	}
+	// 等所有请求都进入等待后再放行加载函数
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("expected a single load, got %d", got)
+	}
+	if value, err := cache.Get(context.Background(), "article:1", load); err != nil || value != "detail" {
+		t.Fatalf("expected cached value, got %q, %v", value, err)
+	}
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("cached read should not load again, got %d loads", got)
+	}
+}
+
+func TestCacheNegativeEntry(t *testing.T) {
+	store := newMemoryStore()
+	errMissing := errors.New("missing row")
+	cache := New[string](store, Options{
+		TTL:         time.Minute,
+		NegativeTTL: 30 * time.Second,
+		IsNotFound:  func(err error) bool { return errors.Is(err, errMissing) },
+	})
+	var loads int
+	load := func(context.Context) (string, error) {
+		loads++
+		return "", errMissing
+	}
+
+	_, err := cache.Get(context.Background(), "article:404", load)
+	if !IsNotFound(err) || !errors.Is(err, errMissing) {
+		t.Fatalf("expected not found wrapping the loader error, got %v", err)
+	}
+	if _, err = cache.Get(context.Background(), "article:404", load); !IsNotFound(err) {
+		t.Fatalf("expected negative cache hit, got %v", err)
+	}
+	if loads != 1 {
+		t.Fatalf("negative entry should prevent reloading, got %d loads", loads)
+	}
+	if ttl := store.ttls["article:404"]; ttl != 30*time.Second {
+		t.Fatalf("unexpected negative ttl %s", ttl)
+	}
+}
+
+func TestCacheServesStaleWhileRevalidating(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	var mu sync.Mutex
+	cache := New[int](newMemoryStore(), Options{TTL: time.Minute, StaleTTL: time.Minute})
+	cache.SetNow(func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	})
+	var version atomic.Int32
+	refreshed := make(chan struct{}, 1)
+	load := func(context.Context) (int, error) {
+		v := int(version.Add(1))
+		if v > 1 {
+			refreshed <- struct{}{}
+		}
+		return v, nil
+	}
+
+	if value, _ := cache.Get(context.Background(), "tags", load); value != 1 {
+		t.Fatalf("expected first load, got %d", value)
+	}
+	mu.Lock()
+	now = now.Add(90 * time.Second)
+	mu.Unlock()
+	if value, _ := cache.Get(context.Background(), "tags", load); value != 1 {
+		t.Fatalf("expected stale value while refreshing, got %d", value)
+	}
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("background refresh did not run")
+	}
+	// 后台刷新写回缓存后读到新值
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if value, _ := cache.Get(context.Background(), "tags", load); value == 2 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("refreshed value was not written back")
+}
+
+func TestCacheJitterBounds(t *testing.T) {
+	cache := New[string](newMemoryStore(), Options{Jitter: 0.2})
+	for range 100 {
+		ttl := cache.jitter(10 * time.Minute)
+		if ttl < 8*time.Minute || ttl > 12*time.Minute {
+			t.Fatalf("jittered ttl %s out of bounds", ttl)
+		}
+	}
+}
+
+func TestCacheLeaderCancelDoesNotFailWaiters(t *testing.T) {
+	cache := New[string](newMemoryStore(), Options{TTL: time.Minute})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(ctx context.Context) (string, error) {
+		close(started)
+		select {
+		case <-release:
+			return "detail", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := cache.Get(leaderCtx, "article:1", load)
+		leaderErr <- err
+	}()
+	<-started
+
+	waiter := make(chan error, 1)
+	go func() {
+		value, err := cache.Get(context.Background(), "article:1", load)
+		if err == nil && value != "detail" {
+			err = errors.New("unexpected value " + value)
+		}
+		waiter <- err
+	}()
+	// 发起加载的请求断开只结束它自己的等待
+	cancel()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected leader to stop waiting with its own ctx error, got %v", err)
+	}
+	close(release)
+	if err := <-waiter; err != nil {
+		t.Fatalf("waiter should receive the shared result, got %v", err)
+	}
+}
+
+func TestCacheLoadDoesNotOverwriteDelete(t *testing.T) {
+	store := newMemoryStore()
+	cache := New[string](store, Options{TTL: time.Minute})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	load := func(context.Context) (string, error) {
+		close(started)
+		<-release
+		return "before update", nil
+	}
+
+	result := make(chan string, 1)
+	go func() {
+		value, _ := cache.Get(context.Background(), "article:1", load)
+		result <- value
+	}()
+	<-started
+	// 加载期间写路径更新数据并删除缓存
+	if err := cache.Delete(context.Background(), "article:1"); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	if value := <-result; value != "before update" {
+		t.Fatalf("unexpected loaded value %q", value)
+	}
+
+	value, err := cache.Get(context.Background(), "article:1", func(context.Context) (string, error) {
+		return "after update", nil
+	})
+	if err != nil || value != "after update" {
+		t.Fatalf("load started before delete must not be written back, got %q, %v", value, err)
+	}
+}
@@ -0,0 +1,84 @@
+package readcache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// compareAndSetScript Key 的当前值与 ARGV[1] 一致时才写入 ARGV[2]
+var compareAndSetScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+return 1
+`)
+
+// RedisStore 是基于 Redis String 的缓存存储实现
+type RedisStore struct {
+	rdb *redis.Client
+}
+
+// NewRedisStore 创建 Redis 缓存存储
+func NewRedisStore(rdb *redis.Client) *RedisStore {
+	return &RedisStore{rdb: rdb}
+}
+
+// NewRedisCache 创建基于 Redis 的读穿缓存
+func NewRedisCache[T any](rdb *redis.Client, options Options) *Cache[T] {
+	return New[T](NewRedisStore(rdb), options)
+}
+
+// Get 读取缓存值，Key 不存在时返回 (nil, nil)
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if s == nil || s.rdb == nil {
+		return nil, nil
+	}
+	value, err := s.rdb.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Set 写入缓存值
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if s == nil || s.rdb == nil {
+		return nil
+	}
+	return s.rdb.Set(ctx, key, value, ttl).Err()
+}
+
+// SetNX Key 不存在时写入缓存值，返回是否写入
+func (s *RedisStore) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	if s == nil || s.rdb == nil {
+		return false, nil
+	}
+	return s.rdb.SetNX(ctx, key, value, ttl).Result()
+}
+
+// CompareAndSet Key 的当前值等于 expected 时写入缓存值，返回是否写入
+func (s *RedisStore) CompareAndSet(ctx context.Context, key string, expected []byte, value []byte, ttl time.Duration) (bool, error) {
+	if s == nil || s.rdb == nil {
+		return false, nil
+	}
+	written, err := compareAndSetScript.Run(ctx, s.rdb, []string{key}, expected, value, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return written == 1, nil
+}
+
+// Del 删除缓存
+func (s *RedisStore) Del(ctx context.Context, keys ...string) error {
+	if s == nil || s.rdb == nil || len(keys) == 0 {
+		return nil
+	}
+	return s.rdb.Del(ctx, keys...).Err()
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type AdminGetArticleListRequest struct {
 	Page     int    `form:"page" binding:"required,gte=1"`
 	PageSize int    `form:"pageSize" binding:"required,gte=1,lte=10"`
@@ -7,12 +9,12 @@ type AdminGetArticleListRequest struct {
 }
 
 type AdminGetArticleListItem struct {
-	ID         string `json:"id"`
-	Title      string `json:"title"`
-	Tag        string `json:"tag"`
-	CreateTime string `json:"createTime"`
-	UpdateTime string `json:"updateTime"`
-	ViewNum    int    `json:"viewNum"`
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Tags       []string `json:"tags"`
+	CreateTime string   `json:"createTime"`
+	UpdateTime string   `json:"updateTime"`
+	ViewNum    int      `json:"viewNum"`
 }
 
 type AdminGetArticleListResponse struct {
@@ -25,51 +27,108 @@ type AdminGetArticleDetailRequest struct {
 }
 
 type AdminGetArticleDetailResponse struct {
-	ID       string `json:"id"`
-	Title    string `json:"title"`
-	Tag      string `json:"tag"`
-	Describe string `json:"describe"`
-	Content  string `json:"content"`
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Tags     []string `json:"tags"`
+	Describe string   `json:"describe"`
+	Content  string   `json:"content"`
+	Slug     string   `json:"slug"`
+	// Visibility public / unlisted / protected，访问密码不回显
+	Visibility string `json:"visibility"`
+	// Version 保存时原样带回，用于发现并发修改
+	Version uint64 `json:"version"`
 }
 
 type AdminAddArticleRequest struct {
-	Title    string `json:"title" binding:"required,max=100"`
-	Tag      string `json:"tag" binding:"required,max=20"`
-	Describe string `json:"describe" binding:"required,max=200"`
-	Content  string `json:"content" binding:"required"`
+	Title    string   `json:"title" binding:"required,max=100"`
+	Tags     []string `json:"tags" binding:"required,min=1,max=5,dive,required,max=20,excludesall=0x2C"`
+	Describe string   `json:"describe" binding:"required,max=200"`
+	Content  string   `json:"content" binding:"required"`
+	// Slug 可选的自定义地址，仅允许小写字母、数字与连字符，且至少包含一个字母
+	Slug string `json:"slug" binding:"omitempty,max=100"`
 }
 
-// AdminSaveArticleResponse 返回新增或修改后的文章 ID。
+// AdminSaveArticleResponse 返回新增或修改后的文章 ID，Version 为保存后的版本号（发布等不涉及版本的操作为 0）。
 type AdminSaveArticleResponse struct {
-	ID    string `json:"id"`
-	Title string `json:"title,omitempty"`
+	ID      string `json:"id"`
+	Title   string `json:"title,omitempty"`
+	Version uint64 `json:"version,omitempty"`
+}
+
+// AdminArticleConflictResponse 保存时版本冲突，返回服务端当前内容，由编辑器提示合并或覆盖
+type AdminArticleConflictResponse struct {
+	ID         string   `json:"id"`
+	Version    uint64   `json:"version"`
+	Title      string   `json:"title"`
+	Tags       []string `json:"tags"`
+	Describe   string   `json:"describe"`
+	Content    string   `json:"content"`
+	UpdateTime string   `json:"updateTime"`
 }
 
 type AdminUpdateArticleRequest struct {
-	ID       string `json:"id" binding:"required,lte=19"`
-	Title    string `json:"title" binding:"required,max=100"`
-	Tag      string `json:"tag" binding:"required,max=20"`
-	Describe string `json:"describe" binding:"required,max=200"`
-	Content  string `json:"content" binding:"required"`
+	ID       string   `json:"id" binding:"required,lte=19"`
+	Title    string   `json:"title" binding:"required,max=100"`
+	Tags     []string `json:"tags" binding:"required,min=1,max=5,dive,required,max=20,excludesall=0x2C"`
+	Describe string   `json:"describe" binding:"required,max=200"`
+	Content  string   `json:"content" binding:"required"`
+	// UnpublishAt 定时下线时间，格式 "2006-01-02 15:04"，为空表示取消定时下线
+	UnpublishAt string `json:"unpublishAt" binding:"omitempty"`
+	// Slug 不传表示保持不变，空串表示取消自定义地址；旧 slug 会保留为重定向地址
+	Slug *string `json:"slug" binding:"omitempty,max=100"`
+	// Version 打开编辑时拿到的版本号，必填，与当前版本不一致时返回冲突
+	Version uint64 `json:"version" binding:"required,gte=1"`
 }
 
 type AdminDeleteArticleRequest struct {
 	ID string `json:"id" binding:"required,lte=19"`
 }
 
-type AdminGetArticleDraftListRequest struct {
+type AdminGetArticleTrashListRequest struct {
 	Page     int `form:"page" binding:"required,gte=1"`
-	PageSize int `form:"pageSize" binding:"required,gte=1,lte=10"`
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=20"`
 }
 
-type AdminArticleDraftListItem struct {
+type AdminArticleTrashItem struct {
 	ID         string `json:"id"`
-	ArticleID  string `json:"articleID,omitempty"`
-	DraftType  string `json:"draftType"`
 	Title      string `json:"title"`
-	Tag        string `json:"tag"`
 	CreateTime string `json:"createTime"`
-	UpdateTime string `json:"updateTime"`
+	DeleteTime string `json:"deleteTime"`
+	// PurgeTime 超过保留期后被自动彻底删除的时间
+	PurgeTime string `json:"purgeTime"`
+}
+
+type AdminGetArticleTrashListResponse struct {
+	Rows  []AdminArticleTrashItem `json:"rows"`
+	Total int                     `json:"total"`
+}
+
+type AdminRestoreArticleRequest struct {
+	ID string `json:"id" binding:"required,lte=19"`
+}
+
+type AdminPurgeArticleRequest struct {
+	ID string `json:"id" binding:"required,lte=19"`
+}
+
+type AdminGetArticleDraftListRequest struct {
+	Page     int `form:"page" binding:"required,gte=1"`
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=10"`
+}
+
+type AdminArticleDraftListItem struct {
+	ID          string   `json:"id"`
+	ArticleID   string   `json:"articleID,omitempty"`
+	DraftType   string   `json:"draftType"`
+	Title       string   `json:"title"`
+	Tags        []string `json:"tags"`
+	Scheduled   bool     `json:"scheduled"`
+	PublishAt   string   `json:"publishAt,omitempty"`
+	UnpublishAt string   `json:"unpublishAt,omitempty"`
+	CreateTime  string   `json:"createTime"`
+	UpdateTime  string   `json:"updateTime"`
+	// Lock 正在编辑该草稿的会话，无人编辑时为空
+	Lock *AdminArticleEditLeaseItem `json:"lock,omitempty"`
 }
 
 type AdminGetArticleDraftListResponse struct {
@@ -82,29 +141,62 @@ type AdminGetArticleDraftDetailRequest struct {
 }
 
 type AdminGetArticleDraftDetailResponse struct {
-	ID        string `json:"id"`
-	ArticleID string `json:"articleID,omitempty"`
-	Title     string `json:"title"`
-	Tag       string `json:"tag"`
-	Describe  string `json:"describe"`
-	Content   string `json:"content"`
+	ID          string   `json:"id"`
+	ArticleID   string   `json:"articleID,omitempty"`
+	Title       string   `json:"title"`
+	Tags        []string `json:"tags"`
+	Describe    string   `json:"describe"`
+	Content     string   `json:"content"`
+	PublishAt   string   `json:"publishAt,omitempty"`
+	UnpublishAt string   `json:"unpublishAt,omitempty"`
+	Version     uint64   `json:"version"`
 }
 
 type AdminSaveArticleDraftRequest struct {
-	ID        string `json:"id" binding:"omitempty,lte=19"`
-	ArticleID string `json:"articleID" binding:"omitempty,lte=19"`
-	Title     string `json:"title" binding:"omitempty,max=100"`
-	Tag       string `json:"tag" binding:"omitempty,max=20"`
-	Describe  string `json:"describe" binding:"omitempty,max=200"`
-	Content   string `json:"content"`
+	ID        string   `json:"id" binding:"omitempty,lte=19"`
+	ArticleID string   `json:"articleID" binding:"omitempty,lte=19"`
+	Title     string   `json:"title" binding:"omitempty,max=100"`
+	Tags      []string `json:"tags" binding:"omitempty,max=5,dive,required,max=20,excludesall=0x2C"`
+	Describe  string   `json:"describe" binding:"omitempty,max=200"`
+	Content   string   `json:"content"`
+	// PublishAt 定时发布时间，格式 "2006-01-02 15:04"，为空表示不定时
+	PublishAt   string `json:"publishAt" binding:"omitempty"`
+	UnpublishAt string `json:"unpublishAt" binding:"omitempty"`
+	// Version 更新已有草稿（传 ID）时必填，规则同 AdminUpdateArticleRequest.Version；
+	// 只传 ArticleID 而该文章已有草稿时同样按版本校验，不传即返回冲突
+	Version uint64 `json:"version" binding:"required_with=ID,omitempty,gte=1"`
+}
+
+// AdminArticleEditLeaseRequest 编辑租约的获取、续期与释放。Kind 为 article 或 draft，
+// SessionID 由编辑器为每个打开的页面生成；Force 仅获取时有效，表示接管其他会话的租约
+type AdminArticleEditLeaseRequest struct {
+	Kind      string `json:"kind" binding:"required,oneof=article draft"`
+	ID        string `json:"id" binding:"required,lte=19"`
+	SessionID string `json:"sessionID" binding:"required,max=64"`
+	Force     bool   `json:"force"`
+}
+
+// AdminArticleEditLeaseItem 租约持有者
+type AdminArticleEditLeaseItem struct {
+	SessionID  string `json:"sessionID"`
+	UserID     string `json:"userID"`
+	AcquiredAt string `json:"acquiredAt"`
+	ExpireAt   string `json:"expireAt"`
+}
+
+// AdminArticleEditLeaseResponse Held 表示当前会话持有租约；为 false 时 Lease 是正在编辑的其他会话
+type AdminArticleEditLeaseResponse struct {
+	Held  bool                       `json:"held"`
+	Lease *AdminArticleEditLeaseItem `json:"lease"`
 }
 
 type AdminPublishArticleDraftRequest struct {
-	ID       string `json:"id" binding:"required,lte=19"`
-	Title    string `json:"title" binding:"required,max=100"`
-	Tag      string `json:"tag" binding:"required,max=20"`
-	Describe string `json:"describe" binding:"required,max=200"`
-	Content  string `json:"content" binding:"required"`
+	ID          string   `json:"id" binding:"required,lte=19"`
+	Title       string   `json:"title" binding:"required,max=100"`
+	Tags        []string `json:"tags" binding:"required,min=1,max=5,dive,required,max=20,excludesall=0x2C"`
+	Describe    string   `json:"describe" binding:"required,max=200"`
+	Content     string   `json:"content" binding:"required"`
+	UnpublishAt string   `json:"unpublishAt" binding:"omitempty"`
 }
 
 type AdminDeleteArticleDraftRequest struct {
@@ -112,10 +204,23 @@ type AdminDeleteArticleDraftRequest struct {
 }
 
 type AdminUploadArticleImageResponse struct {
-	URL       string `json:"url"`
-	ImageName string `json:"imageName"`
-	Size      int64  `json:"size"`
-	Mime      string `json:"mime"`
+	URL       string                        `json:"url"`
+	ImageName string                        `json:"imageName"`
+	Size      int64                         `json:"size"`
+	Mime      string                        `json:"mime"`
+	Sources   []AdminArticleImageSourceItem `json:"sources"`
+	SrcSet    string                        `json:"srcset"`
+	// Duplicate 为 true 表示内容相同的图片已上传过，返回的是已有图片
+	Duplicate bool `json:"duplicate"`
+}
+
+// AdminArticleImageSourceItem srcset 候选图片，按宽度升序
+type AdminArticleImageSourceItem struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Mime   string `json:"mime"`
+	Size   int64  `json:"size"`
 }
 
 type AdminGetArticleImageListRequest struct {
@@ -156,6 +261,8 @@ type AdminArticleImageReferenceItem struct {
 type AdminGetArticleImageDetailResponse struct {
 	Image      AdminArticleImageListItem        `json:"image"`
 	References []AdminArticleImageReferenceItem `json:"references"`
+	Sources    []AdminArticleImageSourceItem    `json:"sources"`
+	SrcSet     string                           `json:"srcset"`
 }
 
 type AdminDeleteArticleImageRequest struct {
@@ -163,19 +270,224 @@ type AdminDeleteArticleImageRequest struct {
 	Force bool   `json:"force"`
 }
 
+type AdminGetArticleImageTrashListRequest struct {
+	Page     int `form:"page" binding:"required,gte=1"`
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=50"`
+}
+
+type AdminArticleImageTrashItem struct {
+	ID         string `json:"id"`
+	URL        string `json:"url"`
+	ImageName  string `json:"imageName"`
+	Mime       string `json:"mime"`
+	Size       int64  `json:"size"`
+	RefCount   int    `json:"refCount"`
+	CreateTime string `json:"createTime"`
+	DeleteTime string `json:"deleteTime"`
+	PurgeTime  string `json:"purgeTime"`
+}
+
+type AdminGetArticleImageTrashListResponse struct {
+	Rows  []AdminArticleImageTrashItem `json:"rows"`
+	Total int                          `json:"total"`
+}
+
+type AdminRestoreArticleImageRequest struct {
+	ID string `json:"id" binding:"required,lte=19"`
+}
+
+type AdminPurgeArticleImageRequest struct {
+	ID string `json:"id" binding:"required,lte=19"`
+}
+
+type AdminGetArticleImageGCReportRequest struct {
+	// Limit 本次预览的候选图片数，缺省使用配置中的单次上限
+	Limit int `form:"limit" binding:"omitempty,gte=1,lte=1000"`
+}
+
+// AdminArticleImageGCItem 未引用图片回收的候选项，Action 为 delete 或 keep
+type AdminArticleImageGCItem struct {
+	ID          string `json:"id"`
+	URL         string `json:"url"`
+	ImageName   string `json:"imageName"`
+	Size        int64  `json:"size"`
+	Variants    int    `json:"variants"`
+	UnusedSince string `json:"unusedSince"`
+	Action      string `json:"action"`
+	// Reason 保留原因：文章或草稿内容中仍引用了该图片
+	Reason string `json:"reason,omitempty"`
+}
+
+type AdminArticleImageGCReportResponse struct {
+	DryRun         bool                      `json:"dryRun"`
+	GraceDays      int                       `json:"graceDays"`
+	Limit          int                       `json:"limit"`
+	Deleted        int                       `json:"deleted"`
+	Kept           int                       `json:"kept"`
+	ReclaimedBytes int64                     `json:"reclaimedBytes"`
+	Rows           []AdminArticleImageGCItem `json:"rows"`
+}
+
+type AdminGetArticleViewStatsRequest struct {
+	ID          string `form:"id" binding:"required"`
+	Days        int    `form:"days" binding:"omitempty,min=1,max=365"`
+	Granularity string `form:"granularity" binding:"omitempty,oneof=hour day week month"`
+}
+
+type AdminGetSiteViewStatsRequest struct {
+	Days        int    `form:"days" binding:"omitempty,min=1,max=365"`
+	Granularity string `form:"granularity" binding:"omitempty,oneof=hour day week month"`
+}
+
+// AdminViewStatsPoint 浏览量序列中的一个点，Time 按粒度为 2006-01-02T15 / 2006-01-02 / 2024-W18 / 2006-01。
+// Visitors 为按访客指纹去重的人数（按小时的序列不统计）；UniqueViews 为全站去重后的（访客, 文章）组合数
+type AdminViewStatsPoint struct {
+	Time        string `json:"time"`
+	Views       uint64 `json:"views"`
+	Visitors    uint64 `json:"visitors"`
+	UniqueViews uint64 `json:"uniqueViews,omitempty"`
+}
+
+type AdminGetArticleViewStatsResponse struct {
+	ID          string                `json:"id"`
+	Title       string                `json:"title"`
+	PublishTime string                `json:"publishTime"`
+	Granularity string                `json:"granularity"`
+	Points      []AdminViewStatsPoint `json:"points"`
+	Total       uint64                `json:"total"`
+	// 以下为开始记录按天浏览量以来的流量构成：发布后 7 天内为首发流量，其余为长尾流量
+	TrackedViews  uint64 `json:"trackedViews"`
+	LaunchViews   uint64 `json:"launchViews"`
+	LongTailViews uint64 `json:"longTailViews"`
+	PeakDay       string `json:"peakDay"`
+	PeakViews     uint64 `json:"peakViews"`
+}
+
+type AdminGetSiteViewStatsResponse struct {
+	Granularity string                `json:"granularity"`
+	Points      []AdminViewStatsPoint `json:"points"`
+	Total       uint64                `json:"total"`
+}
+
+type AdminExportArticleRequest struct {
+	Tag string `form:"tag" binding:"omitempty,max=20"`
+}
+
+type AdminExportArticleResponse struct {
+	FileName string
+	Body     []byte
+}
+
+// AdminImportArticleItem 归档中一篇 Markdown 的导入结果，Error 非空表示未创建草稿
+type AdminImportArticleItem struct {
+	Path    string `json:"path"`
+	DraftID string `json:"draftID,omitempty"`
+	Title   string `json:"title"`
+	// Slug front matter 中的文章地址，草稿不保存 slug，发布后可在编辑页设置
+	Slug     string   `json:"slug,omitempty"`
+	Images   int      `json:"images"`
+	Warnings []string `json:"warnings,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+type AdminImportArticleResponse struct {
+	Rows     []AdminImportArticleItem `json:"rows"`
+	Total    int                      `json:"total"`
+	Imported int                      `json:"imported"`
+}
+
+type AdminGetArticleRevisionListRequest struct {
+	ArticleID string `form:"articleID" binding:"required,lte=19"`
+	Page      int    `form:"page" binding:"required,gte=1"`
+	PageSize  int    `form:"pageSize" binding:"required,gte=1,lte=20"`
+}
+
+type AdminArticleRevisionItem struct {
+	ID         string   `json:"id"`
+	ArticleID  string   `json:"articleID"`
+	Title      string   `json:"title"`
+	Tags       []string `json:"tags"`
+	Source     string   `json:"source"`
+	CreateTime string   `json:"createTime"`
+}
+
+type AdminGetArticleRevisionListResponse struct {
+	Rows  []AdminArticleRevisionItem `json:"rows"`
+	Total int                        `json:"total"`
+}
+
+type AdminGetArticleRevisionDiffRequest struct {
+	FromID string `form:"fromID" binding:"required,lte=19"`
+	ToID   string `form:"toID" binding:"required,lte=19"`
+}
+
+type AdminArticleRevisionFieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// AdminArticleRevisionDiffLine 行级 diff，Type 取值 equal/insert/delete，行号从 1 开始
+type AdminArticleRevisionDiffLine struct {
+	Type    string `json:"type"`
+	OldLine int    `json:"oldLine,omitempty"`
+	NewLine int    `json:"newLine,omitempty"`
+	Text    string `json:"text"`
+}
+
+type AdminArticleRevisionDiffHunk struct {
+	OldStart int                            `json:"oldStart"`
+	OldLines int                            `json:"oldLines"`
+	NewStart int                            `json:"newStart"`
+	NewLines int                            `json:"newLines"`
+	Lines    []AdminArticleRevisionDiffLine `json:"lines"`
+}
+
+type AdminGetArticleRevisionDiffResponse struct {
+	From      AdminArticleRevisionItem          `json:"from"`
+	To        AdminArticleRevisionItem          `json:"to"`
+	Fields    []AdminArticleRevisionFieldChange `json:"fields"`
+	Hunks     []AdminArticleRevisionDiffHunk    `json:"hunks"`
+	Added     int                               `json:"added"`
+	Removed   int                               `json:"removed"`
+	Truncated bool                              `json:"truncated"`
+}
+
+type AdminRestoreArticleRevisionRequest struct {
+	ID string `json:"id" binding:"required,lte=19"`
+	// DraftVersion 文章已有编辑草稿时必填，为打开该草稿时拿到的版本号，不一致或不传即返回冲突
+	DraftVersion uint64 `json:"draftVersion" binding:"omitempty,gte=1"`
+}
+
+type UserGetArticleFeedRequest struct {
+	Format string `uri:"format" binding:"required,oneof=rss atom json"`
+	Tag    string `form:"tag" binding:"omitempty,max=20"`
+	// Origin 由 handler 根据请求填充，仅在站点地址未配置时用于拼接链接，此时结果不缓存
+	Origin string `uri:"-" form:"-"`
+}
+
+type UserGetArticleFeedResponse struct {
+	ContentType  string
+	Body         []byte
+	ETag         string
+	LastModified time.Time
+}
+
 type UserGetArticleListRequest struct {
 	Page     int `form:"page" binding:"required,gte=1"`
 	PageSize int `form:"pageSize" binding:"required,gte=1,lte=10"`
 }
 
 type UserGetArticleItem struct {
-	ID         string `json:"id"`
-	Title      string `json:"title"`
-	TagName    string `json:"tagName,omitempty"`
-	Describe   string `json:"describe,omitempty"`
-	CreateTime string `json:"createTime"`
-	UpdateTime string `json:"updateTime,omitempty"`
-	ViewNum    int    `json:"viewNum"`
+	ID         string   `json:"id"`
+	Slug       string   `json:"slug,omitempty"`
+	Title      string   `json:"title"`
+	Tags       []string `json:"tags,omitempty"`
+	Describe   string   `json:"describe,omitempty"`
+	CreateTime string   `json:"createTime"`
+	UpdateTime string   `json:"updateTime,omitempty"`
+	ViewNum    int      `json:"viewNum"`
+	Pinned     bool     `json:"pinned,omitempty"`
 }
 
 type UserGetArticleListResponse struct {
@@ -189,9 +501,21 @@ type UserSearchArticleRequest struct {
 	PageSize int    `form:"pageSize" binding:"required,gte=1,lte=10"`
 }
 
+// UserSearchArticleItem 搜索结果，TitleHighlight/Snippet 为已转义的 HTML，命中词以 <mark> 包裹
+type UserSearchArticleItem struct {
+	ID             string  `json:"id"`
+	Title          string  `json:"title"`
+	Describe       string  `json:"describe,omitempty"`
+	CreateTime     string  `json:"createTime"`
+	ViewNum        int     `json:"viewNum"`
+	Score          float64 `json:"score"`
+	TitleHighlight string  `json:"titleHighlight"`
+	Snippet        string  `json:"snippet"`
+}
+
 type UserSearchArticleResponse struct {
-	Rows  []UserGetArticleItem `json:"rows"`
-	Total int                  `json:"total"`
+	Rows  []UserSearchArticleItem `json:"rows"`
+	Total int                     `json:"total"`
 }
 
 type GetHotArticleItem struct {
@@ -205,17 +529,73 @@ type UserGetHotArticleResponse struct {
 	Total int                 `json:"total"`
 }
 
-type UserGetArticleDetailRequest struct {
-	ID string `form:"id" binding:"required,lte=19"`
+// UserGetTrendingArticleRequest Size 为空时使用配置中的趋势榜长度
+type UserGetTrendingArticleRequest struct {
+	Size int `form:"size" binding:"omitempty,min=1,max=50"`
 }
 
-type UserGetArticleDetailResponse struct {
+type UserGetTrendingArticleResponse struct {
+	Rows  []GetHotArticleItem `json:"rows"`
+	Total int                 `json:"total"`
+}
+
+type UserGetRelatedArticleRequest struct {
+	ID    string `form:"id" binding:"required,lte=19"`
+	Limit int    `form:"limit" binding:"omitempty,gte=1,lte=10"`
+}
+
+// UserRelatedArticleItem 相关文章，Source 为 content（内容相似）或 tag（同标签补齐）
+type UserRelatedArticleItem struct {
 	ID         string `json:"id"`
 	Title      string `json:"title"`
-	TagName    string `json:"tag"`
-	Content    string `json:"content"`
+	Describe   string `json:"describe"`
 	CreateTime string `json:"createTime"`
-	UpdateTime string `json:"updateTime"`
+	ViewNum    int    `json:"viewNum"`
+	Source     string `json:"source"`
+}
+
+type UserGetRelatedArticleResponse struct {
+	Rows  []UserRelatedArticleItem `json:"rows"`
+	Total int                      `json:"total"`
+}
+
+// UserGetArticleDetailRequest ID 为文章 ID 或 slug
+type UserGetArticleDetailRequest struct {
+	ID string `form:"id" binding:"required,lte=100"`
+	// AccessTokens 加密文章的访问凭证，key 为文章 ID，由 handler 从 Cookie 中收集
+	AccessTokens map[string]string `form:"-"`
+}
+
+// UserArticleMovedResponse 访问文章旧 slug 时返回的新地址，Slug 为空时使用 ID
+type UserArticleMovedResponse struct {
+	ID   string `json:"id"`
+	Slug string `json:"slug"`
+}
+
+type UserGetArticleDetailResponse struct {
+	ID          string                    `json:"id"`
+	Slug        string                    `json:"slug"`
+	Title       string                    `json:"title"`
+	Tags        []string                  `json:"tags"`
+	Content     string                    `json:"content"`
+	CreateTime  string                    `json:"createTime"`
+	UpdateTime  string                    `json:"updateTime"`
+	TOC         []ArticleTOCNode          `json:"toc"`
+	WordCount   int                       `json:"wordCount"`
+	CharCount   int                       `json:"charCount"`
+	ReadingTime int                       `json:"readingTime"`      // 预计阅读分钟数
+	Series      *UserArticleSeriesContext `json:"series,omitempty"` // 文章不属于任何系列时省略
+	// Protected 加密文章的内容依赖访问凭证 Cookie，handler 据此禁止共享缓存。
+	// 系列导航在读取详情缓存之后才合并进来，不随文章更新时间变化，因此不设置 Last-Modified，条件请求只按 ETag 判断
+	Protected bool `json:"-"`
+}
+
+// ArticleTOCNode 文章目录节点，Anchor 按 GitHub 标题锚点规则生成
+type ArticleTOCNode struct {
+	Level    int              `json:"level"`
+	Text     string           `json:"text"`
+	Anchor   string           `json:"anchor"`
+	Children []ArticleTOCNode `json:"children,omitempty"`
 }
 
 type GetTimelineListItem struct {
@@ -233,3 +613,42 @@ type GetTimelineResponse struct {
 	Rows  []GetTimelineRowsItem `json:"rows"`
 	Total int                   `json:"total"`
 }
+
+type UserArchiveMonthItem struct {
+	Month int   `json:"month"`
+	Count int64 `json:"count"`
+}
+
+type UserArchiveYearItem struct {
+	Year   int                    `json:"year"`
+	Count  int64                  `json:"count"`
+	Months []UserArchiveMonthItem `json:"months"`
+}
+
+// UserGetArticleArchiveResponse 公开文章按创建时间的年、月分组计数，年月均倒序
+type UserGetArticleArchiveResponse struct {
+	Years []UserArchiveYearItem `json:"years"`
+	Total int64                 `json:"total"`
+}
+
+type UserGetArticleArchiveMonthRequest struct {
+	Year     int `form:"year" binding:"required,gte=1970,lte=9999"`
+	Month    int `form:"month" binding:"required,gte=1,lte=12"`
+	Page     int `form:"page" binding:"required,gte=1"`
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=50"`
+}
+
+type UserArchiveArticleItem struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Slug       string `json:"slug,omitempty"`
+	CreateTime string `json:"createTime"`
+}
+
+// UserGetArticleArchiveMonthResponse 某月的公开文章，按创建时间倒序分页
+type UserGetArticleArchiveMonthResponse struct {
+	Year  int                      `json:"year"`
+	Month int                      `json:"month"`
+	Rows  []UserArchiveArticleItem `json:"rows"`
+	Total int                      `json:"total"`
+}
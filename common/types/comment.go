@@ -125,6 +125,39 @@ type AdminDeleteCommentRequest struct {
 	IDList []string `json:"idList" binding:"omitempty,dive,lte=19"`
 }
 
+type AdminGetCommentTrashListRequest struct {
+	Page     int `form:"page" binding:"required,gte=1"`
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=50"`
+}
+
+type AdminCommentTrashItem struct {
+	ID           string `json:"id"`
+	ArticleID    string `json:"articleID"`
+	ArticleTitle string `json:"articleTitle"`
+	AuthorName   string `json:"authorName"`
+	Content      string `json:"content"`
+	Status       string `json:"status"`
+	CreateTime   string `json:"createTime"`
+	DeleteTime   string `json:"deleteTime"`
+	PurgeTime    string `json:"purgeTime"`
+}
+
+type AdminGetCommentTrashListResponse struct {
+	Rows  []AdminCommentTrashItem `json:"rows"`
+	Total int                     `json:"total"`
+}
+
+// AdminRestoreCommentRequest 与删除一致，支持单个 ID 或批量 IDList
+type AdminRestoreCommentRequest struct {
+	ID     string   `json:"id" binding:"omitempty,lte=19"`
+	IDList []string `json:"idList" binding:"omitempty,dive,lte=19"`
+}
+
+type AdminPurgeCommentRequest struct {
+	ID     string   `json:"id" binding:"omitempty,lte=19"`
+	IDList []string `json:"idList" binding:"omitempty,dive,lte=19"`
+}
+
 type AdminPreviewCommentModerationRequest struct {
 	Content   string   `json:"content" binding:"omitempty,max=1000"`
 	Comments  []string `json:"comments" binding:"omitempty,max=5000,dive,max=1000"`
@@ -0,0 +1,69 @@
+package types
+
+// AdminGetDashboardRequest 时间范围为 [from, to]（含两端，格式 2006-01-02），只传 from 时截止到今天；
+// 不传 from / to 时统计最近 days 天，days 也不传时按配置的默认天数。refresh 为 true 时跳过缓存重新统计
+type AdminGetDashboardRequest struct {
+	From    string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To      string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	Days    int    `form:"days" binding:"omitempty,min=1,max=365"`
+	Refresh bool   `form:"refresh"`
+}
+
+// AdminDashboardComments 按当前状态统计的新评论数
+type AdminDashboardComments struct {
+	Total    int64 `json:"total"`
+	Pending  int64 `json:"pending"`
+	Approved int64 `json:"approved"`
+	Rejected int64 `json:"rejected"`
+}
+
+// AdminDashboardModeration 新评论提交时的自动审核结果，Checked 不含记录审核结果之前的历史评论；
+// RejectRate = Rejected / Checked，Checked 为 0 时（如上线前的日期）为 null，表示未知而非 0%
+type AdminDashboardModeration struct {
+	Checked    int64    `json:"checked"`
+	Rejected   int64    `json:"rejected"`
+	RejectRate *float64 `json:"rejectRate"`
+}
+
+// AdminDashboardGuard 风控守卫的评估结果，Rejected 为除通过外的全部决策；没有任何决策记录时 RejectRate 为 null
+type AdminDashboardGuard struct {
+	Accepted   int64    `json:"accepted"`
+	Rejected   int64    `json:"rejected"`
+	RejectRate *float64 `json:"rejectRate"`
+}
+
+// AdminDashboardMetrics 一段时间内的各项指标。Views / Visitors 来自已回写的按天浏览量，当天数据有回写延迟
+type AdminDashboardMetrics struct {
+	ArticlesPublished int64                    `json:"articlesPublished"`
+	Views             uint64                   `json:"views"`
+	Visitors          uint64                   `json:"visitors"`
+	NewUsers          int64                    `json:"newUsers"`
+	Comments          AdminDashboardComments   `json:"comments"`
+	Reports           int64                    `json:"reports"`
+	Moderation        AdminDashboardModeration `json:"moderation"`
+	Guard             AdminDashboardGuard      `json:"guard"`
+}
+
+// AdminDashboardPoint 趋势中的一天，缺失的日期各项为 0
+type AdminDashboardPoint struct {
+	Day string `json:"day"`
+	AdminDashboardMetrics
+}
+
+// AdminDashboardGuardItem 某场景某类决策在统计范围内的次数
+type AdminDashboardGuardItem struct {
+	Scene    string `json:"scene"`
+	Decision string `json:"decision"`
+	Count    int64  `json:"count"`
+}
+
+// AdminGetDashboardResponse Totals 为范围内的合计；PendingReports 为当前全部待处理举报数，不受时间范围影响
+type AdminGetDashboardResponse struct {
+	From           string                    `json:"from"`
+	To             string                    `json:"to"`
+	Totals         AdminDashboardMetrics     `json:"totals"`
+	PendingReports int64                     `json:"pendingReports"`
+	Points         []AdminDashboardPoint     `json:"points"`
+	GuardDecisions []AdminDashboardGuardItem `json:"guardDecisions"`
+	GeneratedAt    string                    `json:"generatedAt"`
+}
@@ -0,0 +1,38 @@
+package types
+
+// AdminGetArticlePromotionRequest Kind 为 pinned（置顶）或 featured（精选）
+type AdminGetArticlePromotionRequest struct {
+	Kind string `form:"kind" binding:"required,oneof=pinned featured"`
+}
+
+type AdminArticlePromotionItem struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Status     string `json:"status"`
+	CreateTime string `json:"createTime"`
+}
+
+type AdminGetArticlePromotionResponse struct {
+	Kind  string                      `json:"kind"`
+	Rows  []AdminArticlePromotionItem `json:"rows"`
+	Total int                         `json:"total"`
+}
+
+// AdminUpdateArticlePromotionRequest 按 articleIDs 的顺序整体替换推荐位，增删与排序都走这个接口
+type AdminUpdateArticlePromotionRequest struct {
+	Kind       string   `json:"kind" binding:"required,oneof=pinned featured"`
+	ArticleIDs []string `json:"articleIDs" binding:"max=20,dive,required,lte=19"`
+}
+
+type UserFeaturedArticleItem struct {
+	ID         string `json:"id"`
+	Slug       string `json:"slug,omitempty"`
+	Title      string `json:"title"`
+	Describe   string `json:"describe,omitempty"`
+	CreateTime string `json:"createTime"`
+}
+
+type UserGetFeaturedArticleResponse struct {
+	Rows  []UserFeaturedArticleItem `json:"rows"`
+	Total int                       `json:"total"`
+}
@@ -0,0 +1,99 @@
+package types
+
+type SeriesArticleItem struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	CreateTime string `json:"createTime,omitempty"`
+}
+
+type AdminSeriesItem struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Describe   string `json:"describe"`
+	ArticleNum int    `json:"articleNum"`
+	CreateTime string `json:"createTime"`
+	UpdateTime string `json:"updateTime"`
+}
+
+type AdminGetSeriesListResponse struct {
+	Rows  []AdminSeriesItem `json:"rows"`
+	Total int               `json:"total"`
+}
+
+type AdminGetSeriesDetailRequest struct {
+	ID string `form:"id" binding:"required,lte=19"`
+}
+
+type AdminSeriesArticleItem struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+type AdminGetSeriesDetailResponse struct {
+	ID       string                   `json:"id"`
+	Title    string                   `json:"title"`
+	Describe string                   `json:"describe"`
+	Articles []AdminSeriesArticleItem `json:"articles"`
+}
+
+type AdminAddSeriesRequest struct {
+	Title      string   `json:"title" binding:"required,lte=100"`
+	Describe   string   `json:"describe" binding:"lte=200"`
+	ArticleIDs []string `json:"articleIDs" binding:"max=100,dive,required,lte=19"`
+}
+
+type AdminAddSeriesResponse struct {
+	ID string `json:"id"`
+}
+
+type AdminUpdateSeriesRequest struct {
+	ID       string `json:"id" binding:"required,lte=19"`
+	Title    string `json:"title" binding:"required,lte=100"`
+	Describe string `json:"describe" binding:"lte=200"`
+}
+
+// AdminUpdateSeriesArticlesRequest 按 articleIDs 的顺序整体替换系列成员，增删与排序都走这个接口
+type AdminUpdateSeriesArticlesRequest struct {
+	ID         string   `json:"id" binding:"required,lte=19"`
+	ArticleIDs []string `json:"articleIDs" binding:"max=100,dive,required,lte=19"`
+}
+
+type AdminDeleteSeriesRequest struct {
+	ID string `json:"id" binding:"required,lte=19"`
+}
+
+type UserSeriesItem struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Describe   string `json:"describe"`
+	ArticleNum int    `json:"articleNum"`
+	UpdateTime string `json:"updateTime"`
+}
+
+type UserGetSeriesListResponse struct {
+	Rows  []UserSeriesItem `json:"rows"`
+	Total int              `json:"total"`
+}
+
+type UserGetSeriesDetailRequest struct {
+	ID string `form:"id" binding:"required,lte=19"`
+}
+
+type UserGetSeriesDetailResponse struct {
+	ID         string              `json:"id"`
+	Title      string              `json:"title"`
+	Describe   string              `json:"describe"`
+	UpdateTime string              `json:"updateTime"`
+	Articles   []SeriesArticleItem `json:"articles"`
+}
+
+// UserArticleSeriesContext 文章详情中的系列导航，Position 从 1 开始
+type UserArticleSeriesContext struct {
+	ID       string             `json:"id"`
+	Title    string             `json:"title"`
+	Position int                `json:"position"`
+	Total    int                `json:"total"`
+	Prev     *SeriesArticleItem `json:"prev"`
+	Next     *SeriesArticleItem `json:"next"`
+}
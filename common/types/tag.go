@@ -31,7 +31,7 @@ type AdminGetArticleListByTagResponse struct {
 
 type AdminUpdateTagRequest struct {
 	ArticleIDList []string `json:"articleIDList" binding:"required"`
-	NewTagName    string   `json:"newTagName" binding:"required,lte=20"`
+	NewTagName    string   `json:"newTagName" binding:"required,lte=20,excludesall=0x2C"`
 	OldTagName    string   `json:"oldTagName" binding:"required,lte=20"`
 }
 
@@ -0,0 +1,37 @@
+package types
+
+import "github.com/golang-jwt/jwt/v5"
+
+// AdminUpdateArticleVisibilityRequest Visibility 为 public / unlisted / protected。
+// 设为 protected 时 Password 为空表示沿用原密码，文章此前没有密码时必填
+type AdminUpdateArticleVisibilityRequest struct {
+	ID         string `json:"id" binding:"required,lte=19"`
+	Visibility string `json:"visibility" binding:"required,oneof=public unlisted protected"`
+	Password   string `json:"password" binding:"omitempty,min=4,max=64"`
+}
+
+type UserUnlockArticleRequest struct {
+	ID       string `json:"id" binding:"required,lte=19"`
+	Password string `json:"password" binding:"required,max=64"`
+	ClientIP string `json:"-" form:"-"`
+}
+
+// UserUnlockArticleResponse Token 由 handler 写入 Cookie，不在响应体中返回
+type UserUnlockArticleResponse struct {
+	ID    string `json:"id"`
+	Token string `json:"-"`
+}
+
+// UserArticleProtectedResponse 访问加密文章但未解锁时返回，前端据此展示密码输入框
+type UserArticleProtectedResponse struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// ArticleAccessClaims 加密文章访问凭证。AccessVersion 随密码变化，改密码后旧凭证自动失效
+type ArticleAccessClaims struct {
+	ArticleID     string `json:"articleID"`
+	AccessVersion string `json:"accessVersion"`
+	TokenUse      string `json:"tokenUse"`
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	"meta-api/common/env"
+	"meta-api/common/types"
+)
+
+const (
+	// ArticleAccessCookiePrefix 加密文章访问凭证 Cookie 名前缀，后接文章 ID
+	ArticleAccessCookiePrefix = "article_access_"
+	articleAccessTTL          = 2 * time.Hour
+	articleAccessCookiePath   = "/"
+	articleAccessTokenUse     = "article_access"
+)
+
+func GenerateArticleAccessToken(articleID string, accessVersion string) (string, error) {
+	signingKey, err := RequiredEnvOrFile(env.JWTSigningKey)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims := &types.ArticleAccessClaims{
+		ArticleID:     articleID,
+		AccessVersion: accessVersion,
+		TokenUse:      articleAccessTokenUse,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(articleAccessTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString([]byte(signingKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate article access token: %w", err)
+	}
+	return tokenString, nil
+}
+
+func ParseArticleAccessToken(tokenString string) (*types.ArticleAccessClaims, error) {
+	signingKey, err := RequiredEnvOrFile(env.JWTSigningKey)
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.ParseWithClaims(tokenString, &types.ArticleAccessClaims{},
+		func(token *jwt.Token) (any, error) {
+			if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Method.Alg())
+			}
+			return []byte(signingKey), nil
+		},
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errors.New("TokenExpired")
+		}
+		return nil, fmt.Errorf("failed to parse article access token: %w", err)
+	}
+	if token == nil {
+		return nil, errors.New("token is null")
+	}
+
+	claims, ok := token.Claims.(*types.ArticleAccessClaims)
+	if !ok {
+		return nil, fmt.Errorf("token claims are of incorrect type: %T", token.Claims)
+	}
+	if claims.TokenUse != articleAccessTokenUse {
+		return nil, errors.New("invalid token use")
+	}
+	return claims, nil
+}
+
+// SetArticleAccessCookie 每篇加密文章单独一个 Cookie，互不覆盖
+func SetArticleAccessCookie(c *gin.Context, articleID string, token string) {
+	secure := IsProductionEnv()
+	c.SetSameSite(commentAuthSameSiteMode())
+	c.SetCookie(ArticleAccessCookiePrefix+articleID, token, int(articleAccessTTL/time.Second),
+		articleAccessCookiePath, "", secure, true)
+}
+
+// ArticleAccessTokens 收集请求中所有加密文章访问凭证，key 为文章 ID
+func ArticleAccessTokens(c *gin.Context) map[string]string {
+	tokens := make(map[string]string)
+	for _, cookie := range c.Request.Cookies() {
+		articleID, ok := strings.CutPrefix(cookie.Name, ArticleAccessCookiePrefix)
+		if ok && articleID != "" && cookie.Value != "" {
+			tokens[articleID] = cookie.Value
+		}
+	}
+	return tokens
+}
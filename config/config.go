@@ -2,6 +2,7 @@ package config
 
 import (
 	"maps"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -83,9 +84,105 @@ type ArticleImageCOSConfig struct {
 	PublicBaseURL string `mapstructure:"public_base_url"`
 }
 
+// ArticleImageLocalConfig 描述本地磁盘存储，供本地开发在没有云存储凭证时使用。
+type ArticleImageLocalConfig struct {
+	// Root 图片保存目录
+	Root string `mapstructure:"root"`
+	// RoutePrefix 由本服务提供静态访问的路由前缀，默认 /static/images
+	RoutePrefix string `mapstructure:"route_prefix"`
+	// PublicBaseURL 图片公开地址前缀，为空时使用 RoutePrefix
+	PublicBaseURL string `mapstructure:"public_base_url"`
+}
+
+// ArticleImageS3Config 描述 S3 兼容存储（AWS S3、MinIO 等）的非敏感配置，密钥来自 env。
+type ArticleImageS3Config struct {
+	Endpoint string `mapstructure:"endpoint"`
+	Region   string `mapstructure:"region"`
+	Bucket   string `mapstructure:"bucket"`
+	// Directory 对象 Key 前缀
+	Directory string `mapstructure:"directory"`
+	// PublicBaseURL 图片公开地址前缀（通常是 CDN），为空时使用桶地址
+	PublicBaseURL string `mapstructure:"public_base_url"`
+	// PathStyle 使用 endpoint/bucket/key 形式访问，MinIO 需要开启
+	PathStyle bool `mapstructure:"path_style"`
+}
+
+// ArticleImageVariantConfig 描述上传时生成的响应式图片变体。
+type ArticleImageVariantConfig struct {
+	Disabled bool `mapstructure:"disabled"`
+	// Widths 缩略图宽度（像素），不超过原图宽度的档位才会生成
+	Widths []int `mapstructure:"widths"`
+	// Quality JPEG 重新压缩质量（1~100）
+	Quality int `mapstructure:"quality"`
+}
+
+// ArticleImageGCConfig 描述未引用图片的定时回收。
+type ArticleImageGCConfig struct {
+	Disabled bool `mapstructure:"disabled"`
+	// GraceDays 图片变为未使用后保留的天数，覆盖上传后尚未保存文章的草稿编辑期
+	GraceDays int `mapstructure:"grace_days"`
+	// MaxPerRun 单次最多删除的图片数
+	MaxPerRun int `mapstructure:"max_per_run"`
+}
+
 // ArticleImageConfig 描述文章图片资源配置。
 type ArticleImageConfig struct {
-	COS ArticleImageCOSConfig `mapstructure:"cos"`
+	// Backend 存储后端：cos（默认）、local、s3
+	Backend  string                    `mapstructure:"backend"`
+	COS      ArticleImageCOSConfig     `mapstructure:"cos"`
+	Local    ArticleImageLocalConfig   `mapstructure:"local"`
+	S3       ArticleImageS3Config      `mapstructure:"s3"`
+	Variants ArticleImageVariantConfig `mapstructure:"variants"`
+	GC       ArticleImageGCConfig      `mapstructure:"gc"`
+}
+
+// FeedConfig 描述 RSS / Atom / JSON Feed 订阅源的站点信息。
+type FeedConfig struct {
+	Title       string `mapstructure:"title"`
+	Description string `mapstructure:"description"`
+	Author      string `mapstructure:"author"`
+	Language    string `mapstructure:"language"`
+	// SiteURL 前台站点地址，用于拼接文章链接。生产环境必须配置：为空时按请求的 Host 推导且不缓存订阅源
+	SiteURL string `mapstructure:"site_url"`
+	// APIURL 本服务对外地址（含反向代理前缀，如 https://example.com/api），用于订阅源自身地址；为空时使用 SiteURL
+	APIURL string `mapstructure:"api_url"`
+	// Limit 每个订阅源输出的最新文章数
+	Limit int `mapstructure:"limit"`
+}
+
+// TrashConfig 描述回收站配置。
+type TrashConfig struct {
+	// RetentionDays 文章、评论、图片在回收站中的保留天数，超过后由定时任务彻底删除
+	RetentionDays int `mapstructure:"retention_days"`
+}
+
+// ViewStatsConfig 描述文章浏览量时间序列配置。
+type ViewStatsConfig struct {
+	// Hourly 是否额外记录按小时分桶的浏览量，只保存在 Redis 中，保留 HourlyRetentionDays 天
+	Hourly              bool `mapstructure:"hourly"`
+	HourlyRetentionDays int  `mapstructure:"hourly_retention_days"`
+}
+
+// RankingConfig 描述前台热门 / 趋势文章榜单配置。
+type RankingConfig struct {
+	// HotSize 热门文章（按累计浏览量）返回条数
+	HotSize int `mapstructure:"hot_size"`
+	// TrendingSize 趋势文章默认返回条数，请求可通过 size 参数覆盖
+	TrendingSize int `mapstructure:"trending_size"`
+	// TrendingWindowHours 参与趋势计算的最近事件时长，更早的浏览与评论不再计入
+	TrendingWindowHours int `mapstructure:"trending_window_hours"`
+	// TrendingHalfLifeHours 事件权重的半衰期，越小越偏向最近的事件
+	TrendingHalfLifeHours int `mapstructure:"trending_half_life_hours"`
+	// TrendingCommentWeight 一条评论相当于多少次浏览
+	TrendingCommentWeight float64 `mapstructure:"trending_comment_weight"`
+}
+
+// DashboardConfig 描述后台数据看板配置。
+type DashboardConfig struct {
+	// DefaultDays 请求未指定时间范围时统计最近多少天（含今天）
+	DefaultDays int `mapstructure:"default_days"`
+	// MaxDays 单次查询允许的最大天数，不超过 365
+	MaxDays int `mapstructure:"max_days"`
 }
 
 // GuardConfig 风控守卫引擎配置。
@@ -149,6 +246,13 @@ type BugFeedbackRateLimitConfig struct {
 	IP       RateLimitWindowConfig `mapstructure:"ip"`
 }
 
+// ArticleUnlockRateLimitConfig 描述加密文章密码解锁限流策略。
+type ArticleUnlockRateLimitConfig struct {
+	Disabled  bool                  `mapstructure:"disabled"`
+	IP        RateLimitWindowConfig `mapstructure:"ip"`
+	IPArticle RateLimitWindowConfig `mapstructure:"ip_article"`
+}
+
 // CommentModerationScoreConfig 描述评论审核评分决策阈值。
 type CommentModerationScoreConfig struct {
 	Pending int `mapstructure:"pending"`
@@ -280,6 +384,7 @@ type RateLimitConfig struct {
 	CommentSubmit CommentSubmitRateLimitConfig `mapstructure:"comment_submit"`
 	CommentReport CommentReportRateLimitConfig `mapstructure:"comment_report"`
 	BugFeedback   BugFeedbackRateLimitConfig   `mapstructure:"bug_feedback"`
+	ArticleUnlock ArticleUnlockRateLimitConfig `mapstructure:"article_unlock"`
 }
 
 // Config 定义项目配置文件结构体
@@ -294,6 +399,11 @@ type Config struct {
 	AdminInfoConfig         *AdminInfoConfig         `mapstructure:"admin_info"`
 	BugFeedbackConfig       *BugFeedbackConfig       `mapstructure:"bug_feedback"`
 	ArticleImageConfig      *ArticleImageConfig      `mapstructure:"article_image"`
+	FeedConfig              *FeedConfig              `mapstructure:"feed"`
+	TrashConfig             *TrashConfig             `mapstructure:"trash"`
+	ViewStatsConfig         *ViewStatsConfig         `mapstructure:"view_stats"`
+	RankingConfig           *RankingConfig           `mapstructure:"ranking"`
+	DashboardConfig         *DashboardConfig         `mapstructure:"dashboard"`
 	GuardConfig             *GuardConfig             `mapstructure:"guard"`
 	RateLimitConfig         *RateLimitConfig         `mapstructure:"rate_limit"`
 	CommentModerationConfig *CommentModerationConfig `mapstructure:"comment_moderation"`
@@ -316,6 +426,11 @@ func (c *Config) Replace(next *Config) {
 	c.AdminInfoConfig = next.AdminInfoConfig
 	c.BugFeedbackConfig = next.BugFeedbackConfig
 	c.ArticleImageConfig = next.ArticleImageConfig
+	c.FeedConfig = next.FeedConfig
+	c.TrashConfig = next.TrashConfig
+	c.ViewStatsConfig = next.ViewStatsConfig
+	c.RankingConfig = next.RankingConfig
+	c.DashboardConfig = next.DashboardConfig
 	c.GuardConfig = next.GuardConfig
 	c.RateLimitConfig = next.RateLimitConfig
 	c.CommentModerationConfig = next.CommentModerationConfig
@@ -331,6 +446,11 @@ func (c *Config) Replace(next *Config) {
 //   - oauth：OAuth client_id / redirect_uri 等非敏感配置，secret 仍来自 env；
 //   - admin_info：前台 about-me 展示信息；
 //   - bug_feedback：SMTP 非敏感配置，密码仍来自 env / secret file；
+//   - feed：订阅源标题、站点地址等展示信息（已缓存的订阅内容在下次失效后生效）；
+//   - trash：回收站保留天数（下次定时清理时生效）；
+//   - view_stats：是否记录按小时分桶的浏览量及其保留天数；
+//   - ranking：热门 / 趋势文章条数与趋势衰减参数（趋势榜在下次重新计算时生效）；
+//   - dashboard：后台看板默认与最大统计天数；
 //   - rate_limit：后台登录、评论、反馈等应用级限流规则；
 //   - comment_moderation：评论审核策略。
 //
@@ -346,6 +466,11 @@ func (c *Config) ReplaceHotReloadable(next *Config) {
 	c.OAuthConfig = next.OAuthConfig
 	c.AdminInfoConfig = next.AdminInfoConfig
 	c.BugFeedbackConfig = next.BugFeedbackConfig
+	c.FeedConfig = next.FeedConfig
+	c.TrashConfig = next.TrashConfig
+	c.ViewStatsConfig = next.ViewStatsConfig
+	c.RankingConfig = next.RankingConfig
+	c.DashboardConfig = next.DashboardConfig
 	c.RateLimitConfig = next.RateLimitConfig
 	c.CommentModerationConfig = next.CommentModerationConfig
 }
@@ -396,17 +521,225 @@ func (c *Config) BugFeedbackSnapshot() BugFeedbackConfig {
 	return *c.BugFeedbackConfig
 }
 
-// ArticleImageCOSSnapshot 返回文章图片 COS 配置快照。
-func (c *Config) ArticleImageCOSSnapshot() ArticleImageCOSConfig {
+// ArticleImageStorageSnapshot 返回文章图片存储配置快照。
+func (c *Config) ArticleImageStorageSnapshot() ArticleImageConfig {
 	if c == nil {
-		return ArticleImageCOSConfig{}
+		return ArticleImageConfig{}
 	}
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	if c.ArticleImageConfig == nil {
-		return ArticleImageCOSConfig{}
+		return ArticleImageConfig{}
+	}
+	return *c.ArticleImageConfig
+}
+
+// ArticleImageVariantSnapshot 返回文章图片变体配置快照。
+func (c *Config) ArticleImageVariantSnapshot() ArticleImageVariantConfig {
+	if c == nil {
+		return ArticleImageVariantConfig{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.ArticleImageConfig == nil {
+		return ArticleImageVariantConfig{}
+	}
+	return c.ArticleImageConfig.Variants
+}
+
+// VariantWidths 去重并升序排列的缩略图宽度，未配置时为 320 / 640 / 1280。
+func (v ArticleImageVariantConfig) VariantWidths() []int {
+	widths := v.Widths
+	if len(widths) == 0 {
+		widths = []int{320, 640, 1280}
+	}
+	result := make([]int, 0, len(widths))
+	for _, width := range widths {
+		if width > 0 && !slices.Contains(result, width) {
+			result = append(result, width)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// JPEGQuality JPEG 压缩质量，未配置或配置非法时为 80。
+func (v ArticleImageVariantConfig) JPEGQuality() int {
+	if v.Quality <= 0 || v.Quality > 100 {
+		return 80
+	}
+	return v.Quality
+}
+
+// ArticleImageGCSnapshot 返回未引用图片回收配置快照。
+func (c *Config) ArticleImageGCSnapshot() ArticleImageGCConfig {
+	if c == nil {
+		return ArticleImageGCConfig{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.ArticleImageConfig == nil {
+		return ArticleImageGCConfig{}
+	}
+	return c.ArticleImageConfig.GC
+}
+
+// GracePeriod 未使用图片的保留时长，未配置或配置非法时按 7 天处理。
+func (g ArticleImageGCConfig) GracePeriod() time.Duration {
+	days := g.GraceDays
+	if days <= 0 {
+		days = 7
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// RunLimit 单次最多删除的图片数，未配置或配置非法时为 100。
+func (g ArticleImageGCConfig) RunLimit() int {
+	if g.MaxPerRun <= 0 {
+		return 100
+	}
+	return g.MaxPerRun
+}
+
+// FeedSnapshot 返回订阅源配置快照。
+func (c *Config) FeedSnapshot() FeedConfig {
+	if c == nil {
+		return FeedConfig{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.FeedConfig == nil {
+		return FeedConfig{}
+	}
+	return *c.FeedConfig
+}
+
+// TrashSnapshot 返回回收站配置快照。
+func (c *Config) TrashSnapshot() TrashConfig {
+	if c == nil {
+		return TrashConfig{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.TrashConfig == nil {
+		return TrashConfig{}
+	}
+	return *c.TrashConfig
+}
+
+// Retention 回收站保留时长，未配置或配置非法时按 30 天处理。
+func (t TrashConfig) Retention() time.Duration {
+	days := t.RetentionDays
+	if days <= 0 {
+		days = 30
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// ViewStatsSnapshot 返回浏览量时间序列配置快照。
+func (c *Config) ViewStatsSnapshot() ViewStatsConfig {
+	if c == nil {
+		return ViewStatsConfig{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.ViewStatsConfig == nil {
+		return ViewStatsConfig{}
+	}
+	return *c.ViewStatsConfig
+}
+
+// HourlyRetention 按小时分桶的保留时长，未配置或配置非法时按 7 天处理。
+func (v ViewStatsConfig) HourlyRetention() time.Duration {
+	days := v.HourlyRetentionDays
+	if days <= 0 {
+		days = 7
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// RankingSnapshot 返回文章榜单配置快照。
+func (c *Config) RankingSnapshot() RankingConfig {
+	if c == nil {
+		return RankingConfig{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.RankingConfig == nil {
+		return RankingConfig{}
+	}
+	return *c.RankingConfig
+}
+
+// HotLimit 热门文章条数，未配置或配置非法时为 3。
+func (r RankingConfig) HotLimit() int {
+	if r.HotSize <= 0 {
+		return 3
+	}
+	return r.HotSize
+}
+
+// TrendingLimit 趋势文章默认条数，未配置或配置非法时为 10。
+func (r RankingConfig) TrendingLimit() int {
+	if r.TrendingSize <= 0 {
+		return 10
+	}
+	return r.TrendingSize
+}
+
+// TrendingWindow 趋势计算窗口，未配置或配置非法时为 72 小时，最长 7 天。
+func (r RankingConfig) TrendingWindow() time.Duration {
+	hours := r.TrendingWindowHours
+	if hours <= 0 {
+		hours = 72
+	}
+	return time.Duration(min(hours, 7*24)) * time.Hour
+}
+
+// TrendingHalfLife 趋势权重半衰期，未配置或配置非法时为 24 小时。
+func (r RankingConfig) TrendingHalfLife() time.Duration {
+	if r.TrendingHalfLifeHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(r.TrendingHalfLifeHours) * time.Hour
+}
+
+// CommentWeight 评论相对浏览的权重，未配置或配置非法时为 5。
+func (r RankingConfig) CommentWeight() float64 {
+	if r.TrendingCommentWeight <= 0 {
+		return 5
+	}
+	return r.TrendingCommentWeight
+}
+
+// DashboardSnapshot 返回后台看板配置快照。
+func (c *Config) DashboardSnapshot() DashboardConfig {
+	if c == nil {
+		return DashboardConfig{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.DashboardConfig == nil {
+		return DashboardConfig{}
+	}
+	return *c.DashboardConfig
+}
+
+// RangeDays 未指定时间范围时的统计天数，未配置或配置非法时为 30，且不超过 MaxRangeDays。
+func (d DashboardConfig) RangeDays() int {
+	days := d.DefaultDays
+	if days <= 0 {
+		days = 30
+	}
+	return min(days, d.MaxRangeDays())
+}
+
+// MaxRangeDays 单次查询允许的最大天数，未配置或配置非法时为 365。
+func (d DashboardConfig) MaxRangeDays() int {
+	if d.MaxDays <= 0 || d.MaxDays > 365 {
+		return 365
 	}
-	return c.ArticleImageConfig.COS
+	return d.MaxDays
 }
 
 // RateLimitSnapshot 返回限流配置快照。
@@ -28,8 +28,9 @@ const (
 
 // PurgeArticles 同步清理 CDN 上指定文章详情页的缓存。
 //
-// 入参为文章主键 ID 列表（即雪花 ID 的字符串形式），包内部按
-// `<domain>/article-detail/<id>` 拼成精确 URL 清理 target。
+// 入参为文章详情页地址段列表：文章主键 ID（雪花 ID 的字符串形式）或文章的 slug，
+// 同一篇文章的 ID、当前 slug 与历史 slug 都应传入。包内部按
+// `<domain>/article-detail/<id 或 slug>` 拼成精确 URL 清理 target。
 //
 // 重复 ID 不做去重（接口幂等，且每日配额对个人版足够）。
 // 空切片直接返回 nil；非生产环境 client 未启用时也返回 nil，避免本地开发被腾讯云 env 阻断。
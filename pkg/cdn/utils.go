@@ -4,9 +4,9 @@ package cdn
 // pages/article-detail/[id].vue 对齐。
 const articleDetailRoutePrefix = "/article-detail/"
 
-// articleDetailURL 把文章 ID 拼成 CDN purge_url 接受的精确目标 URL。
+// articleDetailURL 把文章 ID 或 slug 拼成 CDN purge_url 接受的精确目标 URL。
 //
-// 形态固定为 <domain>/article-detail/<id>，与 portal-web 的 Nuxt 路由、
+// 形态固定为 <domain>/article-detail/<id 或 slug>，与 portal-web 的 Nuxt 路由、
 // canonical URL 和 sitemap URL 保持一致。
 //
 // 入参 domain 必须是带 scheme 的完整前缀且末尾不带斜杠（由 New 统一规整），
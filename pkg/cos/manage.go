@@ -3,6 +3,7 @@ package cos
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"path"
 	"strings"
@@ -22,6 +23,30 @@ func (c *Client) Delete(ctx context.Context, objectKey string) error {
 	return nil
 }
 
+// Download 读取 COS 对象内容，maxSize 为允许的最大字节数
+func (c *Client) Download(ctx context.Context, objectKey string, maxSize int64) ([]byte, error) {
+	if c == nil || c.client == nil {
+		return nil, ErrDisabled
+	}
+	key := strings.TrimLeft(path.Clean("/"+objectKey), "/")
+	if key == "" || key == "." {
+		return nil, fmt.Errorf("empty object key")
+	}
+	resp, err := c.client.Object.Get(ctx, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("download article image from COS: %w", err)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read article image from COS: %w", err)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("article image exceeds %d bytes", maxSize)
+	}
+	return content, nil
+}
+
 func (c *Client) ObjectKey(objectName string) string {
 	return c.objectKey(objectName)
 }
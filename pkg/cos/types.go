@@ -1,16 +1,18 @@
 package cos
 
 import (
-	"errors"
 	"time"
 
 	tencentcos "github.com/tencentyun/cos-go-sdk-v5"
 	"go.uber.org/zap"
+
+	"meta-api/pkg/storage"
 )
 
 const defaultTimeout = 15 * time.Second
 
-var ErrDisabled = errors.New("article image COS storage is not configured")
+// ErrDisabled 与其他存储后端共用同一个错误，调用方只需判断 storage.ErrDisabled
+var ErrDisabled = storage.ErrDisabled
 
 type Client struct {
 	client              *tencentcos.Client
@@ -19,3 +21,5 @@ type Client struct {
 	hasCustomPublicBase bool
 	logger              *zap.Logger
 }
+
+var _ storage.ObjectStore = (*Client)(nil)
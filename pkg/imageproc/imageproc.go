@@ -0,0 +1,208 @@
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+// MaxPixels 允许解码的最大像素数，防止小文件声明超大尺寸耗尽内存
+const MaxPixels = 16_000_000
+
+var (
+	// ErrUnsupported 不是 JPEG / PNG / GIF，或是不生成变体的动图
+	ErrUnsupported = errors.New("imageproc: unsupported image")
+	// ErrTooLarge 图片像素数超过 MaxPixels
+	ErrTooLarge = errors.New("imageproc: image dimensions too large")
+)
+
+// Variant 生成的图片变体
+type Variant struct {
+	Width   int
+	Height  int
+	Content []byte
+	Mime    string
+	Ext     string
+	// Optimized 为 true 表示原尺寸重新压缩的版本，否则为缩略图
+	Optimized bool
+}
+
+// Result 图片处理结果
+type Result struct {
+	Width    int
+	Height   int
+	Variants []Variant
+}
+
+// Generate 解码 JPEG / PNG / GIF，为小于原图宽度的每个档位生成缩略图，
+// 并在重新压缩后体积更小时附带原尺寸的压缩版本。不透明图片输出 JPEG，带透明通道的输出 PNG。
+// 动图只返回尺寸，不生成变体，避免丢失动画
+func Generate(content []byte, widths []int, quality int) (*Result, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupported, err)
+	}
+	if format != "jpeg" && format != "png" && format != "gif" {
+		return nil, ErrUnsupported
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+	result := &Result{Width: config.Width, Height: config.Height}
+
+	if format == "gif" {
+		animation, err := gif.DecodeAll(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("decode gif: %w", err)
+		}
+		if len(animation.Image) > 1 {
+			return result, nil
+		}
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", format, err)
+	}
+	src := toRGBA(decoded)
+
+	for _, width := range widths {
+		if width <= 0 || width >= result.Width {
+			continue
+		}
+		variant, err := encode(Resize(src, width), quality)
+		if err != nil {
+			return nil, err
+		}
+		result.Variants = append(result.Variants, variant)
+	}
+
+	optimized, err := encode(src, quality)
+	if err != nil {
+		return nil, err
+	}
+	if len(optimized.Content) < len(content) {
+		optimized.Optimized = true
+		result.Variants = append(result.Variants, optimized)
+	}
+	return result, nil
+}
+
+// Resize 按面积平均把图片等比缩放到指定宽度，只用于缩小
+func Resize(src *image.RGBA, width int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	if width <= 0 || width >= srcW {
+		return src
+	}
+	height := max(int(float64(srcH)*float64(width)/float64(srcW)+0.5), 1)
+
+	xWeights := areaWeights(srcW, width)
+	yWeights := areaWeights(srcH, height)
+
+	// 先横向缩放到 width x srcH 的中间结果，再纵向缩放
+	temp := make([]float32, width*srcH*4)
+	for y := range srcH {
+		row := src.Pix[y*src.Stride:]
+		for x, weights := range xWeights {
+			var r, g, b, a float32
+			for _, w := range weights {
+				p := row[w.index*4:]
+				r += float32(p[0]) * w.weight
+				g += float32(p[1]) * w.weight
+				b += float32(p[2]) * w.weight
+				a += float32(p[3]) * w.weight
+			}
+			offset := (y*width + x) * 4
+			temp[offset], temp[offset+1], temp[offset+2], temp[offset+3] = r, g, b, a
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y, weights := range yWeights {
+		for x := range width {
+			var r, g, b, a float32
+			for _, w := range weights {
+				offset := (w.index*width + x) * 4
+				r += temp[offset] * w.weight
+				g += temp[offset+1] * w.weight
+				b += temp[offset+2] * w.weight
+				a += temp[offset+3] * w.weight
+			}
+			p := dst.Pix[y*dst.Stride+x*4:]
+			p[0], p[1], p[2], p[3] = clamp(r), clamp(g), clamp(b), clamp(a)
+		}
+	}
+	return dst
+}
+
+type sampleWeight struct {
+	index  int
+	weight float32
+}
+
+// areaWeights 目标像素 i 覆盖源区间 [i*scale, (i+1)*scale)，按重叠长度分配权重
+func areaWeights(srcLen int, dstLen int) [][]sampleWeight {
+	scale := float64(srcLen) / float64(dstLen)
+	result := make([][]sampleWeight, dstLen)
+	for i := range dstLen {
+		start := float64(i) * scale
+		end := start + scale
+		weights := make([]sampleWeight, 0, int(scale)+2)
+		for j := int(start); j < srcLen && float64(j) < end; j++ {
+			overlap := min(end, float64(j+1)) - max(start, float64(j))
+			if overlap > 0 {
+				weights = append(weights, sampleWeight{index: j, weight: float32(overlap / scale)})
+			}
+		}
+		result[i] = weights
+	}
+	return result
+}
+
+func clamp(v float32) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 255:
+		return 255
+	default:
+		return uint8(v + 0.5)
+	}
+}
+
+// toRGBA 统一转换为预乘 Alpha 的 RGBA，缩放时透明像素不会把颜色带暗
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
+
+func encode(img *image.RGBA, quality int) (Variant, error) {
+	bounds := img.Bounds()
+	variant := Variant{Width: bounds.Dx(), Height: bounds.Dy()}
+	var buf bytes.Buffer
+	if img.Opaque() {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return Variant{}, fmt.Errorf("encode jpeg: %w", err)
+		}
+		variant.Mime, variant.Ext = "image/jpeg", ".jpg"
+	} else {
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		if err := encoder.Encode(&buf, img); err != nil {
+			return Variant{}, fmt.Errorf("encode png: %w", err)
+		}
+		variant.Mime, variant.Ext = "image/png", ".png"
+	}
+	variant.Content = buf.Bytes()
+	return variant, nil
+}
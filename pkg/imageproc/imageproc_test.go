@@ -0,0 +1,118 @@
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerateOpaqueVariants(t *testing.T) {
+	// 带噪点的照片类内容，PNG 无损压缩效果差
+	src := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	seed := uint32(1)
+	for y := range 400 {
+		for x := range 800 {
+			seed = seed*1664525 + 1013904223
+			src.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: uint8(seed >> 24), A: 255})
+		}
+	}
+
+	result, err := Generate(encodePNG(t, src), []int{200, 640, 1280}, 80)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if result.Width != 800 || result.Height != 400 {
+		t.Fatalf("unexpected source size %dx%d", result.Width, result.Height)
+	}
+	// 1280 超过原图宽度不生成；原图是无损 PNG，重新压缩的 JPEG 更小
+	if len(result.Variants) != 3 {
+		t.Fatalf("expected 2 thumbnails and 1 optimized version, got %d", len(result.Variants))
+	}
+	thumb := result.Variants[0]
+	if thumb.Width != 200 || thumb.Height != 100 || thumb.Mime != "image/jpeg" || thumb.Optimized {
+		t.Fatalf("unexpected thumbnail %+v", thumb)
+	}
+	decoded, format, err := image.Decode(bytes.NewReader(thumb.Content))
+	if err != nil || format != "jpeg" || decoded.Bounds().Dx() != 200 {
+		t.Fatalf("thumbnail not decodable: %v %s", err, format)
+	}
+	if optimized := result.Variants[2]; !optimized.Optimized || optimized.Width != 800 {
+		t.Fatalf("unexpected optimized version %+v", optimized)
+	}
+}
+
+func TestGenerateKeepsTransparency(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+	src.Set(10, 10, color.NRGBA{R: 255, A: 128})
+
+	result, err := Generate(encodePNG(t, src), []int{50}, 80)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(result.Variants) == 0 || result.Variants[0].Mime != "image/png" {
+		t.Fatalf("transparent image should stay png, got %+v", result.Variants)
+	}
+}
+
+func TestGenerateSkipsAnimatedGIF(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	animation := &gif.GIF{
+		Image: []*image.Paletted{
+			image.NewPaletted(image.Rect(0, 0, 64, 64), palette),
+			image.NewPaletted(image.Rect(0, 0, 64, 64), palette),
+		},
+		Delay: []int{10, 10},
+	}
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, animation); err != nil {
+		t.Fatalf("encode gif: %v", err)
+	}
+
+	result, err := Generate(buf.Bytes(), []int{32}, 80)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if result.Width != 64 || len(result.Variants) != 0 {
+		t.Fatalf("animated gif should not produce variants, got %+v", result)
+	}
+}
+
+func TestGenerateRejectsUnsupported(t *testing.T) {
+	if _, err := Generate([]byte("<svg></svg>"), []int{320}, 80); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestResizeAveragesArea(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := range 2 {
+		for x := range 4 {
+			v := uint8(0)
+			if x%2 == 1 {
+				v = 200
+			}
+			src.SetRGBA(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+
+	dst := Resize(src, 2)
+	if dst.Bounds().Dx() != 2 || dst.Bounds().Dy() != 1 {
+		t.Fatalf("unexpected size %v", dst.Bounds())
+	}
+	if got := dst.RGBAAt(0, 0); got.R != 100 || got.A != 255 {
+		t.Fatalf("expected averaged pixel, got %+v", got)
+	}
+}
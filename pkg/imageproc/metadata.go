@@ -0,0 +1,335 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+)
+
+// ErrMalformed 图片结构损坏，无法安全地去除元数据
+var ErrMalformed = errors.New("imageproc: malformed image")
+
+// StripMetadata 在不重新编码像素的前提下去除 EXIF、XMP、IPTC、注释等元数据。
+// JPEG 的方向信息会以只含 Orientation 的最小 EXIF 保留，避免手机照片被转正后显示错误；
+// 颜色相关的数据（ICC、gAMA、sRGB 等）保留。不支持的类型原样返回
+func StripMetadata(mime string, content []byte) ([]byte, error) {
+	switch mime {
+	case "image/jpeg":
+		return stripJPEG(content)
+	case "image/png":
+		return stripPNG(content)
+	case "image/gif":
+		return stripGIF(content)
+	case "image/webp":
+		return stripWebP(content)
+	default:
+		return content, nil
+	}
+}
+
+// Dimensions 读取图片宽高，不解码像素。SVG 等无法确定尺寸的类型返回 0
+func Dimensions(mime string, content []byte) (int, int, error) {
+	switch mime {
+	case "image/jpeg", "image/png", "image/gif":
+		config, _, err := image.DecodeConfig(bytes.NewReader(content))
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+		return config.Width, config.Height, nil
+	case "image/webp":
+		return webPDimensions(content)
+	default:
+		return 0, 0, nil
+	}
+}
+
+// stripJPEG 删除 APP1（EXIF / XMP）、APP13（IPTC）与 COM 段，其余段与熵编码数据原样保留
+func stripJPEG(content []byte) ([]byte, error) {
+	if len(content) < 4 || content[0] != 0xFF || content[1] != 0xD8 {
+		return nil, ErrMalformed
+	}
+	out := make([]byte, 0, len(content))
+	out = append(out, 0xFF, 0xD8)
+	orientation, exifAt := 0, 0
+	pos := 2
+	for {
+		// 段之间允许出现填充的 0xFF
+		for pos < len(content) && content[pos] == 0xFF && pos+1 < len(content) && content[pos+1] == 0xFF {
+			pos++
+		}
+		if pos+4 > len(content) || content[pos] != 0xFF {
+			return nil, ErrMalformed
+		}
+		marker := content[pos+1]
+		if marker == 0xDA {
+			// SOS 之后是熵编码数据直到 EOI，整体保留
+			out = append(out, content[pos:]...)
+			if orientation > 1 {
+				// 放回原 EXIF 段所在的位置，保持 APP1 紧跟 SOI / APP0
+				out = append(out[:exifAt], append(orientationEXIF(orientation), out[exifAt:]...)...)
+			}
+			return out, nil
+		}
+		length := int(binary.BigEndian.Uint16(content[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(content) {
+			return nil, ErrMalformed
+		}
+		segment := content[pos:end]
+		switch {
+		case marker == 0xE1:
+			if o := exifOrientation(segment[4:]); o > 0 {
+				orientation, exifAt = o, len(out)
+			}
+		case marker == 0xED || marker == 0xFE:
+		default:
+			out = append(out, segment...)
+		}
+		pos = end
+	}
+}
+
+// exifOrientation 从 APP1 段内容中读取 Orientation 标签，非 EXIF 或读取失败时返回 0
+func exifOrientation(payload []byte) int {
+	if !bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+		return 0
+	}
+	tiff := payload[6:]
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	offset := int(order.Uint32(tiff[4:]))
+	if offset+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := range count {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// orientationEXIF 生成只包含 Orientation 标签的 APP1 段
+func orientationEXIF(orientation int) []byte {
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	segment = append(segment, "Exif\x00\x00"...)
+	segment = append(segment, 'M', 'M', 0, 42, 0, 0, 0, 8)  // 大端 TIFF 头，IFD0 位于偏移 8
+	segment = append(segment, 0, 1)                         // 1 个条目
+	segment = append(segment, 0x01, 0x12, 0, 3, 0, 0, 0, 1) // Orientation, SHORT, count 1
+	segment = append(segment, 0, byte(orientation), 0, 0)
+	segment = append(segment, 0, 0, 0, 0) // 没有下一个 IFD
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(segment)-2))
+	return segment
+}
+
+// pngDroppedChunks 文本、EXIF 与修改时间块
+var pngDroppedChunks = map[string]bool{
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"eXIf": true,
+	"tIME": true,
+}
+
+func stripPNG(content []byte) ([]byte, error) {
+	signature := []byte("\x89PNG\r\n\x1a\n")
+	if !bytes.HasPrefix(content, signature) {
+		return nil, ErrMalformed
+	}
+	out := make([]byte, 0, len(content))
+	out = append(out, signature...)
+	pos := len(signature)
+	for pos < len(content) {
+		if pos+12 > len(content) {
+			return nil, ErrMalformed
+		}
+		length := int(binary.BigEndian.Uint32(content[pos:]))
+		end := pos + 12 + length
+		if end > len(content) {
+			return nil, ErrMalformed
+		}
+		chunkType := string(content[pos+4 : pos+8])
+		if crc32.ChecksumIEEE(content[pos+4:end-4]) != binary.BigEndian.Uint32(content[end-4:]) {
+			return nil, ErrMalformed
+		}
+		if !pngDroppedChunks[chunkType] {
+			out = append(out, content[pos:end]...)
+		}
+		pos = end
+		if chunkType == "IEND" {
+			return out, nil
+		}
+	}
+	return nil, ErrMalformed
+}
+
+// stripGIF 删除注释扩展与除循环播放控制外的应用扩展（XMP 存放在应用扩展中）
+func stripGIF(content []byte) ([]byte, error) {
+	if len(content) < 13 || (!bytes.HasPrefix(content, []byte("GIF87a")) && !bytes.HasPrefix(content, []byte("GIF89a"))) {
+		return nil, ErrMalformed
+	}
+	pos := 13
+	if flags := content[10]; flags&0x80 != 0 {
+		pos += 3 << ((flags & 0x07) + 1)
+	}
+	if pos > len(content) {
+		return nil, ErrMalformed
+	}
+	out := make([]byte, 0, len(content))
+	out = append(out, content[:pos]...)
+
+	for pos < len(content) {
+		switch content[pos] {
+		case 0x3B:
+			return append(out, 0x3B), nil
+		case 0x21:
+			if pos+2 > len(content) {
+				return nil, ErrMalformed
+			}
+			label := content[pos+1]
+			end, err := gifSubBlocksEnd(content, pos+2)
+			if err != nil {
+				return nil, err
+			}
+			keep := label != 0xFE
+			if label == 0xFF {
+				keep = gifLoopExtension(content[pos+2 : end])
+			}
+			if keep {
+				out = append(out, content[pos:end]...)
+			}
+			pos = end
+		case 0x2C:
+			start := pos
+			if pos+10 > len(content) {
+				return nil, ErrMalformed
+			}
+			flags := content[pos+9]
+			pos += 10
+			if flags&0x80 != 0 {
+				pos += 3 << ((flags & 0x07) + 1)
+			}
+			// LZW 最小码长之后是图像数据子块
+			end, err := gifSubBlocksEnd(content, pos+1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, content[start:end]...)
+			pos = end
+		default:
+			return nil, ErrMalformed
+		}
+	}
+	// 部分编码器不写结尾标记，补上即可
+	return append(out, 0x3B), nil
+}
+
+// gifSubBlocksEnd 跳过以长度 0 结尾的子块序列，返回结束位置
+func gifSubBlocksEnd(content []byte, pos int) (int, error) {
+	for {
+		if pos >= len(content) {
+			return 0, ErrMalformed
+		}
+		size := int(content[pos])
+		pos++
+		if size == 0 {
+			return pos, nil
+		}
+		pos += size
+	}
+}
+
+func gifLoopExtension(blocks []byte) bool {
+	if len(blocks) < 12 || blocks[0] != 11 {
+		return false
+	}
+	identifier := string(blocks[1:12])
+	return identifier == "NETSCAPE2.0" || identifier == "ANIMEXTS1.0"
+}
+
+// stripWebP 删除 EXIF 与 XMP 块，并清除 VP8X 头中对应的标志位
+func stripWebP(content []byte) ([]byte, error) {
+	if len(content) < 12 || string(content[:4]) != "RIFF" || string(content[8:12]) != "WEBP" {
+		return nil, ErrMalformed
+	}
+	out := make([]byte, 0, len(content))
+	out = append(out, content[:12]...)
+	pos := 12
+	for pos < len(content) {
+		if pos+8 > len(content) {
+			return nil, ErrMalformed
+		}
+		fourCC := string(content[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(content[pos+4:]))
+		end := pos + 8 + size + size&1
+		if end > len(content) {
+			return nil, ErrMalformed
+		}
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte(nil), content[pos:end]...)
+			if len(chunk) > 8 {
+				chunk[8] &^= 0x08 | 0x04
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, content[pos:end]...)
+		}
+		pos = end
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// webPDimensions 按 VP8X / VP8 / VP8L 头读取画布尺寸
+func webPDimensions(content []byte) (int, int, error) {
+	if len(content) < 30 || string(content[:4]) != "RIFF" || string(content[8:12]) != "WEBP" {
+		return 0, 0, ErrMalformed
+	}
+	chunk := content[12:]
+	switch string(chunk[:4]) {
+	case "VP8X":
+		width := int(chunk[12]) | int(chunk[13])<<8 | int(chunk[14])<<16
+		height := int(chunk[15]) | int(chunk[16])<<8 | int(chunk[17])<<16
+		return width + 1, height + 1, nil
+	case "VP8 ":
+		// 帧头：3 字节帧标签 + 3 字节起始码，之后是 14 位宽高
+		if chunk[11] != 0x9D || chunk[12] != 0x01 || chunk[13] != 0x2A {
+			return 0, 0, ErrMalformed
+		}
+		width := int(binary.LittleEndian.Uint16(chunk[14:])) & 0x3FFF
+		height := int(binary.LittleEndian.Uint16(chunk[16:])) & 0x3FFF
+		return width, height, nil
+	case "VP8L":
+		if chunk[8] != 0x2F {
+			return 0, 0, ErrMalformed
+		}
+		bits := binary.LittleEndian.Uint32(chunk[9:])
+		return int(bits&0x3FFF) + 1, int((bits>>14)&0x3FFF) + 1, nil
+	default:
+		return 0, 0, ErrMalformed
+	}
+}
@@ -0,0 +1,172 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"testing"
+)
+
+// exifSegment 构造含 Make 与 Orientation 两个标签的小端 EXIF APP1 段
+func exifSegment(orientation uint16) []byte {
+	tiff := []byte{'I', 'I', 42, 0, 8, 0, 0, 0, 2, 0}
+	entry := make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry, 0x010F)
+	binary.LittleEndian.PutUint16(entry[2:], 2)
+	binary.LittleEndian.PutUint32(entry[4:], 4)
+	copy(entry[8:], "Cam\x00")
+	tiff = append(tiff, entry...)
+	entry = make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry, 0x0112)
+	binary.LittleEndian.PutUint16(entry[2:], 3)
+	binary.LittleEndian.PutUint32(entry[4:], 1)
+	binary.LittleEndian.PutUint16(entry[8:], orientation)
+	tiff = append(tiff, entry...)
+	tiff = append(tiff, 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+func TestStripJPEGKeepsOrientationOnly(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 16, 8)), nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+	encoded := buf.Bytes()
+	comment := []byte{0xFF, 0xFE, 0, 7, 'h', 'e', 'l', 'l', 'o'}
+	content := append([]byte{0xFF, 0xD8}, exifSegment(6)...)
+	content = append(content, comment...)
+	content = append(content, encoded[2:]...)
+
+	stripped, err := StripMetadata("image/jpeg", content)
+	if err != nil {
+		t.Fatalf("strip jpeg: %v", err)
+	}
+	if bytes.Contains(stripped, []byte("Cam")) || bytes.Contains(stripped, []byte("hello")) {
+		t.Fatalf("metadata should be removed")
+	}
+	if got := exifOrientation(stripped[6:]); got != 6 {
+		t.Fatalf("expected orientation 6 to be kept, got %d", got)
+	}
+	if width, height, err := Dimensions("image/jpeg", stripped); err != nil || width != 16 || height != 8 {
+		t.Fatalf("unexpected dimensions %dx%d: %v", width, height, err)
+	}
+
+	// 方向为 1 时不需要保留 EXIF，结果应与未加元数据的编码完全一致
+	content = append(append([]byte{0xFF, 0xD8}, exifSegment(1)...), encoded[2:]...)
+	if stripped, err = StripMetadata("image/jpeg", content); err != nil || !bytes.Equal(stripped, encoded) {
+		t.Fatalf("expected original encoding, err %v", err)
+	}
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	copy(chunk[4:], chunkType)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+func TestStripPNGRemovesTextChunks(t *testing.T) {
+	encoded := encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 4, 3)))
+	// IHDR 固定位于签名之后，长度 25 字节
+	headerEnd := 8 + 25
+	content := append([]byte(nil), encoded[:headerEnd]...)
+	content = append(content, pngChunk("tEXt", []byte("Author\x00someone"))...)
+	content = append(content, encoded[headerEnd:]...)
+
+	stripped, err := StripMetadata("image/png", content)
+	if err != nil {
+		t.Fatalf("strip png: %v", err)
+	}
+	if !bytes.Equal(stripped, encoded) {
+		t.Fatalf("text chunk should be removed")
+	}
+
+	content[headerEnd+20] ^= 0xFF
+	if _, err = StripMetadata("image/png", content); err == nil {
+		t.Fatalf("expected crc mismatch to be rejected")
+	}
+}
+
+func TestStripGIFRemovesComments(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	var buf bytes.Buffer
+	if err := gif.Encode(&buf, image.NewPaletted(image.Rect(0, 0, 5, 2), palette), nil); err != nil {
+		t.Fatalf("encode gif: %v", err)
+	}
+	encoded := buf.Bytes()
+	// 全局调色板 2 色，头部 13 字节 + 6 字节调色板
+	headerEnd := 13 + 6
+	content := append([]byte(nil), encoded[:headerEnd]...)
+	content = append(content, 0x21, 0xFE, 5, 'h', 'e', 'l', 'l', 'o', 0)
+	content = append(content, encoded[headerEnd:]...)
+
+	stripped, err := StripMetadata("image/gif", content)
+	if err != nil {
+		t.Fatalf("strip gif: %v", err)
+	}
+	if !bytes.Equal(stripped, encoded) {
+		t.Fatalf("comment extension should be removed")
+	}
+}
+
+func webPChunk(fourCC string, data []byte) []byte {
+	chunk := make([]byte, 8, 9+len(data))
+	copy(chunk, fourCC)
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(len(data)))
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+func TestStripWebPAndDimensions(t *testing.T) {
+	vp8x := make([]byte, 10)
+	vp8x[0] = 0x08 | 0x10
+	vp8x[4], vp8x[7] = 99, 49 // 画布 100x50
+	lossless := []byte{0x2F}
+	lossless = binary.LittleEndian.AppendUint32(lossless, uint32(99)|uint32(49)<<14)
+
+	body := []byte("WEBP")
+	body = append(body, webPChunk("VP8X", vp8x)...)
+	body = append(body, webPChunk("VP8L", lossless)...)
+	body = append(body, webPChunk("EXIF", []byte("secret"))...)
+	content := binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(body)))
+	content = append(content, body...)
+
+	stripped, err := StripMetadata("image/webp", content)
+	if err != nil {
+		t.Fatalf("strip webp: %v", err)
+	}
+	if bytes.Contains(stripped, []byte("secret")) {
+		t.Fatalf("exif chunk should be removed")
+	}
+	if flags := stripped[20]; flags != 0x10 {
+		t.Fatalf("expected only alpha flag left, got %#x", flags)
+	}
+	if size := binary.LittleEndian.Uint32(stripped[4:]); int(size) != len(stripped)-8 {
+		t.Fatalf("riff size not updated: %d", size)
+	}
+	if width, height, err := Dimensions("image/webp", stripped); err != nil || width != 100 || height != 50 {
+		t.Fatalf("unexpected dimensions %dx%d: %v", width, height, err)
+	}
+}
+
+func TestStripMetadataRejectsTruncated(t *testing.T) {
+	encoded := encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 4, 4)))
+	if _, err := StripMetadata("image/png", encoded[:len(encoded)-6]); err == nil {
+		t.Fatalf("expected truncated png to be rejected")
+	}
+	if stripped, err := StripMetadata("image/svg+xml", []byte("<svg/>")); err != nil || string(stripped) != "<svg/>" {
+		t.Fatalf("svg should be returned as is")
+	}
+}
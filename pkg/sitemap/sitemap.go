@@ -10,6 +10,7 @@ import (
 )
 
 // RefreshArticles 异步通知 portal-web 清理给定文章关联的 sitemap 缓存。
+// 入参为文章 ID 或 slug，设置过 slug 的文章应同时传入 ID 与新旧 slug。
 func (c *Client) RefreshArticles(articleIDs ...string) {
 	if !c.enabled() || len(articleIDs) == 0 {
 		return
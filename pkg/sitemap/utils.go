@@ -5,7 +5,7 @@ import "strings"
 // articleDetailPathPrefix 文章详情前端路由段，与 portal-web 路由保持一致。
 const articleDetailPathPrefix = "/article-detail/"
 
-// articleDetailPath 把文章 ID 拼成 portal-web 可识别的详情页路径。
+// articleDetailPath 把文章 ID 或 slug 拼成 portal-web 可识别的详情页路径。
 func articleDetailPath(articleID string) string {
 	if articleID == "" {
 		return ""
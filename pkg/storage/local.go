@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"meta-api/config"
+)
+
+// Local 把图片保存在本地磁盘，由 gin 在 RoutePrefix 下提供静态访问，供本地开发使用
+type Local struct {
+	urlMapper
+	root        string
+	routePrefix string
+}
+
+// NewLocal 创建本地磁盘存储。root 为空时返回不可用的实例
+func NewLocal(cfg config.ArticleImageLocalConfig, logger *zap.Logger) *Local {
+	root := strings.TrimSpace(cfg.Root)
+	routePrefix := "/" + strings.Trim(strings.TrimSpace(cfg.RoutePrefix), "/")
+	if routePrefix == "/" {
+		routePrefix = "/static/images"
+	}
+	if root == "" {
+		logger.Warn("article image local storage disabled: root missing")
+		return &Local{}
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		logger.Warn("article image local storage disabled: create root failed", zap.Error(err))
+		return &Local{}
+	}
+	publicBaseURL := strings.TrimSpace(cfg.PublicBaseURL)
+	if publicBaseURL == "" {
+		publicBaseURL = routePrefix
+	}
+	return &Local{
+		urlMapper:   newURLMapper("", publicBaseURL),
+		root:        root,
+		routePrefix: routePrefix,
+	}
+}
+
+// Root 本地存储目录，为空表示未启用
+func (l *Local) Root() string {
+	return l.root
+}
+
+// RoutePrefix 静态文件路由前缀
+func (l *Local) RoutePrefix() string {
+	return l.routePrefix
+}
+
+func (l *Local) Upload(ctx context.Context, objectName string, content []byte, _ string) (string, error) {
+	if l == nil || l.root == "" {
+		return "", ErrDisabled
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	objectKey := l.ObjectKey(objectName)
+	target, err := l.filePath(objectKey)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", fmt.Errorf("create article image directory: %w", err)
+	}
+	// 先写临时文件再重命名，读取方不会看到写了一半的图片
+	temp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("create article image temp file: %w", err)
+	}
+	defer os.Remove(temp.Name())
+	if _, err = temp.Write(content); err != nil {
+		temp.Close()
+		return "", fmt.Errorf("write article image: %w", err)
+	}
+	if err = temp.Close(); err != nil {
+		return "", fmt.Errorf("write article image: %w", err)
+	}
+	if err = os.Chmod(temp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("chmod article image: %w", err)
+	}
+	if err = os.Rename(temp.Name(), target); err != nil {
+		return "", fmt.Errorf("save article image: %w", err)
+	}
+	return l.PublicURL(objectKey), nil
+}
+
+func (l *Local) Delete(_ context.Context, objectKey string) error {
+	if l == nil || l.root == "" {
+		return ErrDisabled
+	}
+	target, err := l.filePath(objectKey)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("delete article image from local storage: %w", err)
+	}
+	return nil
+}
+
+func (l *Local) Download(_ context.Context, objectKey string, maxSize int64) ([]byte, error) {
+	if l == nil || l.root == "" {
+		return nil, ErrDisabled
+	}
+	target, err := l.filePath(objectKey)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(target)
+	if err != nil {
+		return nil, fmt.Errorf("open article image from local storage: %w", err)
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read article image from local storage: %w", err)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("article image exceeds %d bytes", maxSize)
+	}
+	return content, nil
+}
+
+func (l *Local) ObjectKeyFromPublicURL(rawURL string) (string, bool) {
+	if l == nil || l.root == "" {
+		return "", false
+	}
+	return l.urlMapper.ObjectKeyFromPublicURL(rawURL)
+}
+
+func (l *Local) filePath(objectKey string) (string, error) {
+	key := cleanKey(objectKey)
+	if key == "" || key == "." {
+		return "", fmt.Errorf("empty object key")
+	}
+	return filepath.Join(l.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,261 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"meta-api/common/env"
+	"meta-api/common/utils"
+	"meta-api/config"
+)
+
+const s3Timeout = 15 * time.Second
+
+// S3 兼容 S3 协议的对象存储（AWS S3、MinIO 等），请求按 Signature V4 签名
+type S3 struct {
+	urlMapper
+	httpClient *http.Client
+	endpoint   *url.URL
+	bucket     string
+	region     string
+	pathStyle  bool
+	accessKey  string
+	secretKey  string
+	now        func() time.Time
+}
+
+// NewS3 创建 S3 兼容存储，密钥读取自环境变量或 secret 文件。缺少必要配置时返回不可用的实例
+func NewS3(cfg config.ArticleImageS3Config, logger *zap.Logger) *S3 {
+	accessKey, err := utils.EnvOrFile(env.S3AccessKeyID)
+	if err != nil {
+		logger.Warn("article image S3 disabled: access key file read failed", zap.Error(err))
+		return &S3{}
+	}
+	secretKey, err := utils.EnvOrFile(env.S3SecretAccessKey)
+	if err != nil {
+		logger.Warn("article image S3 disabled: secret key file read failed", zap.Error(err))
+		return &S3{}
+	}
+	endpointValue := strings.TrimSpace(cfg.Endpoint)
+	bucket := strings.TrimSpace(cfg.Bucket)
+	if endpointValue == "" || bucket == "" || accessKey == "" || secretKey == "" {
+		logger.Warn("article image S3 disabled: required config missing",
+			zap.Bool("endpoint_loaded", endpointValue != ""),
+			zap.Bool("bucket_loaded", bucket != ""),
+			zap.Bool("access_key_loaded", accessKey != ""),
+			zap.Bool("secret_key_loaded", secretKey != ""))
+		return &S3{}
+	}
+	endpoint, err := url.Parse(strings.TrimRight(endpointValue, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		logger.Warn("article image S3 disabled: invalid endpoint", zap.String("endpoint", endpointValue))
+		return &S3{}
+	}
+	region := strings.TrimSpace(cfg.Region)
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	client := &S3{
+		httpClient: &http.Client{Timeout: s3Timeout},
+		endpoint:   endpoint,
+		bucket:     bucket,
+		region:     region,
+		pathStyle:  cfg.PathStyle,
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		now:        time.Now,
+	}
+	publicBaseURL := strings.TrimSpace(cfg.PublicBaseURL)
+	if publicBaseURL == "" {
+		// 未配置 CDN 时直接使用桶地址，目录拼在地址中
+		publicBaseURL = client.objectURL(strings.Trim(strings.TrimSpace(cfg.Directory), "/")).String()
+	}
+	client.urlMapper = newURLMapper(cfg.Directory, publicBaseURL)
+	return client
+}
+
+func (s *S3) Upload(ctx context.Context, objectName string, content []byte, contentType string) (string, error) {
+	if s == nil || s.httpClient == nil {
+		return "", ErrDisabled
+	}
+	objectKey := s.ObjectKey(objectName)
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(ctx, http.MethodPut, objectKey, header, content)
+	if err != nil {
+		return "", fmt.Errorf("upload article image to S3: %w", err)
+	}
+	resp.Body.Close()
+	return s.PublicURL(objectKey), nil
+}
+
+func (s *S3) Delete(ctx context.Context, objectKey string) error {
+	if s == nil || s.httpClient == nil {
+		return ErrDisabled
+	}
+	key := cleanKey(objectKey)
+	if key == "" || key == "." {
+		return fmt.Errorf("empty object key")
+	}
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete article image from S3: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3) Download(ctx context.Context, objectKey string, maxSize int64) ([]byte, error) {
+	if s == nil || s.httpClient == nil {
+		return nil, ErrDisabled
+	}
+	key := cleanKey(objectKey)
+	if key == "" || key == "." {
+		return nil, fmt.Errorf("empty object key")
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("download article image from S3: %w", err)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read article image from S3: %w", err)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("article image exceeds %d bytes", maxSize)
+	}
+	return content, nil
+}
+
+func (s *S3) ObjectKeyFromPublicURL(rawURL string) (string, bool) {
+	if s == nil || s.httpClient == nil {
+		return "", false
+	}
+	return s.urlMapper.ObjectKeyFromPublicURL(rawURL)
+}
+
+// objectURL 桶内对象地址：path style 为 endpoint/bucket/key，否则为 bucket.endpoint/key
+func (s *S3) objectURL(objectKey string) *url.URL {
+	target := *s.endpoint
+	if s.pathStyle {
+		target.Path = strings.TrimRight(target.Path, "/") + "/" + s.bucket + "/" + objectKey
+	} else {
+		target.Host = s.bucket + "." + target.Host
+		target.Path = strings.TrimRight(target.Path, "/") + "/" + objectKey
+	}
+	target.RawPath = ""
+	return &target
+}
+
+func (s *S3) do(ctx context.Context, method string, objectKey string, header http.Header,
+	body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(objectKey).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.ContentLength = int64(len(body))
+	signS3Request(req, body, s.accessKey, s.secretKey, s.region, s.now().UTC())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	// 删除不存在的对象返回 204，视为成功
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("s3 %s %s: status %d: %s", method, objectKey, resp.StatusCode,
+			strings.TrimSpace(string(message)))
+	}
+	return resp, nil
+}
+
+// signS3Request 按 AWS Signature V4 为请求添加 Authorization，Host 与全部已设置的请求头参与签名
+func signS3Request(req *http.Request, body []byte, accessKey string, secretKey string, region string,
+	now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// s3EscapePath 按 RFC 3986 编码路径，保留 /
+func s3EscapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
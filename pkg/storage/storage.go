@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrDisabled 存储后端缺少必要配置，上传、下载、删除均不可用
+var ErrDisabled = errors.New("article image storage is not configured")
+
+// 存储后端类型，对应 article_image.backend
+const (
+	BackendCOS   = "cos"
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ObjectStore 文章图片对象存储。ObjectKey 是对象在存储中的完整 Key，
+// objectName 是业务生成的文件名，由实现按目录配置拼成 ObjectKey
+type ObjectStore interface {
+	// Upload 上传对象并返回公开访问地址
+	Upload(ctx context.Context, objectName string, content []byte, contentType string) (string, error)
+	Delete(ctx context.Context, objectKey string) error
+	// Download 读取对象内容，maxSize 为允许的最大字节数
+	Download(ctx context.Context, objectKey string, maxSize int64) ([]byte, error)
+	ObjectKey(objectName string) string
+	PublicURL(objectKey string) string
+	// ObjectKeyFromPublicURL 识别本存储生成的公开地址，外部链接返回 false
+	ObjectKeyFromPublicURL(rawURL string) (string, bool)
+}
+
+// urlMapper 处理对象 Key 与公开地址的互相转换，供本地与 S3 后端复用。
+// publicBaseURL 可以是绝对地址，也可以是本服务上的路径（如 /static/images）
+type urlMapper struct {
+	directory     string
+	publicBaseURL string
+}
+
+func newURLMapper(directory string, publicBaseURL string) urlMapper {
+	return urlMapper{
+		directory:     strings.Trim(strings.TrimSpace(directory), "/"),
+		publicBaseURL: strings.TrimRight(strings.TrimSpace(publicBaseURL), "/"),
+	}
+}
+
+func (m urlMapper) ObjectKey(objectName string) string {
+	name := cleanKey(objectName)
+	if m.directory == "" {
+		return name
+	}
+	return path.Join(m.directory, name)
+}
+
+// PublicURL 公开地址不包含目录前缀，目录已体现在 publicBaseURL 中
+func (m urlMapper) PublicURL(objectKey string) string {
+	name := cleanKey(objectKey)
+	if m.directory != "" {
+		name = strings.TrimPrefix(name, m.directory+"/")
+	}
+	if parsed, err := url.Parse(m.publicBaseURL); err == nil {
+		parsed.Path = path.Join("/", parsed.Path, name)
+		parsed.RawPath = ""
+		return parsed.String()
+	}
+	return m.publicBaseURL + "/" + name
+}
+
+func (m urlMapper) ObjectKeyFromPublicURL(rawURL string) (string, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", false
+	}
+	base, err := url.Parse(m.publicBaseURL)
+	if err != nil {
+		return "", false
+	}
+	// 基础地址是绝对地址时要求同一主机；是站内路径时只接受同样不带主机的地址
+	if base.Host != "" {
+		if parsed.Host == "" || !strings.EqualFold(parsed.Host, base.Host) {
+			return "", false
+		}
+	} else if parsed.Host != "" {
+		return "", false
+	}
+
+	basePath := strings.TrimRight(base.EscapedPath(), "/")
+	imagePath := parsed.EscapedPath()
+	if basePath != "" {
+		if !strings.HasPrefix(imagePath, basePath+"/") {
+			return "", false
+		}
+		imagePath = strings.TrimPrefix(imagePath, basePath)
+	}
+	relativePath, err := url.PathUnescape(strings.TrimLeft(imagePath, "/"))
+	if err != nil || relativePath == "" {
+		return "", false
+	}
+	return m.ObjectKey(relativePath), true
+}
+
+// cleanKey 规范化对象 Key，去掉 .. 与多余的斜杠，避免越出存储目录
+func cleanKey(key string) string {
+	return strings.TrimLeft(path.Clean("/"+key), "/")
+}